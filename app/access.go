@@ -0,0 +1,85 @@
+package app
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Format of HTTP access log records, similar to Apache combined log format
+// with request duration and request ID appended.
+const accessLogFormat = `%s - - [%s] "%s %s %s" %d %d "%s" "%s" %s %s`
+
+// Wrapper of http.ResponseWriter which remembers status code and number of
+// bytes written in response.
+type accessLogWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *accessLogWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *accessLogWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// Flush sends buffered part of response to client, so that wrapped response
+// stays streamable.
+func (w *accessLogWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Middleware which writes record about each served request into access log
+// and updates HTTP metrics. Requests to health handlers and of site icon
+// are not logged. Panic is not recovered here, so that stack of it stays
+// intact for recovery middleware, which wraps this one.
+func useAccessLog(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case healthzPath, readyzPath, faviconPath:
+			handler.ServeHTTP(w, r)
+			return
+		}
+		start := time.Now()
+		aw := &accessLogWriter{ResponseWriter: w}
+		completed := false
+		defer func() {
+			// Handler panicked before writing response,
+			// so outer recovery responds with 500
+			if !completed && aw.status == 0 {
+				aw.status = http.StatusInternalServerError
+			}
+			host, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				host = r.RemoteAddr
+			}
+			if aw.status == 0 {
+				aw.status = http.StatusOK
+			}
+			duration := time.Since(start)
+			httpRequestsTotal.Inc(strconv.Itoa(aw.status))
+			httpRequestDuration.Observe(duration.Seconds())
+			accessLog.Info(accessLogFormat,
+				host, start.Format("02/Jan/2006:15:04:05 -0700"),
+				r.Method, r.RequestURI, r.Proto, aw.status, aw.bytes,
+				r.Referer(), r.UserAgent(), duration, requestID(r.Context()),
+			)
+		}()
+		handler.ServeHTTP(aw, r)
+		completed = true
+	})
+}
@@ -0,0 +1,359 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/parser"
+)
+
+const apiSizeofPath = "/api/sizeof"
+
+type apiSizeofRequest struct {
+	Source    string `json:"source"`
+	Arch      string `json:"arch"`
+	CacheLine uint64 `json:"cacheLine"`
+	// Size above which struct is reported as large
+	LargeSize uint64 `json:"largeSize"`
+	// Instantiations of generic types declared in source, like "Box[int64]"
+	Instantiate []string `json:"instantiate"`
+	// Type expression, like "[]byte" or "[4]Point", laid out instead of
+	// types declared in source
+	Type string `json:"type"`
+	// Size which first laid out type is expected to have, mismatch
+	// is reported in its warnings
+	ExpectedSize *uint64 `json:"expectedSize"`
+	// Layouts of types declared outside of source, like "models.User",
+	// which are used to resolve them
+	External map[string]parser.ExternalType `json:"external"`
+	// Estimate size of structs with runs of bool fields packed into bits,
+	// as C bitfields do
+	Bitfields bool `json:"bitfields"`
+}
+
+type apiLayout struct {
+	// Version of API schema, set for layout responded alone only
+	APIVersion       string       `json:"apiVersion,omitempty"`
+	Arch             string       `json:"arch,omitempty"`
+	CacheLineSize    uint64       `json:"cacheLineSize,omitempty"`
+	Name             string       `json:"name,omitempty"`
+	Type             string       `json:"type"`
+	Size             uint64       `json:"size"`
+	SizePadded       uint64       `json:"sizePadded"`
+	Align            uint64       `json:"align"`
+	NaturalAlign     uint64       `json:"naturalAlign"`
+	Offset           uint64       `json:"offset"`
+	Padding          uint64       `json:"padding"`
+	TrailingPadding  uint64       `json:"trailingPadding,omitempty"`
+	TypeParams       []string     `json:"typeParams,omitempty"`
+	FirstCacheLine   uint64       `json:"firstCacheLine"`
+	LastCacheLine    uint64       `json:"lastCacheLine"`
+	CrossesCacheLine bool         `json:"crossesCacheLine"`
+	Fields           []*apiLayout `json:"fields,omitempty"`
+	Warnings         []string     `json:"warnings,omitempty"`
+	// Layout of type is given by user in request, not computed
+	External bool `json:"external,omitempty"`
+	// Performance related properties of laid out struct
+	Properties *apiProperties `json:"properties,omitempty"`
+	// Summary of struct: number of fields, name of field of the largest
+	// alignment and total padding
+	FieldCount        int     `json:"fieldCount,omitempty"`
+	LargestAlignField string  `json:"largestAlignField,omitempty"`
+	TotalPadding      uint64  `json:"totalPadding,omitempty"`
+	PaddingPercent    float64 `json:"paddingPercent,omitempty"`
+	// Estimated layout with bool fields packed into bits, if requested
+	Bitfields *apiBitfields `json:"bitfields,omitempty"`
+	// Information about processing of request, set for layout responded
+	// alone only
+	Meta *apiMeta `json:"meta,omitempty"`
+}
+
+// Envelope of layouts of several declared types.
+type apiLayouts struct {
+	APIVersion string       `json:"apiVersion"`
+	Meta       *apiMeta     `json:"meta"`
+	Types      []*apiLayout `json:"types"`
+}
+
+type apiMeta struct {
+	InputBytes int `json:"inputBytes"`
+	// Duration of parsing and laying out types, in milliseconds
+	ParseMs float64 `json:"parseMs"`
+	Types   int     `json:"types"`
+}
+
+type apiBitfields struct {
+	Runs       []*apiBitfieldRun `json:"runs"`
+	PackedSize uint64            `json:"packedSize"`
+	Saved      uint64            `json:"saved"`
+}
+
+type apiBitfieldRun struct {
+	Fields     []string `json:"fields"`
+	Size       uint64   `json:"size"`
+	PackedSize uint64   `json:"packedSize"`
+}
+
+type apiProperties struct {
+	PowerOfTwoSize bool `json:"powerOfTwoSize"`
+	CacheLineSized bool `json:"cacheLineSized"`
+	Large          bool `json:"large"`
+}
+
+// Codes of API errors, which clients can branch on.
+const (
+	codeInvalidRequest   = "invalid_request"
+	codeMethodNotAllowed = "method_not_allowed"
+	codeUnsupportedMedia = "unsupported_media_type"
+	codeInputTooLarge    = "input_too_large"
+	codeRateLimited      = "rate_limited"
+	codeServerBusy       = "server_busy"
+	codeParseError       = "parse_error"
+	codeTypeError        = "type_error"
+	codeUnsupportedType  = "unsupported_type"
+	codeTimeout          = "timeout"
+	codeTooComplex       = "too_complex"
+	codeUnknownType      = "unknown_type"
+)
+
+// Envelope of API error responses.
+type apiError struct {
+	APIVersion string           `json:"apiVersion"`
+	Error      *apiErrorDetails `json:"error"`
+}
+
+type apiErrorDetails struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Type declaration and position of error in submitted source
+	Type   string `json:"type,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+	// Supported values, if requested one is unknown
+	Supported []string `json:"supported,omitempty"`
+}
+
+func newAPIError(code, msg string) *apiError {
+	return &apiError{
+		APIVersion: parser.APIVersion,
+		Error:      &apiErrorDetails{Code: code, Message: msg},
+	}
+}
+
+// Helper function which responds with API error of given code and message.
+func writeAPIError(w http.ResponseWriter, status int, code, msg string) {
+	writeJSON(w, status, newAPIError(code, msg))
+}
+
+// Returns HTTP status and API error of given error of computing layouts.
+// All errors of parsing submitted source are mapped to codes here.
+func newParseAPIError(err error) (int, *apiError) {
+	var (
+		tooLargeErr *inputTooLargeError
+		timeoutErr  *parseTimeoutError
+		syntaxErr   *parser.SyntaxError
+		typeErr     *parser.TypeError
+	)
+	switch {
+	case errors.As(err, &tooLargeErr):
+		return http.StatusRequestEntityTooLarge, newAPIError(codeInputTooLarge, err.Error())
+	case errors.As(err, &timeoutErr):
+		return http.StatusBadRequest, newAPIError(codeTimeout, err.Error())
+	case isTooComplex(err):
+		apiErr := newAPIError(codeTooComplex, err.Error())
+		if errors.As(err, &typeErr) {
+			apiErr.Error.Type = typeErr.Decl
+		}
+		return http.StatusRequestEntityTooLarge, apiErr
+	case errors.As(err, &syntaxErr):
+		apiErr := newAPIError(codeParseError, err.Error())
+		apiErr.Error.Type = syntaxErr.Decl
+		apiErr.Error.Line, apiErr.Error.Column = syntaxErr.Line, syntaxErr.Column
+		return http.StatusBadRequest, apiErr
+	case errors.As(err, &typeErr):
+		code := codeTypeError
+		if typeErr.Unsupported {
+			code = codeUnsupportedType
+		}
+		apiErr := newAPIError(code, err.Error())
+		apiErr.Error.Type = typeErr.Decl
+		return http.StatusBadRequest, apiErr
+	}
+	return http.StatusBadRequest, newAPIError(codeParseError, err.Error())
+}
+
+// Handler which accepts Go type source as JSON and responds with its
+// memory layout: total size, alignment and per-field offset/size/padding.
+// Source with type declarations results in array of layouts.
+// Optional "arch" field selects target architecture and "cache_line" sets
+// cache line size used to flag fields which cross cache line boundary.
+// Generic types are laid out only for instantiations given in "instantiate".
+// Given "type" expression is laid out alone, resolved with types of source.
+// Source may be given as plain text body too, with "arch" query param.
+func apiSizeofHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxInputSize))
+	if err != nil && int64(len(body)) >= maxInputSize {
+		logOversizedInput(r)
+		writeAPIError(w, http.StatusRequestEntityTooLarge, codeInputTooLarge, inputTooLargeMessage())
+		return
+	}
+	var req apiSizeofRequest
+	switch {
+	case err != nil:
+	case requestMediaType(r) == mediaTypeText:
+		// Plain text body is raw source, laid out for architecture
+		// given in query
+		req.Source, req.Arch = string(body), r.URL.Query().Get("arch")
+	default:
+		err = json.Unmarshal(body, &req)
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, codeInvalidRequest,
+			"invalid request body, reason -> "+err.Error())
+		return
+	}
+
+	arch, err := parser.LookupArch(req.Arch)
+	if err == nil {
+		arch, err = arch.WithExternalTypes(req.External)
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+	if req.CacheLine == 0 {
+		req.CacheLine = parser.DefaultCacheLineSize
+	}
+	if req.LargeSize == 0 {
+		req.LargeSize = parser.DefaultLargeSize
+	}
+	var types []*parser.NamedType
+	start := time.Now()
+	if req.Type != "" {
+		var typ *parser.TypeInfo
+		if typ, err = parseTypeExpr(r, req.Source, req.Type, arch); err == nil {
+			types = []*parser.NamedType{{Name: req.Type, Type: typ}}
+		}
+	} else {
+		types, err = parseDecls(r, req.Source, arch, req.Instantiate...)
+	}
+	observeParse(r, start, len(types), err)
+	if err != nil {
+		status, apiErr := newParseAPIError(err)
+		writeJSON(w, status, apiErr)
+		return
+	}
+	meta := &apiMeta{
+		InputBytes: len(body),
+		ParseMs:    float64(time.Since(start).Microseconds()) / 1000,
+		Types:      len(types),
+	}
+	layouts := make([]*apiLayout, len(types))
+	for i, typ := range types {
+		if typ.Type == nil {
+			layouts[i] = &apiLayout{
+				Arch:       arch.Name,
+				Name:       typ.Name,
+				Type:       "generic",
+				TypeParams: typ.TypeParams,
+			}
+			continue
+		}
+		if err = parser.AnnotateCacheLines(typ.Type, req.CacheLine); err != nil {
+			writeAPIError(w, http.StatusBadRequest, codeInvalidRequest, err.Error())
+			return
+		}
+		layouts[i] = createAPILayout(typ.Type)
+		layouts[i].Name = typ.Name
+		layouts[i].Warnings = layoutWarnings(r, typ.Type, req.ExpectedSize)
+		// Expected size applies to the first laid out type only
+		req.ExpectedSize = nil
+		layouts[i].Arch = arch.Name
+		layouts[i].CacheLineSize = req.CacheLine
+		props := parser.StructProperties(typ.Type, req.CacheLine, req.LargeSize)
+		if props != nil {
+			layouts[i].Properties = &apiProperties{
+				PowerOfTwoSize: props.PowerOfTwoSize,
+				CacheLineSized: props.CacheLineSized,
+				Large:          props.Large,
+			}
+		}
+		if req.Bitfields {
+			layouts[i].Bitfields = createAPIBitfields(parser.AnalyzeBitfields(typ.Type, arch))
+		}
+	}
+	// Single type expression results in single layout, while type
+	// declarations result in envelope of layouts named by declared types.
+	if req.Type != "" || len(layouts) == 1 && types[0].Name == "" {
+		layouts[0].APIVersion = parser.APIVersion
+		layouts[0].Meta = meta
+		writeJSON(w, http.StatusOK, layouts[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, &apiLayouts{
+		APIVersion: parser.APIVersion,
+		Meta:       meta,
+		Types:      layouts,
+	})
+}
+
+func createAPILayout(typ *parser.TypeInfo) *apiLayout {
+	layout := &apiLayout{
+		Name:            typ.FieldName,
+		Type:            typ.TypeName,
+		Size:            typ.Sizeof,
+		SizePadded:      typ.PaddedSize(),
+		Align:           typ.Alignof,
+		NaturalAlign:    typ.NaturalAlign(),
+		Offset:          typ.Offset,
+		Padding:         typ.Padding,
+		TrailingPadding: typ.TrailingPadding,
+
+		FirstCacheLine:   typ.FirstCacheLine,
+		LastCacheLine:    typ.LastCacheLine,
+		CrossesCacheLine: typ.CrossesCacheLine,
+		External:         typ.External,
+	}
+	if layout.Type == "" {
+		layout.Type = typ.Name
+	}
+	if typ.IsStruct {
+		layout.FieldCount = typ.FieldCount()
+		if largest := typ.LargestAlignField(); largest != nil {
+			layout.LargestAlignField = largest.FieldName
+		}
+		layout.TotalPadding = typ.TotalPadding()
+		layout.PaddingPercent = typ.PaddingPercent()
+	}
+	for _, field := range typ.Fields {
+		layout.Fields = append(layout.Fields, createAPILayout(field))
+	}
+	return layout
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		_ = appLog.Error("writing JSON response FAILED, reason -> %s", err.Error())
+	}
+}
+
+// Returns API representation of given bitfield note, or nil if there is none.
+func createAPIBitfields(note *parser.BitfieldNote) *apiBitfields {
+	if note == nil {
+		return nil
+	}
+	bitfields := &apiBitfields{PackedSize: note.PackedSize, Saved: note.Saved}
+	for _, run := range note.Runs {
+		apiRun := &apiBitfieldRun{Size: run.Size, PackedSize: run.PackedSize}
+		for _, field := range run.Fields {
+			apiRun.Fields = append(apiRun.Fields, field.FieldName)
+		}
+		bitfields.Runs = append(bitfields.Runs, apiRun)
+	}
+	return bitfields
+}
@@ -0,0 +1,465 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/parser"
+)
+
+func TestAPISizeofHandler(t *testing.T) {
+	appLog = &nopLogger{}
+
+	body := `{"source": "struct{a bool; b int64; c bool}"}`
+	r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	apiSizeofHandler(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("invalid status code, expected: 200, actual: %d", w.Code)
+	}
+	var layout apiLayout
+	if err := json.Unmarshal(w.Body.Bytes(), &layout); err != nil {
+		t.Fatalf("failed to decode response, reason -> %s", err.Error())
+	}
+	if layout.Size != 24 || layout.Align != 8 || layout.TrailingPadding != 7 {
+		t.Errorf("invalid struct layout: %+v", layout)
+	}
+	if layout.FieldCount != 3 || layout.LargestAlignField != "b" ||
+		layout.TotalPadding != 14 || layout.PaddingPercent != 14*100/24. {
+		t.Errorf("invalid struct summary: %+v", layout)
+	}
+	if len(layout.Fields) != 3 {
+		t.Fatalf("invalid number of fields, expected: 3, actual: %d", len(layout.Fields))
+	}
+	b := layout.Fields[1]
+	if b.Name != "b" || b.Type != "int64" || b.Offset != 8 || b.Padding != 7 {
+		t.Errorf("invalid layout of field 'b': %+v", b)
+	}
+
+	body = `{"source": "struct{a bool; b int64; c bool}", "arch": "386"}`
+	r = httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+	w = httptest.NewRecorder()
+	apiSizeofHandler(w, r)
+	layout = apiLayout{}
+	if err := json.Unmarshal(w.Body.Bytes(), &layout); err != nil {
+		t.Fatalf("failed to decode response, reason -> %s", err.Error())
+	}
+	if layout.Arch != "386" || layout.Size != 16 || layout.Align != 4 {
+		t.Errorf("invalid struct layout on 386: %+v", layout)
+	}
+
+	body = `{"source": "struct{a [6]byte; b int64}", "cacheLine": 8}`
+	r = httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+	w = httptest.NewRecorder()
+	apiSizeofHandler(w, r)
+	layout = apiLayout{}
+	if err := json.Unmarshal(w.Body.Bytes(), &layout); err != nil {
+		t.Fatalf("failed to decode response, reason -> %s", err.Error())
+	}
+	if layout.CacheLineSize != 8 || !layout.CrossesCacheLine ||
+		layout.Fields[1].FirstCacheLine != 1 || layout.Fields[1].CrossesCacheLine {
+		t.Errorf("invalid cache lines of struct: %+v", layout)
+	}
+
+	body = `{"source": "type A struct{a bool}\ntype B struct{a A; b int32}"}`
+	r = httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+	w = httptest.NewRecorder()
+	apiSizeofHandler(w, r)
+	var envelope apiLayouts
+	if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("failed to decode response, reason -> %s", err.Error())
+	}
+	layouts := envelope.Types
+	if len(layouts) != 2 || layouts[0].Name != "A" || layouts[1].Name != "B" ||
+		layouts[1].Size != 8 {
+		t.Errorf("invalid layouts of declared types: %s", w.Body.String())
+	}
+
+	for _, c := range []struct {
+		method string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "{", http.StatusBadRequest},
+		{http.MethodPost, `{"source": "struct{"}`, http.StatusBadRequest},
+		{http.MethodPost, `{"source": "int", "arch": "pdp11"}`, http.StatusBadRequest},
+	} {
+		r := httptest.NewRequest(c.method, apiSizeofPath, strings.NewReader(c.body))
+		w := httptest.NewRecorder()
+		useAPIMethods(http.HandlerFunc(apiSizeofHandler), http.MethodPost).ServeHTTP(w, r)
+		if w.Code != c.status {
+			t.Errorf("%s %q: invalid status code, expected: %d, actual: %d",
+				c.method, c.body, c.status, w.Code)
+		}
+	}
+}
+
+func TestAPISizeofLimits(t *testing.T) {
+	appLog = &nopLogger{}
+	defer func(size int64, timeout time.Duration) {
+		maxInputSize, parseTimeout = size, timeout
+	}(maxInputSize, parseTimeout)
+
+	maxInputSize = 32
+	body := `{"source": "struct{a bool; b int64; c bool}"}`
+	r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	apiSizeofHandler(w, r)
+	if w.Code != http.StatusRequestEntityTooLarge ||
+		!strings.Contains(w.Body.String(), "32 bytes") {
+		t.Errorf("invalid response for oversized input: %d %s", w.Code, w.Body.String())
+	}
+
+	maxInputSize, parseTimeout = 1024, 0
+	r = httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+	w = httptest.NewRecorder()
+	apiSizeofHandler(w, r)
+	if w.Code != http.StatusBadRequest ||
+		!strings.Contains(w.Body.String(), "parsing took longer") {
+		t.Errorf("invalid response for parse timeout: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAPISizeofSyntaxError(t *testing.T) {
+	appLog = &nopLogger{}
+	body := `{"source": "type A struct{\n\ta bool\n\tb int64 c\n}"}`
+	r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	apiSizeofHandler(w, r)
+	var apiErr apiError
+	if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil {
+		t.Fatalf("failed to decode response, reason -> %s", err.Error())
+	}
+	if w.Code != http.StatusBadRequest || apiErr.Error == nil ||
+		apiErr.Error.Code != codeParseError || apiErr.Error.Type != "A" ||
+		apiErr.Error.Line != 3 || apiErr.Error.Column != 10 {
+		t.Errorf("invalid syntax error response: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAPISizeofGenerics(t *testing.T) {
+	appLog = &nopLogger{}
+	body := `{"source": "type Box[T any] struct{ ok bool; v T }", "instantiate": ["Box[int16]", "Box[int64]"]}`
+	r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	apiSizeofHandler(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("invalid status code, expected: 200, actual: %d", w.Code)
+	}
+	var envelope apiLayouts
+	if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("failed to decode response, reason -> %s", err.Error())
+	}
+	layouts := envelope.Types
+	if len(layouts) != 3 {
+		t.Fatalf("invalid number of layouts, expected: 3, actual: %d", len(layouts))
+	}
+	if layouts[0].Type != "generic" || len(layouts[0].TypeParams) != 1 {
+		t.Errorf("invalid layout of generic type: %+v", layouts[0])
+	}
+	if layouts[1].Name != "Box[int16]" || layouts[1].Size != 4 ||
+		layouts[2].Name != "Box[int64]" || layouts[2].Size != 16 {
+		t.Errorf("invalid layouts of instantiations: %+v, %+v", layouts[1], layouts[2])
+	}
+}
+
+func TestAPISizeofType(t *testing.T) {
+	appLog = &nopLogger{}
+	for body, expected := range map[string]apiLayout{
+		`{"type": "[]byte"}`:         {Name: "[]byte", Size: 24, Align: 8},
+		`{"type": "map[string]int"}`: {Name: "map[string]int", Size: 8, Align: 8},
+		`{"source": "type Point struct{X, Y int32}", "type": "[4]Point"}`: {
+			Name: "[4]Point", Size: 32, Align: 4,
+		},
+	} {
+		r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		apiSizeofHandler(w, r)
+
+		var layout apiLayout
+		if err := json.Unmarshal(w.Body.Bytes(), &layout); err != nil || w.Code != http.StatusOK {
+			t.Errorf("invalid response for '%s': %d %s", body, w.Code, w.Body.String())
+			continue
+		}
+		if layout.Name != expected.Name || layout.Size != expected.Size || layout.Align != expected.Align {
+			t.Errorf(
+				"invalid layout for '%s'\n\texpected: %s (size %d, align %d)\n\tactual: %s (size %d, align %d)",
+				body, expected.Name, expected.Size, expected.Align,
+				layout.Name, layout.Size, layout.Align,
+			)
+		}
+	}
+}
+
+func TestAPISizeofExpectedSize(t *testing.T) {
+	appLog = &nopLogger{}
+	for body, warned := range map[string]bool{
+		`{"source": "struct{a bool; b int64}", "expectedSize": 16}`:                           false,
+		`{"source": "struct{a bool; b int64}", "expectedSize": 9}`:                            true,
+		`{"source": "struct{a bool; b int64}"}`:                                               false,
+		`{"source": "struct{A bool \u0060json:\"a\"\u0060; B int64 \u0060json:\"a\"\u0060}"}`: true,
+	} {
+		r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		apiSizeofHandler(w, r)
+
+		var layout apiLayout
+		if err := json.Unmarshal(w.Body.Bytes(), &layout); err != nil || w.Code != http.StatusOK {
+			t.Errorf("invalid response for '%s': %d %s", body, w.Code, w.Body.String())
+			continue
+		}
+		if layout.Size != 16 || layout.SizePadded != 16 ||
+			layout.Align != 8 || layout.NaturalAlign != 8 {
+			t.Errorf("invalid layout for '%s': %s", body, w.Body.String())
+		}
+		if (len(layout.Warnings) > 0) != warned {
+			t.Errorf("invalid warnings for '%s': %v", body, layout.Warnings)
+		}
+	}
+}
+
+func TestAPISizeofExternalTypes(t *testing.T) {
+	appLog = &nopLogger{}
+	body := `{"source": "struct{a bool; u models.User}", "external": {"models.User": {"size": 24, "align": 8}}}`
+	r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	apiSizeofHandler(w, r)
+
+	var layout apiLayout
+	if err := json.Unmarshal(w.Body.Bytes(), &layout); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("invalid response: %d %s", w.Code, w.Body.String())
+	}
+	if layout.Size != 32 || len(layout.Fields) != 2 ||
+		layout.Fields[0].External || !layout.Fields[1].External || len(layout.Warnings) != 1 {
+		t.Errorf("invalid layout: %s", w.Body.String())
+	}
+
+	body = `{"source": "struct{u models.User}", "external": {"models.User": {"size": 24, "align": 5}}}`
+	r = httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+	w = httptest.NewRecorder()
+	apiSizeofHandler(w, r)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), codeInvalidRequest) {
+		t.Errorf("invalid external type must be rejected: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAPISizeofProperties(t *testing.T) {
+	appLog = &nopLogger{}
+	for body, expected := range map[string]*apiProperties{
+		`{"source": "struct{a bool; b int64}"}`:                    {PowerOfTwoSize: true},
+		`{"source": "struct{a [8]int64}"}`:                         {PowerOfTwoSize: true, CacheLineSized: true},
+		`{"source": "struct{a [8]int64}", "largeSize": 32}`:        {PowerOfTwoSize: true, CacheLineSized: true, Large: true},
+		`{"source": "struct{a [3]int64}", "cacheLine": 24}`:        {CacheLineSized: true},
+		`{"source": "[3]int64"}`:                                   nil,
+		`{"source": "struct{a [17]int64}", "largeSize": 136}`:      {},
+		`{"source": "struct{a [17]int64; b bool}", "arch": "386"}`: {Large: true},
+	} {
+		r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		apiSizeofHandler(w, r)
+
+		var layout apiLayout
+		if err := json.Unmarshal(w.Body.Bytes(), &layout); err != nil || w.Code != http.StatusOK {
+			t.Errorf("invalid response for '%s': %d %s", body, w.Code, w.Body.String())
+			continue
+		}
+		if (layout.Properties == nil) != (expected == nil) ||
+			expected != nil && *layout.Properties != *expected {
+			t.Errorf("invalid properties for '%s': %s", body, w.Body.String())
+		}
+	}
+}
+
+func TestAPISizeofBitfields(t *testing.T) {
+	appLog = &nopLogger{}
+	for body, expected := range map[string]*apiBitfields{
+		`{"source": "struct{a, b, c bool; x int32}", "bitfields": true}`: {
+			Runs:       []*apiBitfieldRun{{Fields: []string{"a", "b", "c"}, Size: 3, PackedSize: 1}},
+			PackedSize: 8,
+		},
+		`{"source": "struct{a, b, c bool; x int32}"}`:                      nil,
+		`{"source": "struct{a bool; x int32; b bool}", "bitfields": true}`: nil,
+	} {
+		r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		apiSizeofHandler(w, r)
+
+		var layout apiLayout
+		if err := json.Unmarshal(w.Body.Bytes(), &layout); err != nil || w.Code != http.StatusOK {
+			t.Errorf("invalid response for '%s': %d %s", body, w.Code, w.Body.String())
+			continue
+		}
+		if !reflect.DeepEqual(layout.Bitfields, expected) {
+			t.Errorf("invalid bitfields for '%s': %s", body, w.Body.String())
+		}
+	}
+}
+
+func TestAPISizeofVersion(t *testing.T) {
+	appLog = &nopLogger{}
+	for _, c := range []struct {
+		body    string
+		layouts int // 0 for single layout, -1 for error
+	}{
+		{`{"source": "struct{a bool; b int64}"}`, 0},
+		{`{"source": "type A int8\ntype B[T any] struct{v T}"}`, 2},
+		{`{"source": "struct{"}`, -1},
+	} {
+		r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(c.body))
+		w := httptest.NewRecorder()
+		apiSizeofHandler(w, r)
+
+		// Every response is object with version at the top level, which
+		// is not repeated by layouts
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Errorf("%s: response is not object: %s", c.body, w.Body.String())
+			continue
+		}
+		if version := fmt.Sprint(response["apiVersion"]); version != parser.APIVersion {
+			t.Errorf("%s: expected API version '%s', got '%s' in: %s",
+				c.body, parser.APIVersion, version, w.Body.String())
+		}
+		if strings.Count(w.Body.String(), `"apiVersion"`) != 1 {
+			t.Errorf("%s: version expected once in response: %s", c.body, w.Body.String())
+		}
+		switch c.layouts {
+		case 0:
+			if response["type"] == nil {
+				t.Errorf("%s: expected single layout, got: %s", c.body, w.Body.String())
+			}
+		case -1:
+			if response["error"] == nil {
+				t.Errorf("%s: expected error, got: %s", c.body, w.Body.String())
+			}
+		default:
+			if layouts, _ := response["types"].([]interface{}); len(layouts) != c.layouts {
+				t.Errorf("%s: expected %d layouts, got: %s", c.body, c.layouts, w.Body.String())
+			}
+		}
+	}
+}
+
+func TestAPISizeofMeta(t *testing.T) {
+	appLog = &nopLogger{}
+	body := `{"source": "type A struct{a bool; b int64}\ntype B [4]A"}`
+	r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	apiSizeofHandler(w, r)
+
+	var envelope apiLayouts
+	if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil || len(envelope.Types) != 2 {
+		t.Fatalf("expected 2 layouts, got: %s", w.Body.String())
+	}
+	meta := envelope.Meta
+	if meta == nil || meta.InputBytes != len(body) || meta.Types != 2 || meta.ParseMs < 0 {
+		t.Errorf("invalid meta of response: %+v", meta)
+	}
+	for _, layout := range envelope.Types {
+		if layout.Meta != nil {
+			t.Errorf("meta is repeated in layout %s", layout.Name)
+		}
+		for _, field := range layout.Fields {
+			if field.Meta != nil {
+				t.Errorf("meta is repeated in field %s of layout %s", field.Name, layout.Name)
+			}
+		}
+	}
+}
+
+func TestAPISizeofErrorCodes(t *testing.T) {
+	appLog = &nopLogger{}
+	defer func(size int64, timeout time.Duration) {
+		maxInputSize, parseTimeout = size, timeout
+	}(maxInputSize, parseTimeout)
+
+	nested, _ := json.Marshal(map[string]string{"source": nestedDecls(13)})
+	cases := []struct {
+		method, body string
+		size         int64
+		timeout      time.Duration
+		status       int
+		code         string
+	}{
+		{http.MethodGet, ``, 1024, time.Second, http.StatusMethodNotAllowed, codeMethodNotAllowed},
+		{http.MethodPost, `{"source": `, 1024, time.Second, http.StatusBadRequest, codeInvalidRequest},
+		{http.MethodPost, `{"source": "bool", "arch": "pdp11"}`, 1024, time.Second, http.StatusBadRequest, codeInvalidRequest},
+		{http.MethodPost, `{"source": "struct{a bool; b int64}"}`, 8, time.Second, http.StatusRequestEntityTooLarge, codeInputTooLarge},
+		{http.MethodPost, `{"source": "struct{a bool"}`, 1024, time.Second, http.StatusBadRequest, codeParseError},
+		{http.MethodPost, `{"source": "struct{a Foo}"}`, 1024, time.Second, http.StatusBadRequest, codeTypeError},
+		{http.MethodPost, `{"source": "struct{t foo.Bar}"}`, 1024, time.Second, http.StatusBadRequest, codeUnsupportedType},
+		{http.MethodPost, `{"source": "struct{a bool}"}`, 1024, 0, http.StatusBadRequest, codeTimeout},
+		{http.MethodPost, string(nested), 1024, time.Second, http.StatusRequestEntityTooLarge, codeTooComplex},
+	}
+	for _, c := range cases {
+		maxInputSize, parseTimeout = c.size, c.timeout
+		r := httptest.NewRequest(c.method, apiSizeofPath, strings.NewReader(c.body))
+		w := httptest.NewRecorder()
+		useAPIMethods(http.HandlerFunc(apiSizeofHandler), http.MethodPost).ServeHTTP(w, r)
+		var apiErr apiError
+		if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil || apiErr.Error == nil {
+			t.Errorf("invalid error response for '%s': %s", c.body, w.Body.String())
+			continue
+		}
+		if w.Code != c.status || apiErr.Error.Code != c.code || apiErr.Error.Message == "" {
+			t.Errorf(
+				"invalid error response for '%s'\n\texpected: %d %s\n\tactual: %d %s",
+				c.body, c.status, c.code, w.Code, w.Body.String(),
+			)
+		}
+	}
+}
+
+func TestAPISizeofParseDuration(t *testing.T) {
+	appLog, accessLog = &nopLogger{}, &nopLogger{}
+	defer func() { appLog, accessLog = nil, nil }()
+	handler := bindHttpHandlers(nil)
+
+	ok, failed := parseDuration.Count("ok", "2-4"), parseDuration.Count("error", "0")
+	for _, body := range []string{
+		`{"source": "type A struct{a bool}\ntype B [2]A"}`,
+		`{"source": "type A struct{"}`,
+	} {
+		r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(body))
+		r.Header.Set("Content-Type", mediaTypeJSON)
+		r.Header.Set(requestIDHeader, "parse-exemplar")
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+	}
+	if count := parseDuration.Count("ok", "2-4"); count != ok+1 {
+		t.Errorf("expected %d successful parses observed, got %d", ok+1, count)
+	}
+	if count := parseDuration.Count("error", "0"); count != failed+1 {
+		t.Errorf("expected %d failed parses observed, got %d", failed+1, count)
+	}
+
+	// Request ID is exemplar of observed duration in OpenMetrics format
+	r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	r.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if body := w.Body.String(); !strings.Contains(body, `# {request_id="parse-exemplar"}`) ||
+		!strings.HasSuffix(body, "# EOF\n") {
+		t.Errorf("exemplar of parse duration is not exposed:\n%s", body)
+	}
+}
+
+func TestParseBuckets(t *testing.T) {
+	buckets, err := parseBuckets("1ms, 10ms,1s")
+	if err != nil || !reflect.DeepEqual(buckets, []float64{.001, .01, 1}) {
+		t.Errorf("expected buckets [0.001 0.01 1], got %v (error %v)", buckets, err)
+	}
+	for _, value := range []string{"", "1ms,1ms", "10ms,1ms", "0s", "fast"} {
+		if _, err := parseBuckets(value); err == nil {
+			t.Errorf("'%s': expected error", value)
+		}
+	}
+}
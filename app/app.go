@@ -4,8 +4,15 @@ import "github.com/chappjc/go-sizeof-webapp/internal/log"
 
 var appLog log.Logger
 
+var accessLog log.Logger
+
 const DefaultHttpPort = ":7777"
 
+// Address to listen HTTP requests on when it is given neither with "-http"
+// flag nor with GOHTTP environment variable. Packages embedding application
+// can change it before Run is called.
+var DefaultHttpAddr = DefaultHttpPort
+
 // Represents simple zero-cost message that can be used
 // as signal between goroutines.
 type sig struct{}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/parser"
+)
+
+const apiAssertPath = "/api/assert"
+
+type apiAssertRequest struct {
+	Source string `json:"source"`
+	Arch   string `json:"arch"`
+	// Type expression laid out instead of types declared in source
+	Type string `json:"type"`
+	// Size which no laid out type may exceed
+	MaxSize *uint64 `json:"maxSize"`
+	// Sum of sizes of all laid out types, which must match if given
+	ExpectedTotal *uint64 `json:"expectedTotal"`
+}
+
+// Result of assertion, which is kept tiny for scripts.
+type apiAssertion struct {
+	Pass bool `json:"pass"`
+	// Size and name of the largest laid out type, and sum of sizes
+	// of all of them
+	Size    uint64 `json:"size"`
+	Largest string `json:"largest,omitempty"`
+	Total   uint64 `json:"total"`
+}
+
+// Handler which lays out submitted source and checks that no type is larger
+// than given maximal size, and that sizes of all types sum up to expected
+// total if it is given. Responds with 200 if check passes, and with 422 if it
+// fails, so that CI pipeline can be gated by "curl --fail".
+func apiAssertHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxInputSize))
+	if err != nil && int64(len(body)) >= maxInputSize {
+		logOversizedInput(r)
+		writeAPIError(w, http.StatusRequestEntityTooLarge, codeInputTooLarge, inputTooLargeMessage())
+		return
+	}
+	var req apiAssertRequest
+	if err == nil {
+		err = json.Unmarshal(body, &req)
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, codeInvalidRequest,
+			"invalid request body, reason -> "+err.Error())
+		return
+	}
+	if req.MaxSize == nil {
+		writeAPIError(w, http.StatusBadRequest, codeInvalidRequest, "'max_size' is required")
+		return
+	}
+
+	arch, err := parser.LookupArch(req.Arch)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+	var types []*parser.NamedType
+	if req.Type != "" {
+		var typ *parser.TypeInfo
+		if typ, err = parseTypeExpr(r, req.Source, req.Type, arch); err == nil {
+			types = []*parser.NamedType{{Name: req.Type, Type: typ}}
+		}
+	} else {
+		types, err = parseDecls(r, req.Source, arch)
+	}
+	if err != nil {
+		status, apiErr := newParseAPIError(err)
+		writeJSON(w, status, apiErr)
+		return
+	}
+
+	resp := &apiAssertion{}
+	var largest *parser.NamedType
+	for _, typ := range types {
+		// Generic types have no size until they are instantiated
+		if typ.Type == nil {
+			continue
+		}
+		resp.Total += typ.Type.Sizeof
+		if largest == nil || typ.Type.Sizeof > largest.Type.Sizeof {
+			largest = typ
+		}
+	}
+	if largest != nil {
+		resp.Size, resp.Largest = largest.Type.Sizeof, largest.Name
+	}
+	resp.Pass = resp.Size <= *req.MaxSize &&
+		(req.ExpectedTotal == nil || resp.Total == *req.ExpectedTotal)
+	status := http.StatusOK
+	if !resp.Pass {
+		status = http.StatusUnprocessableEntity
+	}
+	writeJSON(w, status, resp)
+}
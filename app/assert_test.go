@@ -0,0 +1,51 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIAssertHandler(t *testing.T) {
+	appLog = &nopLogger{}
+	source := `type A struct{a bool; b int64}\ntype B [3]int32`
+	for _, c := range []struct {
+		body   string
+		status int
+		resp   apiAssertion
+	}{
+		{`{"source": "` + source + `", "maxSize": 16}`, http.StatusOK,
+			apiAssertion{Pass: true, Size: 16, Largest: "A", Total: 28}},
+		{`{"source": "` + source + `", "maxSize": 12}`, http.StatusUnprocessableEntity,
+			apiAssertion{Pass: false, Size: 16, Largest: "A", Total: 28}},
+		{`{"source": "` + source + `", "maxSize": 16, "expectedTotal": 32}`, http.StatusUnprocessableEntity,
+			apiAssertion{Pass: false, Size: 16, Largest: "A", Total: 28}},
+		{`{"source": "struct{a bool; b int64}", "arch": "386", "maxSize": 12, "expectedTotal": 12}`,
+			http.StatusOK, apiAssertion{Pass: true, Size: 12, Total: 12}},
+		{`{"source": "` + source + `", "type": "[2]A", "maxSize": 32}`, http.StatusOK,
+			apiAssertion{Pass: true, Size: 32, Largest: "[2]A", Total: 32}},
+	} {
+		r := httptest.NewRequest(http.MethodPost, apiAssertPath, strings.NewReader(c.body))
+		w := httptest.NewRecorder()
+		apiAssertHandler(w, r)
+		var resp apiAssertion
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != c.status || resp != c.resp {
+			t.Errorf("%s: expected %d %+v, got %d %s", c.body, c.status, c.resp, w.Code, w.Body.String())
+		}
+	}
+
+	for _, body := range []string{
+		`{"source": "struct{a bool}"}`,
+		`{"source": "struct{", "maxSize": 8}`,
+		`{"source": "struct{a bool}", "arch": "pdp11", "maxSize": 8}`,
+	} {
+		r := httptest.NewRequest(http.MethodPost, apiAssertPath, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		apiAssertHandler(w, r)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d %s", body, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/parser"
+)
+
+const (
+	comparePath    = "/compare"
+	apiComparePath = "/api/compare"
+)
+
+var errNothingToCompare = errors.New("no type to compare")
+
+// Returns limit of compare request body, which contains two sources
+// and some overhead of their encoding.
+func compareBodyLimit() int64 {
+	return 2*maxInputSize + 1<<10
+}
+
+// Layouts of old and new versions of type with difference between them.
+type comparison struct {
+	Before *parser.NamedType
+	After  *parser.NamedType
+	Delta  *parser.LayoutDelta
+}
+
+// Parses old and new versions of type and compares their layouts.
+// First declared non-generic type of each source is compared.
+func compareSources(
+	r *http.Request,
+	before, after string,
+	arch *parser.Arch,
+) (*comparison, error) {
+	cmp := &comparison{}
+	var err error
+	if cmp.Before, err = comparedType(r, before, arch); err != nil {
+		return nil, fmt.Errorf("before: %w", err)
+	}
+	if cmp.After, err = comparedType(r, after, arch); err != nil {
+		return nil, fmt.Errorf("after: %w", err)
+	}
+	cmp.Delta = parser.CompareLayouts(cmp.Before.Type, cmp.After.Type)
+	return cmp, nil
+}
+
+// Returns first declared non-generic type of given code.
+func comparedType(
+	r *http.Request,
+	code string,
+	arch *parser.Arch,
+) (*parser.NamedType, error) {
+	if int64(len(code)) > maxInputSize {
+		logOversizedInput(r)
+		return nil, &inputTooLargeError{}
+	}
+	types, err := parseDecls(r, code, arch)
+	if err != nil {
+		return nil, err
+	}
+	for _, typ := range types {
+		if typ.Type != nil {
+			return typ, nil
+		}
+	}
+	return nil, errNothingToCompare
+}
+
+// Returns status of struct field in new version of struct:
+// "added", "removed", "shifted", "resized" or "unchanged".
+func fieldStatus(d *parser.FieldDelta) string {
+	switch {
+	case d.Added():
+		return "added"
+	case d.Removed():
+		return "removed"
+	case d.Shift != 0:
+		return "shifted"
+	case d.Resize != 0:
+		return "resized"
+	}
+	return "unchanged"
+}
+
+// Handler which renders form for old and new versions of type,
+// and their layouts side by side when form is submitted.
+func compareHandler(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, compareBodyLimit())
+	toRender := &struct {
+		Before string
+		After  string
+		Arch   string
+		Archs  []string
+		Result *comparison
+		Error  string
+	}{
+		Before: r.PostFormValue("before"),
+		After:  r.PostFormValue("after"),
+		Arch:   r.FormValue("arch"),
+		Archs:  archNames(),
+	}
+	if toRender.Arch == "" {
+		toRender.Arch = parser.DefaultArch
+	}
+	if r.Method != http.MethodPost {
+		renderTemplate(w, "compare", toRender)
+		return
+	}
+
+	arch, err := parser.LookupArch(toRender.Arch)
+	if err == nil {
+		toRender.Result, err = compareSources(r, toRender.Before, toRender.After, arch)
+	}
+	if err != nil {
+		toRender.Error = err.Error()
+	}
+	if isTooComplex(err) {
+		renderTemplateStatus(w, http.StatusRequestEntityTooLarge, "compare", toRender)
+		return
+	}
+	renderTemplate(w, "compare", toRender)
+}
+
+type apiCompareRequest struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+	Arch   string `json:"arch"`
+}
+
+type apiFieldDelta struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Shift  int64  `json:"shift"`
+	Resize int64  `json:"resize"`
+}
+
+type apiDelta struct {
+	Size    int64            `json:"size"`
+	Align   int64            `json:"align"`
+	Padding int64            `json:"padding"`
+	Fields  []*apiFieldDelta `json:"fields,omitempty"`
+}
+
+type apiComparison struct {
+	Before *apiLayout `json:"before"`
+	After  *apiLayout `json:"after"`
+	Delta  *apiDelta  `json:"delta"`
+}
+
+// Handler which accepts old and new versions of Go type as JSON and responds
+// with both layouts and difference between them. Positive values of delta
+// mean that new version is larger.
+func apiCompareHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, compareBodyLimit()))
+	if err != nil && int64(len(body)) >= compareBodyLimit() {
+		logOversizedInput(r)
+		writeAPIError(w, http.StatusRequestEntityTooLarge, codeInputTooLarge, inputTooLargeMessage())
+		return
+	}
+	var req apiCompareRequest
+	if err == nil {
+		err = json.Unmarshal(body, &req)
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, codeInvalidRequest,
+			"invalid request body, reason -> "+err.Error())
+		return
+	}
+
+	arch, err := parser.LookupArch(req.Arch)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+	cmp, err := compareSources(r, req.Before, req.After, arch)
+	if err != nil {
+		status, apiErr := newParseAPIError(err)
+		writeJSON(w, status, apiErr)
+		return
+	}
+	resp := &apiComparison{
+		Before: createAPILayout(cmp.Before.Type),
+		After:  createAPILayout(cmp.After.Type),
+		Delta: &apiDelta{
+			Size:    cmp.Delta.Size,
+			Align:   cmp.Delta.Align,
+			Padding: cmp.Delta.Padding,
+		},
+	}
+	resp.Before.Name, resp.After.Name = cmp.Before.Name, cmp.After.Name
+	resp.Before.Arch, resp.After.Arch = arch.Name, arch.Name
+	for _, d := range cmp.Delta.Fields {
+		resp.Delta.Fields = append(resp.Delta.Fields, &apiFieldDelta{
+			Name:   d.Name,
+			Status: fieldStatus(d),
+			Shift:  d.Shift,
+			Resize: d.Resize,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAPICompareHandler(t *testing.T) {
+	appLog = &nopLogger{}
+	body := `{"before": "type T struct{a bool; b int64; c bool}",` +
+		` "after": "type T struct{b int64; a, c bool; d int16}"}`
+	r := httptest.NewRequest(http.MethodPost, apiComparePath, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	apiCompareHandler(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("invalid status code, expected: 200, actual: %d\n%s", w.Code, w.Body.String())
+	}
+	var cmp apiComparison
+	if err := json.Unmarshal(w.Body.Bytes(), &cmp); err != nil {
+		t.Fatalf("failed to decode response, reason -> %s", err.Error())
+	}
+	if cmp.Before.Size != 24 || cmp.After.Size != 16 || cmp.Before.Name != "T" {
+		t.Errorf("invalid compared layouts: %+v, %+v", cmp.Before, cmp.After)
+	}
+	if cmp.Delta.Size != -8 || cmp.Delta.Padding != -10 || len(cmp.Delta.Fields) != 4 {
+		t.Fatalf("invalid delta: %+v", cmp.Delta)
+	}
+	if b := cmp.Delta.Fields[0]; b.Name != "b" || b.Status != "shifted" || b.Shift != -8 {
+		t.Errorf("invalid delta of field 'b': %+v", b)
+	}
+	if d := cmp.Delta.Fields[3]; d.Name != "d" || d.Status != "added" {
+		t.Errorf("invalid delta of field 'd': %+v", d)
+	}
+
+	body = `{"before": "struct{a bool}", "after": "struct{a bool"}`
+	r = httptest.NewRequest(http.MethodPost, apiComparePath, strings.NewReader(body))
+	w = httptest.NewRecorder()
+	apiCompareHandler(w, r)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "after: syntax error") {
+		t.Errorf("invalid response for syntax error: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCompareHandler(t *testing.T) {
+	appLog = &nopLogger{}
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	form := url.Values{
+		"before": {"struct{a bool; b int64; c bool}"},
+		"after":  {"struct{a bool; c bool; b int64}"},
+		"arch":   {"386"},
+	}
+	r := httptest.NewRequest(http.MethodPost, comparePath, strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	compareHandler(w, r)
+
+	body := w.Body.String()
+	if w.Code != http.StatusOK || !strings.Contains(body, "Size 16 &rarr; 12 (-4 bytes)") ||
+		!strings.Contains(body, `<tr class="shifted">`) {
+		t.Errorf("comparison is not rendered: %d\n%s", w.Code, body)
+	}
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Media types of API request bodies.
+const (
+	mediaTypeJSON = "application/json"
+	mediaTypeText = "text/plain"
+)
+
+// Returns media type of body of given request, without parameters like
+// charset, or empty string if it is not given or is malformed.
+func requestMediaType(r *http.Request) string {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+// Middleware which rejects POST requests to JSON API with body of media type
+// other than given ones with 415 error listing supported types, so that
+// client does not get confusing error of decoding body.
+func useContentType(handler http.Handler, mediaTypes ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		mediaType := requestMediaType(r)
+		for _, supported := range mediaTypes {
+			if mediaType == supported {
+				handler.ServeHTTP(w, r)
+				return
+			}
+		}
+		msg := "unsupported content type '" + r.Header.Get("Content-Type") +
+			"', supported types: " + strings.Join(mediaTypes, ", ")
+		writeAPIError(w, http.StatusUnsupportedMediaType, codeUnsupportedMedia, msg)
+	})
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUseContentType(t *testing.T) {
+	appLog = &nopLogger{}
+	handler := useContentType(
+		useAPIMethods(http.HandlerFunc(apiSizeofHandler), http.MethodPost), mediaTypeJSON, mediaTypeText,
+	)
+	for _, c := range []struct {
+		contentType string
+		body        string
+		status      int
+	}{
+		{"application/json", `{"source": "struct{a bool; b int64}"}`, http.StatusOK},
+		{"application/json; charset=utf-8", `{"source": "struct{a bool; b int64}"}`, http.StatusOK},
+		{"text/plain", "struct{a bool; b int64}", http.StatusOK},
+		{"", `{"source": "struct{a bool; b int64}"}`, http.StatusUnsupportedMediaType},
+		{"application/x-www-form-urlencoded", "source=int", http.StatusUnsupportedMediaType},
+		{"application/json;;", `{"source": "int"}`, http.StatusUnsupportedMediaType},
+	} {
+		r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader(c.body))
+		if c.contentType != "" {
+			r.Header.Set("Content-Type", c.contentType)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != c.status {
+			t.Errorf("'%s': invalid status code, expected: %d, actual: %d %s",
+				c.contentType, c.status, w.Code, w.Body.String())
+			continue
+		}
+		if c.status == http.StatusOK {
+			continue
+		}
+		var apiErr apiError
+		if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil ||
+			apiErr.Error.Code != codeUnsupportedMedia ||
+			!strings.Contains(apiErr.Error.Message, "application/json, text/plain") {
+			t.Errorf("'%s': invalid error: %s", c.contentType, w.Body.String())
+		}
+	}
+
+	// Requests without body are left to handler
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, apiSizeofPath, nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("invalid status code of GET request: %d", w.Code)
+	}
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Preflight responses are cached by browsers for this number of seconds.
+const corsMaxAge = 600
+
+// Returns origins allowed to call JSON API from browser, given as comma
+// separated list (like value of GOCORSORIGINS env var). Origin "*" allows
+// any origin. Empty list makes API available to pages of the same origin only.
+func parseCORSOrigins(value string) []string {
+	var origins []string
+	for _, origin := range strings.Split(value, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// Returns whether given origin is one of allowed origins.
+func corsAllowed(origins []string, origin string) bool {
+	for _, allowed := range origins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware which allows cross-origin requests to JSON API from given
+// origins, and responds to their preflight OPTIONS requests.
+// Requests of other origins are served without CORS headers,
+// so that browser does not expose response to calling page.
+func useCORS(origins []string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Origin")
+		if !corsAllowed(origins, origin) {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Methods", http.MethodPost)
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(corsMaxAge))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
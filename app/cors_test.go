@@ -0,0 +1,54 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUseCORS(t *testing.T) {
+	appLog = &nopLogger{}
+	handler := useCORS(parseCORSOrigins(" https://play.example.com, ,"), http.HandlerFunc(apiSizeofHandler))
+
+	request := func(method, origin string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, apiSizeofPath,
+			strings.NewReader(`{"source": "struct{a bool}"}`))
+		r.Header.Set("Origin", origin)
+		if method == http.MethodOptions {
+			r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			r.Header.Set("Access-Control-Request-Headers", "content-type")
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	w := request(http.MethodOptions, "https://play.example.com")
+	if w.Code != http.StatusNoContent ||
+		w.Header().Get("Access-Control-Allow-Origin") != "https://play.example.com" ||
+		w.Header().Get("Access-Control-Allow-Methods") != http.MethodPost ||
+		w.Header().Get("Access-Control-Allow-Headers") != "Content-Type" {
+		t.Errorf("invalid preflight response: %d %v", w.Code, w.Header())
+	}
+
+	w = request(http.MethodPost, "https://play.example.com")
+	if w.Code != http.StatusOK ||
+		w.Header().Get("Access-Control-Allow-Origin") != "https://play.example.com" ||
+		w.Header().Get("Vary") != "Origin" {
+		t.Errorf("invalid cross-origin response: %d %v", w.Code, w.Header())
+	}
+
+	for _, method := range []string{http.MethodOptions, http.MethodPost} {
+		w = request(method, "https://evil.example.com")
+		if w.Header().Get("Access-Control-Allow-Origin") != "" {
+			t.Errorf("%s request of disallowed origin is allowed: %v", method, w.Header())
+		}
+	}
+
+	handler = useCORS([]string{"*"}, http.HandlerFunc(apiSizeofHandler))
+	w = request(http.MethodPost, "https://any.example.com")
+	if w.Header().Get("Access-Control-Allow-Origin") != "https://any.example.com" {
+		t.Errorf("request of any origin is not allowed: %v", w.Header())
+	}
+}
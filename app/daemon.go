@@ -17,15 +17,19 @@ import (
 // flag
 var nodaemon bool
 var httpPort string
+var shutdownTimeout time.Duration
 
 // Setting up daemon properties.
 func init() {
 	daemon.AppName = "go-sizeof-webapp HTTP server"
 	daemon.PidFile = "logs/sizeof.pid"
 
-	flag.StringVar(&httpPort, "http", DefaultHttpPort, "port to listen http reauests on")
+	flag.StringVar(&httpPort, "http", "",
+		"address (host:port or port) to listen http requests on (default \""+
+			DefaultHttpPort+"\")")
 	flag.BoolVar(&nodaemon, "nodaemon", false, "do not start daemonized")
-	defer flag.Parse()
+	flag.DurationVar(&shutdownTimeout, "shutdown-timeout", 10*time.Second,
+		"time to wait for in-flight requests on shutdown")
 
 	if nodaemon {
 		return
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/http/pprof"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Token which must be provided to access debug handlers. If is empty, then
+// debug handlers are disabled.
+var debugToken string
+
+// Registers net/http/pprof handlers under /debug/pprof/, set with GOPPROF
+// env var. They are guarded by debug token as well.
+var pprofEnabled bool
+
+// Part of server write timeout, which is left for writing captured profile
+// after its duration ends.
+const pprofWriteMargin = 5 * time.Second
+
+// Handler which changes level of application log at runtime. Requires POST
+// method, "level" form value and "Authorization: Bearer <token>" header,
+// which are checked by middlewares it is routed with.
+func logLevelHandler(w http.ResponseWriter, r *http.Request) {
+	level := r.FormValue("level")
+	if err := appLog.SetLevel(level); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	requestLog(r).Info("Log level changed to %s", level)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Helper function to check whether given request is authorized to access
+// debug handlers.
+func isDebugAuthorized(r *http.Request) bool {
+	if debugToken == "" {
+		return false
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(debugToken)) == 1
+}
+
+// Registers profiling handlers of net/http/pprof in given mux,
+// if they are enabled.
+func bindPprofHandlers(mux *http.ServeMux) {
+	if !pprofEnabled {
+		return
+	}
+	mux.Handle("/debug/pprof/", useDebugAuth(usePprofSeconds(0, pprof.Index)))
+	mux.Handle("/debug/pprof/cmdline", useDebugAuth(pprof.Cmdline))
+	mux.Handle("/debug/pprof/profile", useDebugAuth(usePprofSeconds(30, pprof.Profile)))
+	mux.Handle("/debug/pprof/symbol", useDebugAuth(pprof.Symbol))
+	mux.Handle("/debug/pprof/trace", useDebugAuth(usePprofSeconds(1, pprof.Trace)))
+}
+
+// Middleware which shortens duration of profile capture ("seconds" query
+// parameter, or given default one of handler) to fit into write timeout of
+// server, as pprof rejects longer captures and response would be cut off.
+// Zero default means that handler captures nothing without parameter.
+func usePprofSeconds(def int, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		srv, ok := r.Context().Value(http.ServerContextKey).(*http.Server)
+		if !ok || srv.WriteTimeout <= 0 {
+			handler(w, r)
+			return
+		}
+		query := r.URL.Query()
+		seconds, err := strconv.Atoi(query.Get("seconds"))
+		if query.Get("seconds") == "" {
+			seconds, err = def, nil
+		}
+		limit := int((srv.WriteTimeout - pprofWriteMargin) / time.Second)
+		if limit < 1 {
+			limit = 1
+		}
+		if err != nil || seconds <= limit {
+			handler(w, r)
+			return
+		}
+		requestLog(r).Info("Profile duration is shortened from %ds to %ds by write timeout", seconds, limit)
+		query.Set("seconds", strconv.Itoa(limit))
+		r = r.Clone(r.Context())
+		r.URL.RawQuery, r.Form = query.Encode(), nil
+		handler(w, r)
+	}
+}
+
+// Middleware which hides given debug handler behind 404 page from requests
+// without debug token.
+func useDebugAuth(handler http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isDebugAuthorized(r) {
+			write404(w)
+			return
+		}
+		handler(w, r)
+	})
+}
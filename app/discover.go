@@ -2,7 +2,14 @@ package app
 
 import (
 	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/chappjc/go-sizeof-webapp/internal/parser"
@@ -16,26 +23,532 @@ struct {
 }
 `
 
+// Name of multipart form field of uploaded source file.
+const uploadField = "file"
+
 func discoverHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		renderSubmission(w, r, readUploadedFile(w, r))
+		return
+	}
 	code := parseCodeRequestParam(r.FormValue("t"))
 	if code == "" {
 		code = exampleCode
 	}
+	renderDiscover(w, r, code)
+}
+
+// Source code submitted to be laid out, pasted or uploaded as file.
+type submission struct {
+	Code string
+	// Name of uploaded file, empty for pasted code
+	FileName string
+	// Error of reading uploaded file
+	Err error
+}
+
+// Helper function which reads source file uploaded with given multipart form
+// request. File is limited by maxInputSize, as pasted code is. Successfully
+// read code is added to query of request, so that links of rendered page
+// (layout view, exports) refer to it.
+func readUploadedFile(w http.ResponseWriter, r *http.Request) *submission {
+	// Leave room for boundaries and headers of multipart body
+	r.Body = http.MaxBytesReader(w, r.Body, maxInputSize+1<<10)
+	// Options are given in query, which must be parsed before body is
+	// read by multipart reader
+	_ = r.ParseForm()
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return &submission{Err: fmt.Errorf("invalid file upload: %s", err)}
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return &submission{Err: errors.New("no file is uploaded")}
+		}
+		if err != nil {
+			return &submission{Err: fmt.Errorf("invalid file upload: %s", err)}
+		}
+		if part.FormName() != uploadField {
+			continue
+		}
+		if part.FileName() == "" {
+			return &submission{Err: errors.New("no file is uploaded")}
+		}
+		sub := &submission{FileName: filepath.Base(part.FileName())}
+		data, err := ioutil.ReadAll(io.LimitReader(part, maxInputSize+1))
+		switch {
+		case err != nil:
+			sub.Err = fmt.Errorf("invalid file upload: %s", err)
+		case int64(len(data)) > maxInputSize:
+			sub.Err = &inputTooLargeError{}
+		default:
+			sub.Code = string(data)
+			query := r.URL.Query()
+			query.Set("t", base64.URLEncoding.EncodeToString(data))
+			r.URL.RawQuery = query.Encode()
+			requestLog(r).Debug("Uploaded file '%s' of %d bytes", sub.FileName, len(data))
+		}
+		return sub
+	}
+}
+
+// Renders layout of types declared in given code.
+func renderDiscover(w http.ResponseWriter, r *http.Request, code string) {
+	renderSubmission(w, r, &submission{Code: code})
+}
+
+// Renders layout of types declared in given submitted code.
+func renderSubmission(w http.ResponseWriter, r *http.Request, sub *submission) {
+	code := sub.Code
 
 	toRender := &struct {
-		Code   string
-		Result *viewData
-		Error  string
-	}{Code: code}
+		Code         string
+		FileName     string
+		Arch         string
+		Archs        []string
+		Source       []*sourceLine
+		Results      []*typeView
+		KeepGroups   bool
+		Strategy     string
+		Strategies   []string
+		ExplicitPad  bool
+		Bitfields    bool
+		Union        bool
+		UnionNote    *parser.UnionNote
+		CacheLine    string
+		LargeSize    string
+		Inst         string
+		Ext          string
+		ShowDiagram  bool
+		ViewURL      string
+		ExportGoURL  string
+		ExportCURL   string
+		ExportTxtURL string
+		Error        string
+		ErrorLine    *errorLine
+	}{
+		Code:        code,
+		FileName:    sub.FileName,
+		Arch:        r.FormValue("arch"),
+		Archs:       archNames(),
+		KeepGroups:  r.FormValue("keepgroups") == "1",
+		Strategy:    r.FormValue("strategy"),
+		Strategies:  parser.Strategies,
+		ExplicitPad: r.FormValue("explicitpad") == "1",
+		Bitfields:   r.FormValue("bitfields") == "1",
+		Union:       r.FormValue("union") == "1",
+		CacheLine:   r.FormValue("cacheline"),
+		LargeSize:   r.FormValue("largesize"),
+		Inst:        r.FormValue("inst"),
+		Ext:         r.FormValue("ext"),
+	}
+	toRender.ShowDiagram, toRender.ViewURL = prepareView(r)
+	toRender.ExportGoURL = exportURL(r, exportGo)
+	toRender.ExportCURL = exportURL(r, exportC)
+	toRender.ExportTxtURL = exportURL(r, exportText)
+	_, tooLarge := sub.Err.(*inputTooLargeError)
+	if tooLarge || int64(len(code)) > maxInputSize {
+		logOversizedInput(r)
+		toRender.Error = inputTooLargeMessage()
+		renderTemplateStatus(w, http.StatusRequestEntityTooLarge, "index", toRender)
+		return
+	}
+	if sub.Err != nil {
+		toRender.Error = sub.Err.Error()
+		renderTemplate(w, "index", toRender)
+		return
+	}
+	if toRender.Arch == "" {
+		toRender.Arch = parser.DefaultArch
+	}
 
-	result, err := parser.ParseCode(code)
+	arch, err := parser.LookupArch(toRender.Arch)
+	if err == nil {
+		var external map[string]parser.ExternalType
+		if external, err = parseExternalTypes(toRender.Ext); err == nil {
+			arch, err = arch.WithExternalTypes(external)
+		}
+	}
+	if err != nil {
+		toRender.Error = err.Error()
+		renderTemplate(w, "index", toRender)
+		return
+	}
+	cacheLine, err := parseCacheLineSize(toRender.CacheLine)
+	if err != nil {
+		toRender.Error = err.Error()
+		renderTemplate(w, "index", toRender)
+		return
+	}
+	toRender.CacheLine = strconv.FormatUint(cacheLine, 10)
+	largeSize, err := parseLargeSize(toRender.LargeSize)
+	if err != nil {
+		toRender.Error = err.Error()
+		renderTemplate(w, "index", toRender)
+		return
+	}
+	toRender.LargeSize = strconv.FormatUint(largeSize, 10)
+	if toRender.Strategy, err = parseStrategy(toRender.Strategy); err != nil {
+		toRender.Error = err.Error()
+		renderTemplate(w, "index", toRender)
+		return
+	}
+	expectedSize, err := parseExpectedSize(r.FormValue("expectedsize"))
+	if err != nil {
+		toRender.Error = err.Error()
+		renderTemplate(w, "index", toRender)
+		return
+	}
+	export := r.FormValue("export")
+	if err = validateExportFormat(export); err != nil {
+		toRender.Error = err.Error()
+		renderTemplate(w, "index", toRender)
+		return
+	}
+	types, err := parseDecls(r, code, arch, splitInstantiations(toRender.Inst)...)
 	if err != nil {
 		toRender.Error = err.Error()
-	} else {
-		toRender.Result = createViewData(result)
+		if syntaxErr, ok := err.(*parser.SyntaxError); ok {
+			toRender.ErrorLine = createErrorLine(code, syntaxErr)
+		}
+		if isTooComplex(err) {
+			renderTemplateStatus(w, http.StatusRequestEntityTooLarge, "index", toRender)
+			return
+		}
+		renderTemplate(w, "index", toRender)
+		return
+	}
+	if export != "" {
+		writeExport(w, export, types, arch)
+		return
+	}
+	toRender.Source = highlightCode(code, fieldNotes(types))
+	if toRender.Union {
+		toRender.UnionNote = parser.AnalyzeUnion(types)
+	}
+	for _, typ := range types {
+		if typ.Type == nil {
+			toRender.Results = append(toRender.Results, &typeView{
+				Name:       typ.Name,
+				TypeParams: typ.TypeParams,
+			})
+			continue
+		}
+		if err = parser.AnnotateCacheLines(typ.Type, cacheLine); err != nil {
+			toRender.Error = err.Error()
+			renderTemplate(w, "index", toRender)
+			return
+		}
+		view := &typeView{
+			Name:      typ.Name,
+			Result:    createViewData(typ.Type),
+			Suggested: createSuggestion(typ, toRender.KeepGroups, toRender.Strategy),
+			Packing:   parser.AnalyzePacking(typ.Type),
+			Props:     parser.StructProperties(typ.Type, cacheLine, largeSize),
+			Warnings:  layoutWarnings(r, typ.Type, expectedSize),
+		}
+		// Expected size applies to the first laid out type only
+		expectedSize = nil
+		if toRender.ExplicitPad {
+			view.Padded = createPaddedView(typ)
+		}
+		if toRender.Bitfields {
+			view.Bitfields = parser.AnalyzeBitfields(typ.Type, arch)
+		}
+		if toRender.ShowDiagram {
+			if view.Diagram, err = parser.Diagram(typ.Type); err != nil {
+				view.Diagram = err.Error()
+			}
+		}
+		toRender.Results = append(toRender.Results, view)
 	}
 
-	templates["index"].ExecuteTemplate(w, "base", toRender)
+	renderTemplate(w, "index", toRender)
+}
+
+// Line of submitted code which contains syntax error.
+type errorLine struct {
+	Number int
+	Text   string
+	Caret  string // points to column of error below text
+}
+
+// Returns line of given code where given syntax error occurred,
+// or nil if there is no such line.
+func createErrorLine(code string, err *parser.SyntaxError) *errorLine {
+	lines := strings.Split(code, "\n")
+	if err.Line < 1 || err.Line > len(lines) {
+		return nil
+	}
+	text := strings.TrimRight(lines[err.Line-1], "\r")
+	column := err.Column - 1
+	if column > len(text) {
+		column = len(text)
+	}
+	if column < 0 {
+		column = 0
+	}
+	// Caret is indented by line number prefix, and keeps tabs
+	// so that it is aligned with text.
+	caret := []byte(strings.Repeat(" ", len(strconv.Itoa(err.Line))+2))
+	for i := 0; i < column; i++ {
+		if text[i] == '\t' {
+			caret = append(caret, '\t')
+		} else {
+			caret = append(caret, ' ')
+		}
+	}
+	return &errorLine{
+		Number: err.Line,
+		Text:   text,
+		Caret:  string(append(caret, '^')),
+	}
+}
+
+// Layout of single type declared in submitted code.
+// Generic type has only names of its type parameters.
+type typeView struct {
+	Name       string
+	TypeParams []string
+	Result     *viewData
+	Suggested  *suggestion
+	Packing    *parser.PackingHint
+	Bitfields  *parser.BitfieldNote
+	Props      *parser.Properties
+	Diagram    string
+	Warnings   []string
+	Padded     *paddedView
+}
+
+// Returns instantiations of generic types (like "Box[int64]") given
+// in request param separated by semicolons.
+func splitInstantiations(param string) []string {
+	var insts []string
+	for _, inst := range strings.Split(param, ";") {
+		if inst = strings.TrimSpace(inst); inst != "" {
+			insts = append(insts, inst)
+		}
+	}
+	return insts
+}
+
+// Returns whether layout diagram was requested with "view=diagram" param,
+// and URL which switches to the other view.
+func prepareView(r *http.Request) (showDiagram bool, viewURL string) {
+	query := r.URL.Query()
+	if query.Get("view") == "diagram" {
+		query.Del("view")
+		return true, "?" + query.Encode()
+	}
+	query.Set("view", "diagram")
+	return false, "?" + query.Encode()
+}
+
+// Struct with fields reordered to minimize padding.
+type suggestion struct {
+	Code   string
+	Sizeof uint64
+	Saved  uint64
+	// Strategy of reordering which is used, and requested one if it
+	// fell back to other
+	Strategy  string
+	Requested string
+}
+
+// Returns suggested layout of given type,
+// or nil if type is not a struct or its size cannot be reduced.
+func createSuggestion(named *parser.NamedType, keepGroups bool, strategy string) *suggestion {
+	typ := named.Type
+	if !typ.IsStruct || len(typ.Fields) < 2 {
+		return nil
+	}
+	suggested, used, err := parser.SuggestLayoutWith(typ, keepGroups, strategy)
+	if err != nil || suggested.Sizeof >= typ.Sizeof {
+		return nil
+	}
+	code := "struct {\n"
+	// Instantiation of generic type cannot be declared by its name
+	if named.Name != "" && !strings.Contains(named.Name, "[") {
+		code = "type " + named.Name + " " + code
+	}
+	for i, field := range suggested.Fields {
+		if keepGroups && i > 0 && field.Group != suggested.Fields[i-1].Group {
+			code += "\n"
+		}
+		code += "\t" + field.Source + "\n"
+	}
+	code += "}"
+	sug := &suggestion{
+		Code:     code,
+		Sizeof:   suggested.Sizeof,
+		Saved:    typ.Sizeof - suggested.Sizeof,
+		Strategy: used,
+	}
+	if used != strategy {
+		sug.Requested = strategy
+	}
+	return sug
+}
+
+// Struct with padding declared explicitly as blank fields.
+type paddedView struct {
+	Fields []*parser.PaddedField
+	Code   string
+}
+
+// Returns explicitly padded layout of given type,
+// or nil if type is not a struct.
+func createPaddedView(named *parser.NamedType) *paddedView {
+	fields := parser.ExplicitPadding(named.Type)
+	if fields == nil {
+		return nil
+	}
+	code := "struct {\n"
+	// Instantiation of generic type cannot be declared by its name
+	if named.Name != "" && !strings.Contains(named.Name, "[") {
+		code = "type " + named.Name + " " + code
+	}
+	for _, field := range fields {
+		code += "\t" + field.Decl()
+		if comment := field.Comment(); comment != "" {
+			code += " // " + comment
+		}
+		code += "\n"
+	}
+	code += "}"
+	return &paddedView{Fields: fields, Code: code}
+}
+
+// Returns sorted names of supported target architectures.
+func archNames() []string {
+	names := make([]string, 0, len(parser.Archs))
+	for name := range parser.Archs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns strategy of reordering fields from given request param,
+// or greedy one if param is empty.
+func parseStrategy(param string) (string, error) {
+	if param == "" {
+		return parser.StrategyGreedy, nil
+	}
+	for _, strategy := range parser.Strategies {
+		if param == strategy {
+			return strategy, nil
+		}
+	}
+	return "", fmt.Errorf("unknown reordering strategy '%s', expected one of: %s",
+		param, strings.Join(parser.Strategies, ", "))
+}
+
+// Returns cache line size from given request param,
+// or default size if param is empty.
+func parseCacheLineSize(param string) (uint64, error) {
+	if param == "" {
+		return parser.DefaultCacheLineSize, nil
+	}
+	size, err := strconv.ParseUint(param, 10, 64)
+	if err != nil || size == 0 {
+		return 0, fmt.Errorf("invalid cache line size '%s'", param)
+	}
+	return size, nil
+}
+
+// Returns size above which struct is reported as large from given request
+// param, or default one if param is empty.
+func parseLargeSize(param string) (uint64, error) {
+	if param == "" {
+		return parser.DefaultLargeSize, nil
+	}
+	size, err := strconv.ParseUint(param, 10, 64)
+	if err != nil || size == 0 {
+		return 0, fmt.Errorf("invalid large struct size '%s'", param)
+	}
+	return size, nil
+}
+
+// Returns size which laid out type is expected to have from given request
+// param, or nil if param is empty.
+func parseExpectedSize(param string) (*uint64, error) {
+	if param == "" {
+		return nil, nil
+	}
+	size, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expected size '%s'", param)
+	}
+	return &size, nil
+}
+
+// Returns layouts of types declared outside of submitted code, given in
+// request param like "MyType:24:8; models.User:16:8" (name, size and
+// alignment), separated by semicolons.
+func parseExternalTypes(param string) (map[string]parser.ExternalType, error) {
+	var types map[string]parser.ExternalType
+	for _, def := range strings.Split(param, ";") {
+		if def = strings.TrimSpace(def); def == "" {
+			continue
+		}
+		parts := strings.Split(def, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid external type '%s', expected name:size:align", def)
+		}
+		size, sizeErr := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 64)
+		align, alignErr := strconv.ParseUint(strings.TrimSpace(parts[2]), 10, 64)
+		if sizeErr != nil || alignErr != nil {
+			return nil, fmt.Errorf("invalid external type '%s', expected name:size:align", def)
+		}
+		if types == nil {
+			types = make(map[string]parser.ExternalType)
+		}
+		types[strings.TrimSpace(parts[0])] = parser.ExternalType{Size: size, Align: align}
+	}
+	return types, nil
+}
+
+// Returns names of fields of given type, whose layouts are given by user
+// as external types, or name of type itself if it is external.
+func externalFields(typ *parser.TypeInfo) []string {
+	if typ.External {
+		return []string{typ.Name}
+	}
+	var names []string
+	for _, field := range typ.Fields {
+		if field.External {
+			names = append(names, field.FieldName)
+		}
+	}
+	return names
+}
+
+// Returns warnings about layout of given type: size which differs from
+// given expected one (if any), fields laid out with user given sizes of
+// external types, mistakes in struct tags, and inconsistency of computed
+// layout, which is logged as well, as it means bug of layout computation.
+func layoutWarnings(r *http.Request, typ *parser.TypeInfo, expectedSize *uint64) []string {
+	var warnings []string
+	if names := externalFields(typ); len(names) > 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"layout is an estimate, user given sizes of external types are used for: %s",
+			strings.Join(names, ", "),
+		))
+	}
+	if expectedSize != nil && *expectedSize != typ.Sizeof {
+		warnings = append(warnings, fmt.Sprintf(
+			"expected size %d, but computed size is %d (alignment %d)",
+			*expectedSize, typ.Sizeof, typ.Alignof,
+		))
+	}
+	warnings = append(warnings, parser.TagWarnings(typ)...)
+	if err := typ.CheckLayout(); err != nil {
+		_ = requestLog(r).Error("Inconsistent layout computed, reason -> %s", err.Error())
+		warnings = append(warnings, "inconsistent layout: "+err.Error())
+	}
+	return warnings
 }
 
 func parseCodeRequestParam(param string) string {
@@ -47,6 +560,15 @@ func parseCodeRequestParam(param string) string {
 	return string(bytes)
 }
 
+// Largest size of struct, which layout is shown in table. Arrays are shown
+// with chunk per alignment word, so view of larger struct would take too much
+// time and memory to be created.
+const viewMaxBytes = 1 << 16
+
+var viewTooLargeMessage = fmt.Sprintf(
+	"Struct is too large for layout table, maximum size is %s.", humanBytes(viewMaxBytes),
+)
+
 type chunk struct {
 	Cells     []bool
 	IsPadding bool
@@ -68,27 +590,35 @@ func (ch *chunk) asPadding() *chunk {
 type row struct {
 	Chunks []*chunk
 	Name   string
+	Field  *parser.TypeInfo // nil for padding
+	// Padding before field, which is attributed to it
+	Padding *parser.FieldPadding
 }
 
 type viewData struct {
 	*parser.TypeInfo
 	Details []*row
+	// Reason why details of struct are not shown
+	Omitted string
 }
 
 func (data *viewData) prepareFields(
-	fields []*parser.TypeInfo,
+	strct *parser.TypeInfo,
 	offset uint64,
 	topLevel bool,
 ) uint64 {
-	for _, field := range fields {
+	for _, attr := range parser.AttributePadding(strct) {
+		field := attr.Field
 		switch {
 		case field.IsArray:
 			fallthrough
 		case field.IsFixed:
 			if field.Sizeof == 0 {
 				data.Details = append(data.Details, &row{
-					Name:   field.Name,
-					Chunks: []*chunk{newChunk(0, 0, data.Alignof)},
+					Name:    field.Name,
+					Field:   field,
+					Padding: attr,
+					Chunks:  []*chunk{newChunk(0, 0, data.Alignof)},
 				})
 				continue
 			}
@@ -133,19 +663,32 @@ func (data *viewData) prepareFields(
 			}
 			if len(chunks) > 0 {
 				data.Details = append(data.Details, &row{
-					Name:   field.Name,
-					Chunks: chunks,
+					Name:    field.Name,
+					Field:   field,
+					Padding: attr,
+					Chunks:  chunks,
 				})
 			}
 		case field.IsStruct:
 			if len(field.Fields) < 1 {
 				data.Details = append(data.Details, &row{
-					Name:   field.Name,
-					Chunks: []*chunk{newChunk(0, 0, data.Alignof)},
+					Name:    field.Name,
+					Field:   field,
+					Padding: attr,
+					Chunks:  []*chunk{newChunk(0, 0, data.Alignof)},
 				})
 				continue
 			}
-			offset = data.prepareFields(field.Fields, offset, false)
+			first := len(data.Details)
+			offset = data.prepareFields(field, offset, false)
+			// Padding before nested struct is shown before its first field
+			for _, r := range data.Details[first:] {
+				if r.Padding != nil {
+					r.Padding.Before += attr.Before
+					r.Padding.Misaligned = r.Padding.Misaligned || attr.Misaligned
+					break
+				}
+			}
 		}
 	}
 	if topLevel && offset > 0 && offset < data.Alignof {
@@ -164,7 +707,20 @@ func createViewData(typ *parser.TypeInfo) (data *viewData) {
 	if !typ.IsStruct {
 		return
 	}
+	if typ.Sizeof > viewMaxBytes {
+		data.Omitted = viewTooLargeMessage
+		return
+	}
 	data.Details = make([]*row, 0, len(typ.Fields))
-	data.prepareFields(typ.Fields, 0, true)
+	offset := data.prepareFields(typ, 0, true)
+	// Whole word of padding added after trailing zero sized field
+	if offset == 0 && typ.TrailingPadding >= typ.Alignof {
+		data.Details = append(data.Details, &row{
+			Name: "padding",
+			Chunks: []*chunk{newChunk(
+				0, typ.Alignof, typ.Alignof,
+			).asPadding()},
+		})
+	}
 	return
 }
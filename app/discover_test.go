@@ -0,0 +1,244 @@
+package app
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/parser"
+)
+
+func TestCreateErrorLine(t *testing.T) {
+	code := "type A struct {\n\ta bool\n\tb int64 c\n}"
+	line := createErrorLine(code, &parser.SyntaxError{Line: 3, Column: 10})
+	if line == nil || line.Text != "\tb int64 c" || line.Caret != "   \t        ^" {
+		t.Errorf("invalid error line: %+v", line)
+	}
+	if line := createErrorLine(code, &parser.SyntaxError{Line: 7}); line != nil {
+		t.Errorf("unexpected error line: %+v", line)
+	}
+}
+
+func TestDiscoverSyntaxError(t *testing.T) {
+	appLog = &nopLogger{}
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	for _, code := range []string{
+		"type X struct {",
+		"struct{a bool",
+		"type A struct{\n\tx: bool\n}",
+		"[3 int",
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		renderDiscover(w, r, code)
+		body := w.Body.String()
+		if w.Code != http.StatusOK || !strings.Contains(body, "syntax error") ||
+			!strings.Contains(body, "^</pre>") {
+			t.Errorf("syntax error of '%s' is not rendered: %d\n%s", code, w.Code, body)
+		}
+	}
+}
+
+// Returns declarations of n types, each of which has 8 fields of the
+// previous one, so layout of the last one has 8^n fields.
+func nestedDecls(n int) string {
+	var code strings.Builder
+	code.WriteString("type T0 struct{a, b, c, d, e, f, g, h int8}\n")
+	for i := 1; i < n; i++ {
+		fmt.Fprintf(&code, "type T%d struct{a, b, c, d, e, f, g, h T%d}\n", i, i-1)
+	}
+	return code.String()
+}
+
+func TestDiscoverTooComplex(t *testing.T) {
+	appLog = &nopLogger{}
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	renderDiscover(w, r, nestedDecls(13))
+	if w.Code != http.StatusRequestEntityTooLarge ||
+		!strings.Contains(w.Body.String(), "types are too complex") {
+		t.Errorf("too complex types are not rejected: %d\n%s", w.Code, w.Body.String())
+	}
+}
+
+func TestDiscoverBitfields(t *testing.T) {
+	appLog = &nopLogger{}
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	code := "struct{a, b, c bool; x int32}"
+	for query, shown := range map[string]bool{"/?bitfields=1": true, "/": false} {
+		r := httptest.NewRequest(http.MethodGet, query, nil)
+		w := httptest.NewRecorder()
+		renderDiscover(w, r, code)
+		body := w.Body.String()
+		if strings.Contains(body, "Type size would be 8") != shown {
+			t.Errorf("%s: bitfields note expected to be shown %t:\n%s", query, shown, body)
+		}
+	}
+}
+
+func TestDiscoverUnion(t *testing.T) {
+	appLog = &nopLogger{}
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	for _, c := range []struct {
+		query, code, expected string
+	}{
+		{"/?union=1", "type A int64\ntype B [3]int32", "would take 16 bytes"},
+		{"/?union=1", "type A int64", "Declare two or more types"},
+		{"/", "type A int64\ntype B [3]int32", ""},
+	} {
+		r := httptest.NewRequest(http.MethodGet, c.query, nil)
+		w := httptest.NewRecorder()
+		renderDiscover(w, r, c.code)
+		body := w.Body.String()
+		if c.expected == "" && strings.Contains(body, "<h4>Union</h4>") ||
+			!strings.Contains(body, c.expected) {
+			t.Errorf("%s %q: expected union note '%s':\n%s", c.query, c.code, c.expected, body)
+		}
+	}
+}
+
+func TestDiscoverStrategy(t *testing.T) {
+	appLog = &nopLogger{}
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	code := "struct{a bool; b int32; c int16; d int64}"
+	for query, expected := range map[string]string{
+		"/":                   "with greedy strategy saves 8 bytes",
+		"/?strategy=optimal":  "with optimal strategy saves 8 bytes",
+		"/?strategy=minchurn": "Strategy minchurn fell back to it, as it leaves more padding",
+		"/?strategy=firstfit": "unknown reordering strategy &#39;firstfit&#39;",
+	} {
+		r := httptest.NewRequest(http.MethodGet, query, nil)
+		w := httptest.NewRecorder()
+		renderDiscover(w, r, code)
+		if body := w.Body.String(); !strings.Contains(body, expected) {
+			t.Errorf("%s: expected '%s' in page:\n%s", query, expected, body)
+		}
+	}
+}
+
+func TestCreateViewDataPadding(t *testing.T) {
+	typ, err := parser.ParseCode("struct{a bool; b struct{x int32; y bool}; c int64}")
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	// Padding before nested struct is attributed to its first field
+	expected := map[string]uint64{"a": 0, "x": 3, "y": 0, "c": 4}
+	for _, r := range createViewData(typ).Details {
+		if r.Field == nil {
+			if r.Padding != nil {
+				t.Errorf("padding row has padding attributed: %+v", r.Padding)
+			}
+			continue
+		}
+		before, exists := expected[r.Field.FieldName]
+		if !exists || r.Padding == nil || r.Padding.Before != before || r.Padding.Misaligned {
+			t.Errorf("field '%s': expected %d bytes of padding before, got %+v",
+				r.Field.FieldName, before, r.Padding)
+		}
+		delete(expected, r.Field.FieldName)
+	}
+	if len(expected) > 0 {
+		t.Errorf("fields have no rows: %v", expected)
+	}
+}
+
+func TestCreateViewDataHugeArray(t *testing.T) {
+	appLog = &nopLogger{}
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	code := "struct{a [1<<30]int64; b bool}"
+	typ, err := parser.ParseCode(code)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	start := time.Now()
+	data := createViewData(typ)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("view of huge array took %s", elapsed)
+	}
+	if len(data.Details) > 0 || data.Omitted != viewTooLargeMessage {
+		t.Errorf("details of huge struct must be omitted, got %d rows", len(data.Details))
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	renderDiscover(w, r, code)
+	if body := w.Body.String(); !strings.Contains(body, "maximum size is 64 KiB") {
+		t.Errorf("expected omitted layout note in page:\n%s", body)
+	}
+}
+
+func TestDiscoverUpload(t *testing.T) {
+	appLog = &nopLogger{}
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	upload := func(field, fileName, content string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, err := mw.CreateFormFile(field, fileName)
+		if err != nil {
+			t.Fatalf("failed to create form file, reason -> %s", err.Error())
+		}
+		fw.Write([]byte(content))
+		mw.Close()
+		r := httptest.NewRequest(http.MethodPost, "/?arch=386", &body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		discoverHandler(w, r)
+		return w
+	}
+
+	code := "package models\n\nimport \"time\"\n\ntype A struct {\n\ta bool\n\tb int64\n}\n\ntype B struct {\n\tt time.Time\n}\n"
+	w := upload(uploadField, "models.go", code)
+	body := w.Body.String()
+	for _, expected := range []string{
+		"uploaded file <code>models.go</code>",
+		"type A", "type B",
+		"Type size: 12",
+		"t=" + strings.TrimRight(base64.URLEncoding.EncodeToString([]byte(code)), "="),
+	} {
+		if w.Code != http.StatusOK || !strings.Contains(body, expected) {
+			t.Errorf("uploaded file is not rendered with '%s': %d\n%s", expected, w.Code, body)
+		}
+	}
+
+	if w = upload("other", "models.go", code); !strings.Contains(w.Body.String(), "no file is uploaded") {
+		t.Errorf("missing file is not reported:\n%s", w.Body.String())
+	}
+	if w = upload(uploadField, "", ""); !strings.Contains(w.Body.String(), "no file is uploaded") {
+		t.Errorf("empty file field is not reported:\n%s", w.Body.String())
+	}
+
+	defer func(size int64) { maxInputSize = size }(maxInputSize)
+	maxInputSize = 16
+	if w = upload(uploadField, "models.go", code); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 for too large file, got %d", w.Code)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("t=abc"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	discoverHandler(w, r)
+	if !strings.Contains(w.Body.String(), "invalid file upload") {
+		t.Errorf("non-multipart request is not reported:\n%s", w.Body.String())
+	}
+}
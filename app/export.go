@@ -0,0 +1,222 @@
+package app
+
+import (
+	"fmt"
+	"go/format"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/parser"
+)
+
+// Formats which computed layout can be exported in with "export" param.
+const (
+	exportGo   = "go"
+	exportC    = "c"
+	exportText = "txt"
+)
+
+// Returns error if given export format is not supported.
+func validateExportFormat(to string) error {
+	switch to {
+	case "", exportGo, exportC, exportText:
+		return nil
+	}
+	return fmt.Errorf("unsupported export format '%s', expected '%s', '%s' or '%s'",
+		to, exportGo, exportC, exportText)
+}
+
+// Returns URL of current page which downloads its layouts in given format.
+func exportURL(r *http.Request, to string) string {
+	query := r.URL.Query()
+	query.Del("view")
+	query.Set("export", to)
+	return "?" + query.Encode()
+}
+
+// Writes structs of given types with explicit padding as downloadable Go
+// file or C header, or layout diagrams of types as plain text, depending on
+// given format.
+func writeExport(w http.ResponseWriter, to string, types []*parser.NamedType, arch *parser.Arch) {
+	var code, fName, contentType string
+	switch to {
+	case exportGo:
+		code, fName, contentType = exportGoFile(types, arch), "types.go", "text/x-go"
+	case exportC:
+		code, fName, contentType = exportCHeader(types, arch), "types.h", "text/x-c"
+	case exportText:
+		code, fName, contentType = exportTextDiagrams(types, arch), "layout.txt", "text/plain"
+	}
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+fName+`"`)
+	_, _ = w.Write([]byte(code))
+}
+
+// Returns Go file which declares given structs with explicit padding fields
+// and offsets of fields in comments. Types other than structs are skipped.
+func exportGoFile(types []*parser.NamedType, arch *parser.Arch) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Layout of types computed by go-sizeof for %s.\n", arch.Name)
+	b.WriteString("// Padding is explicit, so each field has the same offset without it.\n\n")
+	b.WriteString("package types\n")
+	for _, named := range types {
+		fields := parser.ExplicitPadding(named.Type)
+		if fields == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "\n// %s has size %d and alignment %d.\n",
+			exportName(named.Name), named.Type.Sizeof, named.Type.Alignof)
+		fmt.Fprintf(&b, "type %s struct {\n", exportName(named.Name))
+		for _, field := range fields {
+			fmt.Fprintf(&b, "\t%s // offset %d", field.Decl(), field.Offset)
+			if comment := field.Comment(); comment != "" {
+				b.WriteString(", " + comment)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("}\n")
+	}
+	// Formatting aligns comments, while source of fields is kept as is
+	// if it fails
+	code, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return b.String()
+	}
+	return string(code)
+}
+
+// Returns plain text with layout diagrams of given types, which are the same
+// as ones shown on page with "view=diagram" param.
+func exportTextDiagrams(types []*parser.NamedType, arch *parser.Arch) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Layout of types computed by go-sizeof for %s.\n", arch.Name)
+	for _, named := range types {
+		fmt.Fprintf(&b, "\n%s (size %d, alignment %d)\n\n",
+			named.Name, named.Type.Sizeof, named.Type.Alignof)
+		diagram, err := parser.Diagram(named.Type)
+		if err != nil {
+			diagram = err.Error() + "\n"
+		}
+		b.WriteString(diagram)
+	}
+	return b.String()
+}
+
+// C types of Go basic types, which have the same size on all architectures.
+var cBasicTypes = map[string]string{
+	"bool":    "bool",
+	"byte":    "uint8_t",
+	"rune":    "int32_t",
+	"int8":    "int8_t",
+	"int16":   "int16_t",
+	"int32":   "int32_t",
+	"int64":   "int64_t",
+	"uint8":   "uint8_t",
+	"uint16":  "uint16_t",
+	"uint32":  "uint32_t",
+	"uint64":  "uint64_t",
+	"float32": "float",
+	"float64": "double",
+}
+
+// Returns C header which declares given structs with explicit padding fields
+// and checks their sizes and offsets of fields. Fields of types which have no
+// C equivalent are declared as byte arrays of the same size and alignment.
+// Types other than structs are skipped.
+func exportCHeader(types []*parser.NamedType, arch *parser.Arch) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "/* Layout of types computed by go-sizeof for %s.\n", arch.Name)
+	b.WriteString(" * Padding is explicit, so offsets noted in comments do not depend on\n")
+	b.WriteString(" * compiler. Do not use #pragma pack with these structs: it keeps offsets,\n")
+	b.WriteString(" * but lowers alignment of struct, so arrays of it are laid out differently.\n")
+	b.WriteString(" */\n")
+	b.WriteString("#include <stdbool.h>\n#include <stddef.h>\n#include <stdint.h>\n")
+	for _, named := range types {
+		fields := parser.ExplicitPadding(named.Type)
+		if fields == nil {
+			continue
+		}
+		name := exportName(named.Name)
+		fmt.Fprintf(&b, "\nstruct %s {\n", name)
+		var asserts []string
+		for i, field := range fields {
+			switch {
+			case field.Field == nil:
+				fmt.Fprintf(&b, "\tuint8_t _pad%d[%d]; /* offset %d, %s */\n",
+					i, field.Size, field.Offset, field.Comment())
+			case field.Size == 0:
+				fmt.Fprintf(&b, "\t/* %s: offset %d, zero sized */\n", field.Decl(), field.Offset)
+			default:
+				fieldName := cFieldName(field.Field.FieldName, i)
+				fmt.Fprintf(&b, "\t%s; /* offset %d, %s */\n",
+					cFieldDecl(field.Field, fieldName), field.Offset, field.Decl())
+				if field.Field.FieldName != "_" {
+					asserts = append(asserts, fmt.Sprintf(
+						"_Static_assert(offsetof(struct %s, %s) == %d, \"offset of %s.%s\");\n",
+						name, fieldName, field.Offset, name, fieldName,
+					))
+				}
+			}
+		}
+		b.WriteString("};\n")
+		fmt.Fprintf(&b, "_Static_assert(sizeof(struct %s) == %d, \"size of %s\");\n",
+			name, named.Type.Sizeof, name)
+		for _, assert := range asserts {
+			b.WriteString(assert)
+		}
+	}
+	return b.String()
+}
+
+// Returns C declaration of given struct field with given name.
+func cFieldDecl(field *parser.TypeInfo, name string) string {
+	if !field.IsStruct && !field.IsArray {
+		if typ, basic := cBasicTypes[field.TypeName]; basic {
+			return typ + " " + name
+		}
+		switch field.TypeName {
+		case "int", "uint", "uintptr":
+			return fmt.Sprintf("%s%d_t %s",
+				strings.TrimSuffix(field.TypeName, "ptr"), field.Sizeof*8, name)
+		}
+	}
+	return fmt.Sprintf("_Alignas(%d) uint8_t %s[%d]", field.Alignof, name, field.Sizeof)
+}
+
+// C keywords which cannot be used as field names.
+var cKeywords = map[string]bool{
+	"auto": true, "char": true, "double": true, "enum": true, "extern": true,
+	"float": true, "int": true, "long": true, "register": true, "short": true,
+	"signed": true, "sizeof": true, "static": true, "typedef": true,
+	"union": true, "unsigned": true, "void": true, "volatile": true,
+	"while": true, "do": true, "inline": true, "restrict": true, "bool": true,
+}
+
+// Returns C name of struct field with given Go name at given position.
+func cFieldName(name string, i int) string {
+	switch {
+	case name == "_":
+		return fmt.Sprintf("_blank%d", i)
+	case cKeywords[name]:
+		return name + "_"
+	}
+	return name
+}
+
+// Returns identifier which given type is exported with: instantiation of
+// generic type (like "Box[int64]") has brackets replaced, and anonymous
+// type is named "T".
+func exportName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+	name = strings.TrimRight(name, "_")
+	if name == "" {
+		return "T"
+	}
+	return name
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/parser"
+)
+
+func TestExport(t *testing.T) {
+	appLog = &nopLogger{}
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	code := "type A struct {\n\ta bool\n\tb int64\n\ts string\n\tint uint32\n}\ntype B [3]int"
+	export := func(to string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/?export="+to, nil)
+		w := httptest.NewRecorder()
+		renderDiscover(w, r, code)
+		return w
+	}
+
+	w := export(exportGo)
+	if w.Header().Get("Content-Disposition") != `attachment; filename="types.go"` {
+		t.Errorf("invalid Go export headers: %v", w.Header())
+	}
+	// Exported struct has the same layout without implicit padding
+	body := w.Body.String()
+	types, err := parser.ParseDecls(body[strings.Index(body, "type "):], parser.Archs[parser.DefaultArch])
+	if err != nil {
+		t.Fatalf("failed to parse exported Go file, reason -> %s\n%s", err.Error(), body)
+	}
+	if len(types) != 1 || types[0].Type.Sizeof != 40 ||
+		!strings.Contains(body, "_   [7]byte // offset 1, padding") {
+		t.Errorf("invalid exported Go file:\n%s", body)
+	}
+
+	w = export(exportC)
+	body = w.Body.String()
+	if w.Header().Get("Content-Disposition") != `attachment; filename="types.h"` ||
+		!strings.Contains(body, "uint8_t _pad1[7]; /* offset 1, padding */") ||
+		!strings.Contains(body, "_Alignas(8) uint8_t s[16]; /* offset 16, s string */") ||
+		!strings.Contains(body, "uint32_t int_; /* offset 32, int uint32 */") ||
+		!strings.Contains(body, `_Static_assert(sizeof(struct A) == 40, "size of A");`) {
+		t.Errorf("invalid exported C header:\n%s", body)
+	}
+
+	w = export(exportText)
+	body = w.Body.String()
+	if w.Header().Get("Content-Disposition") != `attachment; filename="layout.txt"` ||
+		!strings.Contains(body, "A (size 40, alignment 8)\n") ||
+		!strings.Contains(body, "    0 │ A │ ░ │ ░ │ ░ │ ░ │ ░ │ ░ │ ░ │\n") ||
+		!strings.Contains(body, "▒  tail padding\n") ||
+		!strings.Contains(body, "total    40 bytes\n") ||
+		!strings.Contains(body, "B (size 24, alignment 8)\n") {
+		t.Errorf("invalid exported diagrams:\n%s", body)
+	}
+
+	if body = export("pdf").Body.String(); !strings.Contains(body, "unsupported export format") {
+		t.Errorf("unsupported export format is not reported:\n%s", body)
+	}
+}
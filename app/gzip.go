@@ -0,0 +1,188 @@
+package app
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Responses shorter than this number of bytes are not compressed,
+// as gzip overhead outweighs savings.
+const gzipMinLength = 1 << 10
+
+var gzipWriters = sync.Pool{
+	New: func() interface{} {
+		return gzip.NewWriter(nil)
+	},
+}
+
+// Wrapper of http.ResponseWriter which buffers beginning of response until
+// gzipMinLength bytes are written, and then compresses the rest of it.
+// Shorter responses are written uncompressed when handler returns.
+type gzipWriter struct {
+	http.ResponseWriter
+	status  int
+	buf     []byte
+	started bool // response headers are written
+	gz      *gzip.Writer
+}
+
+func (w *gzipWriter) WriteHeader(code int) {
+	if w.started {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *gzipWriter) Write(b []byte) (int, error) {
+	if !w.started {
+		w.buf = append(w.buf, b...)
+		if len(w.buf) < gzipMinLength {
+			return len(b), nil
+		}
+		if err := w.start(true); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush compresses buffered part of response and flushes it to client,
+// so that streaming is not delayed. Length of streamed response is not
+// known in advance, so it is compressed even if it is short yet.
+func (w *gzipWriter) Flush() {
+	if !w.started {
+		if err := w.start(true); err != nil {
+			return
+		}
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Writes response headers and buffered part of response, compressing
+// the rest of response if requested and response can be compressed.
+func (w *gzipWriter) start(compress bool) error {
+	w.started = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	header := w.Header()
+	switch {
+	case w.status == http.StatusPartialContent,
+		w.status == http.StatusNoContent,
+		w.status == http.StatusNotModified,
+		header.Get("Content-Encoding") != "":
+		compress = false
+	}
+	if compress {
+		// Content type is detected before compression,
+		// as it cannot be detected from compressed bytes.
+		if header.Get("Content-Type") == "" {
+			header.Set("Content-Type", http.DetectContentType(w.buf))
+		}
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", "gzip")
+		w.gz = gzipWriters.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// Finishes response: writes short buffered response as is
+// or completes compressed stream.
+func (w *gzipWriter) close() {
+	if !w.started {
+		_ = w.start(false)
+		return
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+		w.gz.Reset(nil)
+		gzipWriters.Put(w.gz)
+		w.gz = nil
+	}
+}
+
+// Releases compressor of response, which handler failed to complete because
+// of panic. Uncommitted response is discarded, so that error response can be
+// written instead of it, without gzip encoding.
+func (w *gzipWriter) abort() {
+	if !w.started {
+		w.buf = nil
+		w.Header().Del("Content-Encoding")
+		return
+	}
+	if w.gz != nil {
+		w.gz.Reset(nil)
+		gzipWriters.Put(w.gz)
+		w.gz = nil
+	}
+}
+
+// Returns whether client accepts gzip encoded response.
+func acceptsGzip(r *http.Request) bool {
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		parts := strings.Split(enc, ";")
+		if strings.TrimSpace(parts[0]) != "gzip" {
+			continue
+		}
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				q, err := strconv.ParseFloat(param[2:], 64)
+				return err == nil && q > 0
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Middleware which compresses responses with gzip, if client accepts it.
+// Metrics and health responses are not compressed.
+func useGzip(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metrics", healthzPath, readyzPath:
+			handler.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Accept-Encoding")
+		if r.Method == http.MethodHead || !acceptsGzip(r) {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		gw := &gzipWriter{ResponseWriter: w}
+		completed := false
+		defer func() {
+			if !completed {
+				gw.abort()
+				return
+			}
+			gw.close()
+		}()
+		handler.ServeHTTP(gw, r)
+		completed = true
+	})
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUseGzip(t *testing.T) {
+	long := strings.Repeat("sizeof ", gzipMinLength)
+	handler := useGzip(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100500")
+		if r.URL.Path == "/short" {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("short"))
+			return
+		}
+		// Written in parts, so that compression starts in the middle
+		_, _ = w.Write([]byte(long[:10]))
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte(long[10:]))
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/long", nil)
+	r.Header.Set("Accept-Encoding", "deflate, gzip;q=0.8")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Header().Get("Content-Encoding") != "gzip" || w.Header().Get("Content-Length") != "" {
+		t.Fatalf("response is not compressed: %v", w.Header())
+	}
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf("invalid content type: %s", w.Header().Get("Content-Type"))
+	}
+	zr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("failed to read compressed response, reason -> %s", err.Error())
+	}
+	body, err := ioutil.ReadAll(zr)
+	if err != nil || string(body) != long {
+		t.Errorf("invalid decompressed response: %v", err)
+	}
+
+	for path, encoding := range map[string]string{
+		"/short":   "gzip",
+		"/long":    "gzip;q=0",
+		"/metrics": "gzip",
+	} {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.Header.Set("Accept-Encoding", encoding)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Header().Get("Content-Encoding") != "" {
+			t.Errorf("response of %s (%s) is compressed", path, encoding)
+		}
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/short", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusCreated || w.Body.String() != "short" ||
+		w.Header().Get("Content-Length") != "100500" {
+		t.Errorf("invalid short response: %d %s %v", w.Code, w.Body.String(), w.Header())
+	}
+}
+
+func TestUseGzipStreaming(t *testing.T) {
+	appLog, accessLog = &nopLogger{}, &nopLogger{}
+	defer func() { appLog, accessLog = nil, nil }()
+	long := strings.Repeat("sizeof ", gzipMinLength)
+	w := httptest.NewRecorder()
+	// Flush passes through all middlewares of application to connection
+	handler := useCommon(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte(long[:10]))
+		rw.(http.Flusher).Flush()
+		if !w.Flushed || w.Body.Len() == 0 {
+			t.Errorf("response is not flushed to connection")
+		}
+		_, _ = rw.Write([]byte(long[10:]))
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	handler.ServeHTTP(w, r)
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("response is not compressed: %v", w.Header())
+	}
+	zr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("failed to read compressed response, reason -> %s", err.Error())
+	}
+	if body, err := ioutil.ReadAll(zr); err != nil || string(body) != long {
+		t.Errorf("invalid decompressed response: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/log"
+)
+
+// Paths of health handlers, which are not written into access log.
+const (
+	healthzPath = "/healthz"
+	readyzPath  = "/readyz"
+)
+
+// Handler which reports that process is alive.
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "ok")
+}
+
+// Handler which reports whether application is ready to serve requests:
+// templates are parsed and log directory is writable.
+func readyzHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := checkReadiness(); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(w, err.Error())
+		return
+	}
+	fmt.Fprintln(w, "ok")
+}
+
+// Helper function which checks readiness of application and returns reason
+// if it is not ready.
+func checkReadiness() error {
+	if _, ok := templates["index"]; !ok {
+		return fmt.Errorf("templates are not parsed")
+	}
+	// Logs directory does not matter when logs are written to stderr
+	if log.UsesFallback(appLog) {
+		return nil
+	}
+	dir := filepath.Dir(log.FilePath(log.ApplicationLogFile, appRoot))
+	f, err := ioutil.TempFile(dir, ".readyz")
+	if err != nil {
+		return fmt.Errorf("log directory is not writable: %s", err)
+	}
+	f.Close()
+	os.Remove(f.Name())
+	return nil
+}
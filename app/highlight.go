@@ -0,0 +1,152 @@
+package app
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"html/template"
+	"strings"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/parser"
+)
+
+// Line of submitted code rendered with syntax highlighting.
+type sourceLine struct {
+	Number int
+	HTML   template.HTML
+	Note   string // layout of fields declared in line
+	Indent string // aligns notes of all lines
+}
+
+// Width of tab used to align notes, matches tab-size of rendered source.
+const tabWidth = 4
+
+// Names of predeclared types, highlighted same as keywords.
+var predeclaredTypes = map[string]bool{
+	"bool": true, "byte": true, "rune": true, "string": true,
+	"error": true, "any": true, "uintptr": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
+// Returns CSS class of given token, or empty string if it is not highlighted.
+func tokenClass(tok token.Token, lit string) string {
+	switch {
+	case tok == token.COMMENT:
+		return "comment"
+	case tok.IsKeyword():
+		return "keyword"
+	case tok.IsLiteral() && tok != token.IDENT:
+		return "literal"
+	case tok == token.IDENT && predeclaredTypes[lit]:
+		return "type"
+	}
+	return ""
+}
+
+// Returns lines of given code with tokens wrapped into spans classified
+// by tokenClass, annotated with given notes by line numbers.
+func highlightCode(code string, notes map[int]string) []*sourceLine {
+	code = strings.TrimSuffix(strings.Replace(code, "\r\n", "\n", -1), "\n")
+	lines := []*sourceLine{{Number: 1}}
+	widths := []int{0} // widths of lines with expanded tabs
+	var html strings.Builder
+	emit := func(class, s string) {
+		for i, part := range strings.Split(s, "\n") {
+			if i > 0 {
+				lines[len(lines)-1].HTML = template.HTML(html.String())
+				html.Reset()
+				lines = append(lines, &sourceLine{Number: len(lines) + 1})
+				widths = append(widths, 0)
+			}
+			if part == "" {
+				continue
+			}
+			widths[len(widths)-1] += len(part) + strings.Count(part, "\t")*(tabWidth-1)
+			if class == "" {
+				html.WriteString(template.HTMLEscapeString(part))
+				continue
+			}
+			fmt.Fprintf(&html, `<span class="%s">%s</span>`,
+				class, template.HTMLEscapeString(part))
+		}
+	}
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(code))
+	var s scanner.Scanner
+	s.Init(file, []byte(code), nil, scanner.ScanComments)
+	end := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		// Skip semicolons inserted by scanner at line ends
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+		offset := file.Offset(pos)
+		if offset < end {
+			continue
+		}
+		size := len(tok.String())
+		if lit != "" {
+			size = len(lit)
+		}
+		if offset+size > len(code) {
+			size = len(code) - offset
+		}
+		emit("", code[end:offset])
+		emit(tokenClass(tok, lit), code[offset:offset+size])
+		end = offset + size
+	}
+	emit("", code[end:])
+	lines[len(lines)-1].HTML = template.HTML(html.String())
+
+	// Notes start at same column, after the longest annotated line
+	column := 0
+	for i, line := range lines {
+		if _, ok := notes[line.Number]; ok && widths[i] > column {
+			column = widths[i]
+		}
+	}
+	for i, line := range lines {
+		if note, ok := notes[line.Number]; ok {
+			line.Note = note
+			line.Indent = strings.Repeat(" ", column-widths[i]+2)
+		}
+	}
+	return lines
+}
+
+// Returns notes with offsets and sizes of top level fields of given types
+// by lines where fields are declared. Several fields declared in one line
+// are prefixed with their names.
+func fieldNotes(types []*parser.NamedType) map[int]string {
+	fields := make(map[int][]*parser.TypeInfo)
+	for _, typ := range types {
+		// Instantiations share lines with their generic declaration
+		if typ.Type == nil || !typ.Type.IsStruct || strings.Contains(typ.Name, "[") {
+			continue
+		}
+		for _, field := range typ.Type.Fields {
+			if field.Line > 0 {
+				fields[field.Line] = append(fields[field.Line], field)
+			}
+		}
+	}
+	notes := make(map[int]string, len(fields))
+	for line, list := range fields {
+		parts := make([]string, len(list))
+		for i, field := range list {
+			parts[i] = fmt.Sprintf("offset %d, size %d", field.Offset, field.Sizeof)
+			if len(list) > 1 {
+				parts[i] = field.FieldName + ": " + parts[i]
+			}
+		}
+		notes[line] = "// " + strings.Join(parts, "; ")
+	}
+	return notes
+}
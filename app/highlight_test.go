@@ -0,0 +1,37 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/parser"
+)
+
+func TestHighlightCode(t *testing.T) {
+	code := "type A struct {\n\ta bool // <flag>\n\tb, c int64\n\ts string `json:\"s\"`\n}\n"
+	types, err := parser.ParseDecls(code, parser.Archs["amd64"])
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	lines := highlightCode(code, fieldNotes(types))
+	if len(lines) != 5 {
+		t.Fatalf("invalid number of lines, expected: 5, actual: %d", len(lines))
+	}
+	if html := string(lines[1].HTML); html != "\ta <span class=\"type\">bool</span> "+
+		"<span class=\"comment\">// &lt;flag&gt;</span>" {
+		t.Errorf("invalid highlighting of line 2: %s", html)
+	}
+	if !strings.Contains(string(lines[3].HTML), `<span class="literal">`) {
+		t.Errorf("field tag is not highlighted: %s", lines[3].HTML)
+	}
+	if lines[0].Note != "" || lines[1].Note != "// offset 0, size 1" ||
+		lines[2].Note != "// b: offset 8, size 8; c: offset 16, size 8" {
+		t.Errorf("invalid notes: '%s', '%s', '%s'",
+			lines[0].Note, lines[1].Note, lines[2].Note)
+	}
+	// Notes are aligned after the longest annotated line
+	if len(lines[1].Indent) != 5 || len(lines[2].Indent) != 11 {
+		t.Errorf("notes are not aligned: %d, %d",
+			len(lines[1].Indent), len(lines[2].Indent))
+	}
+}
@@ -1,46 +1,133 @@
 package app
 
 import (
-	"fmt"
+	"context"
+	"io/fs"
 	"net/http"
+	"path"
 	"runtime"
 	"strings"
-
-	"github.com/chappjc/go-sizeof-webapp/internal/bindata/static"
 )
 
-func bindHttpHandlers() {
+// Path which browsers request icon of site from.
+const faviconPath = "/favicon.ico"
+
+func bindHttpHandlers(corsOrigins []string) http.Handler {
 	fileServer := http.NewServeMux()
-	fileServer.Handle("/", useCustom404(http.FileServer(static.AssetFS())))
+	fileServer.Handle("/", useCustom404(http.HandlerFunc(serveStatic)))
 
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if p := recover(); p != nil {
-				buf := make([]byte, 1<<16)
-				runtime.Stack(buf, false)
-				reason := fmt.Sprintf("%v: %s", r, buf)
-				appLog.Critical("Runtime failure, reason -> %s", reason)
-				write500(w)
-			}
-		}()
+	// Handlers which parse submitted code share rate limit of client
+	// and pool of parse slots
+	limiter := newRateLimiter(rateLimit, rateBurst)
+	pool := newParsePool(maxParse, parseQueueWait)
+	limited := func(handler http.HandlerFunc) http.Handler {
+		return useRateLimit(limiter, useParsePool(pool, handler))
+	}
+	get := func(handler http.Handler) http.Handler {
+		return useMethods(handler, http.MethodGet)
+	}
+	// Source file can be uploaded to discover handler with POST request
+	discover := useMethods(limited(discoverHandler), http.MethodGet, http.MethodPost)
+	static := get(fileServer)
+	// JSON API handlers accept POST requests with bodies of given media types
+	api := func(handler http.HandlerFunc, mediaTypes ...string) http.Handler {
+		return useCORS(corsOrigins, useAPIMethods(
+			useContentType(limited(handler), mediaTypes...), http.MethodPost,
+		))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/debug/loglevel",
+		useDebugAuth(useMethods(http.HandlerFunc(logLevelHandler), http.MethodPost).ServeHTTP))
+	bindPprofHandlers(mux)
+	mux.Handle(healthzPath, get(http.HandlerFunc(healthzHandler)))
+	mux.Handle(readyzPath, get(http.HandlerFunc(readyzHandler)))
+	mux.Handle("/metrics", get(appMetrics))
+	mux.Handle(apiSizeofPath, api(apiSizeofHandler, mediaTypeJSON, mediaTypeText))
+	mux.Handle(comparePath, useMethods(limited(compareHandler), http.MethodGet, http.MethodPost))
+	mux.Handle(apiComparePath, api(apiCompareHandler, mediaTypeJSON))
+	mux.Handle(apiAssertPath, api(apiAssertHandler, mediaTypeJSON))
+	mux.Handle(apiStdTypePath, useCORS(corsOrigins, useAPIMethods(http.HandlerFunc(apiStdTypeHandler), http.MethodGet)))
+	mux.Handle(faviconPath, get(http.HandlerFunc(faviconHandler)))
+	mux.Handle(sharePath, useAPIMethods(http.HandlerFunc(shareHandler), http.MethodPost))
+	mux.Handle(sharedPathPrefix, get(limited(sharedHandler)))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case strings.Contains(r.URL.Path, "."):
-			fileServer.ServeHTTP(w, r)
+			static.ServeHTTP(w, r)
 			return
 		case r.URL.Path != "/":
+			requestLog(r).Debug("No page at '%s'", r.URL.Path)
 			write404(w)
 			return
 		}
-		discoverHandler(w, r)
+		discover.ServeHTTP(w, r)
+	})
+
+	return useCommon(mux)
+}
+
+// Wraps given handler with middlewares which are common for all requests.
+// Recovery is the outermost one, so that panics of other middlewares are
+// recovered too.
+func useCommon(handler http.Handler) http.Handler {
+	return useRecovery(useRequestID(useAccessLog(useGzip(handler))))
+}
+
+// Middleware which recovers panics occurred during request handling, logs
+// them with stack trace and responds with 500 error page. Request ID is
+// assigned by inner middleware, so it is taken from response header to be
+// logged.
+func useRecovery(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if p := recover(); p != nil {
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+				if id := w.Header().Get(requestIDHeader); id != "" && requestID(r.Context()) == "" {
+					r = r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id))
+				}
+				buf := make([]byte, 1<<16)
+				buf = buf[:runtime.Stack(buf, false)]
+				_ = requestLog(r).Error("Runtime failure, reason -> %v: %s", p, buf)
+				write500(w)
+			}
+		}()
+		handler.ServeHTTP(w, r)
 	})
 }
 
+// Serves static files from assets.
+func serveStatic(w http.ResponseWriter, r *http.Request) {
+	static, err := fs.Sub(assetsFS(), staticDir)
+	if err != nil {
+		write500(w)
+		return
+	}
+	http.FileServer(http.FS(static)).ServeHTTP(w, r)
+}
+
+// Serves icon of site from assets, so that browsers do not fall through
+// to 404 page requesting it.
+func faviconHandler(w http.ResponseWriter, r *http.Request) {
+	icon, err := fs.ReadFile(assetsFS(), path.Join(staticDir, "images", "favicon.ico"))
+	if err != nil {
+		write404(w)
+		return
+	}
+	w.Header().Set("Content-Type", "image/x-icon")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	_, _ = w.Write(icon)
+}
+
 func write500(w http.ResponseWriter) {
-	templates["500"].ExecuteTemplate(w, "base", nil)
+	renderTemplateStatus(w, http.StatusInternalServerError, "500", nil)
 }
 
 func write404(w http.ResponseWriter) {
-	templates["404"].ExecuteTemplate(w, "base", nil)
+	renderTemplateStatus(w, http.StatusNotFound, "404", nil)
 }
 
 type hijack404 struct {
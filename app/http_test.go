@@ -0,0 +1,218 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/log"
+	"github.com/chappjc/go-sizeof-webapp/internal/log/filelog"
+)
+
+func TestMain(m *testing.M) {
+	// Assets are embedded by main package, so tests read them from disk
+	Assets = os.DirFS("..")
+	os.Exit(m.Run())
+}
+
+// Logger which discards all records, except counting errors.
+type nopLogger struct {
+	errors int
+}
+
+func (*nopLogger) Debug(interface{}, ...interface{})          {}
+func (*nopLogger) Trace(interface{}, ...interface{})          {}
+func (*nopLogger) Info(interface{}, ...interface{})           {}
+func (*nopLogger) Warn(interface{}, ...interface{}) error     { return nil }
+func (*nopLogger) Critical(interface{}, ...interface{}) error { return nil }
+func (*nopLogger) Fatal(interface{}, ...interface{})          {}
+func (*nopLogger) SetLevel(string) error                      { return nil }
+func (*nopLogger) SetCallDepth(int)                           {}
+func (*nopLogger) Stats() filelog.Stats                       { return filelog.Stats{} }
+func (*nopLogger) Close()                                     {}
+
+func (l *nopLogger) Error(interface{}, ...interface{}) error {
+	l.errors++
+	return nil
+}
+
+func (l *nopLogger) With(map[string]interface{}) log.Logger {
+	return l
+}
+
+// Logger which remembers fields of the last derived logger.
+type fieldsLogger struct {
+	nopLogger
+	fields map[string]interface{}
+}
+
+func (l *fieldsLogger) With(fields map[string]interface{}) log.Logger {
+	l.fields = fields
+	return l
+}
+
+func TestUseRecovery(t *testing.T) {
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	lgr := &nopLogger{}
+	appLog = lgr
+	defer func() {
+		appLog = nil
+	}()
+
+	handler := useRecovery(http.HandlerFunc(
+		func(http.ResponseWriter, *http.Request) {
+			panic("test panic")
+		},
+	))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status code expected %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+	if lgr.errors != 1 {
+		t.Errorf("panic expected to be logged as error")
+	}
+
+	// Panic is logged with ID of request, which is assigned inside of
+	// recovery
+	flgr := &fieldsLogger{}
+	appLog, accessLog = flgr, &nopLogger{}
+	defer func() {
+		accessLog = nil
+	}()
+	handler = useCommon(http.HandlerFunc(
+		func(http.ResponseWriter, *http.Request) {
+			panic("test panic")
+		},
+	))
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set(requestIDHeader, "test-id")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusInternalServerError || w.Header().Get(requestIDHeader) != "test-id" {
+		t.Errorf("invalid response to panic: %d %v", w.Code, w.Header())
+	}
+	if flgr.errors != 1 || flgr.fields["request_id"] != "test-id" {
+		t.Errorf("panic expected to be logged with request ID, got %v", flgr.fields)
+	}
+
+	// Panic is counted as 500, and buffered part of gzip response
+	// is discarded
+	handler = useCommon(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("partial"))
+			panic("test panic")
+		},
+	))
+	failed := httpRequestsTotal.Count("500")
+	r = httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusInternalServerError || w.Header().Get("Content-Encoding") != "" ||
+		strings.Contains(w.Body.String(), "partial") {
+		t.Errorf("invalid gzip response to panic: %d %v\n%s", w.Code, w.Header(), w.Body.String())
+	}
+	if count := httpRequestsTotal.Count("500"); count != failed+1 {
+		t.Errorf("panic expected to be counted as 500, count %d", count-failed)
+	}
+}
+
+func TestPprofHandlers(t *testing.T) {
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	appLog = &nopLogger{}
+	defer func() {
+		appLog, debugToken, pprofEnabled = nil, "", false
+	}()
+	debugToken = "secret"
+
+	request := func(token string) int {
+		r := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		bindHttpHandlers(nil).ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := request("secret"); code != http.StatusNotFound {
+		t.Errorf("pprof handlers are routed when disabled: %d", code)
+	}
+	pprofEnabled = true
+	if code := request(""); code != http.StatusNotFound {
+		t.Errorf("pprof handlers are accessible without token: %d", code)
+	}
+	if code := request("secret"); code != http.StatusOK {
+		t.Errorf("pprof handlers are not accessible with token: %d", code)
+	}
+}
+
+func TestUsePprofSeconds(t *testing.T) {
+	appLog = &nopLogger{}
+	defer func() {
+		appLog = nil
+	}()
+	var seconds string
+	handler := usePprofSeconds(30, func(_ http.ResponseWriter, r *http.Request) {
+		seconds = r.FormValue("seconds")
+	})
+
+	srv := &http.Server{WriteTimeout: pprofWriteMargin + 3*time.Second}
+	for query, expected := range map[string]string{
+		"":            "3",
+		"?seconds=2":  "2",
+		"?seconds=3":  "3",
+		"?seconds=60": "3",
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/debug/pprof/profile"+query, nil)
+		r = r.WithContext(context.WithValue(r.Context(), http.ServerContextKey, srv))
+		handler(httptest.NewRecorder(), r)
+		if seconds != expected {
+			t.Errorf("invalid profile duration of '%s'\n\texpected: %s\n\tactual: %s",
+				query, expected, seconds)
+		}
+	}
+
+	// Duration is not changed without write timeout
+	srv.WriteTimeout = 0
+	r := httptest.NewRequest(http.MethodGet, "/debug/pprof/profile?seconds=60", nil)
+	r = r.WithContext(context.WithValue(r.Context(), http.ServerContextKey, srv))
+	handler(httptest.NewRecorder(), r)
+	if seconds != "60" {
+		t.Errorf("profile duration is changed without write timeout: %s", seconds)
+	}
+}
+
+func TestFaviconAndNotFound(t *testing.T) {
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	appLog = &nopLogger{}
+	defer func() {
+		appLog = nil
+	}()
+	handler := bindHttpHandlers(nil)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, faviconPath, nil))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "image/x-icon" ||
+		w.Body.Len() == 0 {
+		t.Errorf("invalid favicon response: %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/no/such/page", nil))
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "No page here") {
+		t.Errorf("invalid not found response: %d %s", w.Code, w.Body.String())
+	}
+}
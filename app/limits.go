@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/parser"
+)
+
+// Limits of sizeof submissions, which can be changed
+// with GOMAXINPUT and GOPARSETIMEOUT env vars.
+var (
+	maxInputSize int64 = 64 << 10
+	parseTimeout       = 2 * time.Second
+)
+
+// Returns message of error for submissions exceeding maxInputSize.
+func inputTooLargeMessage() string {
+	return fmt.Sprintf("input exceeds limit of %d bytes", maxInputSize)
+}
+
+// Error of submission exceeding maxInputSize.
+type inputTooLargeError struct{}
+
+func (*inputTooLargeError) Error() string {
+	return inputTooLargeMessage()
+}
+
+// Error of parsing which took longer than parseTimeout.
+type parseTimeoutError struct {
+	timeout time.Duration
+}
+
+func (err *parseTimeoutError) Error() string {
+	return fmt.Sprintf("parsing took longer than %s", err.timeout)
+}
+
+// Returns whether given error of parsing is caused by source, which layout
+// has more fields than parser lays out. Few KiB of nested declarations can
+// expand to that many fields, so they are rejected like oversized input,
+// not to exhaust memory.
+func isTooComplex(err error) bool {
+	return errors.Is(err, parser.ErrTooComplex)
+}
+
+// Helper function which replaces error of parsing cancelled by timeout
+// with parseTimeoutError, and logs rejection of too complex source.
+func parseError(ctx context.Context, r *http.Request, err error) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return &parseTimeoutError{timeout: parseTimeout}
+	}
+	if isTooComplex(err) {
+		_ = requestLog(r).Warn("Rejected too complex sizeof request from %s", r.RemoteAddr)
+	}
+	return err
+}
+
+func logOversizedInput(r *http.Request) {
+	_ = requestLog(r).Warn(
+		"Rejected oversized sizeof request from %s, limit is %d bytes",
+		r.RemoteAddr, maxInputSize,
+	)
+}
+
+// Parses types declared in given code and given instantiations of generic
+// types, giving up after parseTimeout.
+func parseDecls(
+	r *http.Request,
+	code string,
+	arch *parser.Arch,
+	insts ...string,
+) ([]*parser.NamedType, error) {
+	ctx, cancel := context.WithTimeout(r.Context(), parseTimeout)
+	defer cancel()
+	types, err := parser.ParseDeclsContext(ctx, code, arch, insts...)
+	if err != nil {
+		return nil, parseError(ctx, r, err)
+	}
+	return types, nil
+}
+
+// Parses given type expression, which may refer to types declared in given
+// code, giving up after parseTimeout.
+func parseTypeExpr(
+	r *http.Request,
+	code, expr string,
+	arch *parser.Arch,
+) (*parser.TypeInfo, error) {
+	ctx, cancel := context.WithTimeout(r.Context(), parseTimeout)
+	defer cancel()
+	typ, err := parser.ParseTypeContext(ctx, code, expr, arch)
+	if err != nil {
+		return nil, parseError(ctx, r, err)
+	}
+	return typ, nil
+}
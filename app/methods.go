@@ -0,0 +1,51 @@
+package app
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Middleware which responds to requests of methods other than given ones
+// with 405 error and "Allow" header listing given methods. HEAD method is
+// allowed wherever GET is, as server responds to it without body.
+func useMethods(handler http.Handler, methods ...string) http.Handler {
+	return allowMethods(handler, methods, func(w http.ResponseWriter) {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	})
+}
+
+// Middleware which acts as useMethods, but responds with JSON error of API.
+func useAPIMethods(handler http.Handler, methods ...string) http.Handler {
+	return allowMethods(handler, methods, func(w http.ResponseWriter) {
+		writeAPIError(w, http.StatusMethodNotAllowed, codeMethodNotAllowed, "method not allowed")
+	})
+}
+
+// Helper function which wraps given handler to be called for requests of
+// given methods only and to reject others with given function.
+func allowMethods(
+	handler http.Handler, methods []string, reject func(w http.ResponseWriter),
+) http.Handler {
+	allowed := make(map[string]bool, len(methods)+1)
+	var list []string
+	for _, method := range methods {
+		if allowed[method] {
+			continue
+		}
+		allowed[method] = true
+		list = append(list, method)
+		if method == http.MethodGet && !allowed[http.MethodHead] {
+			allowed[http.MethodHead] = true
+			list = append(list, http.MethodHead)
+		}
+	}
+	allow := strings.Join(list, ", ")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowed[r.Method] {
+			w.Header().Set("Allow", allow)
+			reject(w)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
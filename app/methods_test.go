@@ -0,0 +1,73 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUseMethods(t *testing.T) {
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	appLog, accessLog = &nopLogger{}, &nopLogger{}
+	defer func() {
+		appLog, accessLog, debugToken = nil, nil, ""
+	}()
+	debugToken = "secret"
+	handler := bindHttpHandlers(nil)
+
+	for _, c := range []struct {
+		method, path, allow string
+		api                 bool
+	}{
+		{http.MethodGet, apiSizeofPath, "POST", true},
+		{http.MethodPut, apiSizeofPath, "POST", true},
+		{http.MethodGet, apiComparePath, "POST", true},
+		{http.MethodGet, apiAssertPath, "POST", true},
+		{http.MethodGet, sharePath, "POST", true},
+		{http.MethodPost, apiStdTypePath, "GET, HEAD", true},
+		{http.MethodDelete, comparePath, "GET, HEAD, POST", false},
+		{http.MethodGet, "/debug/loglevel", "POST", false},
+		{http.MethodPost, healthzPath, "GET, HEAD", false},
+		{http.MethodPost, readyzPath, "GET, HEAD", false},
+		{http.MethodPost, "/metrics", "GET, HEAD", false},
+		{http.MethodPost, faviconPath, "GET, HEAD", false},
+		{http.MethodPost, sharedPathPrefix + "abc", "GET, HEAD", false},
+		{http.MethodDelete, "/", "GET, HEAD, POST", false},
+		{http.MethodPost, "/main.min.css", "GET, HEAD", false},
+	} {
+		r := httptest.NewRequest(c.method, c.path, nil)
+		r.Header.Set("Authorization", "Bearer secret")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != c.allow {
+			t.Errorf("%s %s: expected 405 allowing '%s', got %d allowing '%s'",
+				c.method, c.path, c.allow, w.Code, w.Header().Get("Allow"))
+			continue
+		}
+		var apiErr apiError
+		isAPIErr := json.Unmarshal(w.Body.Bytes(), &apiErr) == nil && apiErr.Error != nil &&
+			apiErr.Error.Code == codeMethodNotAllowed
+		if isAPIErr != c.api {
+			t.Errorf("%s %s: expected API error %t, got: %s", c.method, c.path, c.api, w.Body.String())
+		}
+	}
+
+	// Debug handler stays hidden from requests without token
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/loglevel", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("debug handler expected to respond with 404 without token, got %d", w.Code)
+	}
+
+	// Allowed methods are served
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, healthzPath, nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s %s: expected 200, got %d", method, healthzPath, w.Code)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/log/filelog"
+	"github.com/chappjc/go-sizeof-webapp/internal/metrics"
+)
+
+// Registry of application metrics exposed on /metrics.
+var appMetrics = metrics.NewRegistry()
+
+var (
+	httpRequestsTotal = appMetrics.NewCounterVec(
+		"sizeof_http_requests_total",
+		"Total number of served HTTP requests by status code.",
+		"status",
+	)
+	httpRequestDuration = appMetrics.NewHistogram(
+		"sizeof_http_request_duration_seconds",
+		"Duration of served HTTP requests in seconds.",
+		metrics.DefaultBuckets,
+	)
+	parseDuration = appMetrics.NewHistogramVec(
+		"sizeof_parse_duration_seconds",
+		"Duration of parsing and laying out submitted types in seconds by result and number of types.",
+		defaultParseBuckets, "result", "types",
+	)
+	_ = appMetrics.NewCounterFunc(
+		"sizeof_log_rotations_total",
+		"Total number of log files rotations by log.",
+		"log", logStats(func(s filelog.Stats) uint64 { return s.Rotations }),
+	)
+	_ = appMetrics.NewCounterFunc(
+		"sizeof_log_written_bytes_total",
+		"Total number of bytes written into log files by log.",
+		"log", logStats(func(s filelog.Stats) uint64 { return s.BytesWritten }),
+	)
+	_ = appMetrics.NewCounterFunc(
+		"sizeof_log_dropped_records_total",
+		"Total number of log records dropped due to write errors by log.",
+		"log", logStats(func(s filelog.Stats) uint64 { return s.Dropped }),
+	)
+	_ = appMetrics.NewCounterFunc(
+		"sizeof_log_overflowed_records_total",
+		"Total number of log records dropped due to full buffer by log.",
+		"log", logStats(func(s filelog.Stats) uint64 { return s.Overflowed }),
+	)
+	_ = appMetrics.NewCounterFunc(
+		"sizeof_log_syslog_dropped_records_total",
+		"Total number of log records not sent to syslog by log.",
+		"log", logStats(func(s filelog.Stats) uint64 { return s.SyslogDropped }),
+	)
+)
+
+// Default upper bounds of buckets of parse durations in seconds, which can be
+// changed with GOPARSEBUCKETS env var.
+var defaultParseBuckets = []float64{
+	.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5,
+}
+
+// Helper function which observes duration of parsing since given start for
+// given request, with result of parsing and number of laid out types. ID of
+// request is kept as exemplar, so that slow inputs can be found in logs.
+func observeParse(r *http.Request, start time.Time, types int, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	var exemplar map[string]string
+	if id := requestID(r.Context()); id != "" {
+		exemplar = map[string]string{"request_id": id}
+	}
+	parseDuration.Observe(time.Since(start).Seconds(), exemplar, result, typesLabel(types))
+}
+
+// Returns label of given number of types, which groups large numbers to keep
+// number of series low.
+func typesLabel(n int) string {
+	switch {
+	case n < 2:
+		return fmt.Sprint(n)
+	case n < 5:
+		return "2-4"
+	case n < 10:
+		return "5-9"
+	}
+	return "10+"
+}
+
+// Parses upper bounds of histogram buckets from given comma separated
+// durations, like "1ms,10ms,100ms", which must be increasing.
+func parseBuckets(value string) ([]float64, error) {
+	var buckets []float64
+	for _, item := range strings.Split(value, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		if d <= 0 || len(buckets) > 0 && d.Seconds() <= buckets[len(buckets)-1] {
+			return nil, fmt.Errorf("bucket '%s' is not positive and increasing", item)
+		}
+		buckets = append(buckets, d.Seconds())
+	}
+	return buckets, nil
+}
+
+// Helper function which returns function providing given statistics value of
+// application loggers.
+func logStats(
+	value func(filelog.Stats) uint64,
+) func() map[string]float64 {
+	return func() map[string]float64 {
+		values := make(map[string]float64, 2)
+		if appLog != nil {
+			values["application"] = float64(value(appLog.Stats()))
+		}
+		if accessLog != nil {
+			values["access"] = float64(value(accessLog.Stats()))
+		}
+		return values
+	}
+}
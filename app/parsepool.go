@@ -0,0 +1,74 @@
+package app
+
+import (
+	"math"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Number of requests which may parse submitted code at the same time,
+// which can be changed with GOMAXPARSE env var.
+var maxParse = runtime.NumCPU()
+
+// Request waits this long for free parse slot before it is rejected.
+const parseQueueWait = 100 * time.Millisecond
+
+// Pool of slots which bounds number of concurrent parse operations,
+// so that burst of requests does not spike memory and CPU usage.
+type parsePool struct {
+	slots chan struct{}
+	wait  time.Duration
+}
+
+func newParsePool(size int, wait time.Duration) *parsePool {
+	return &parsePool{slots: make(chan struct{}, size), wait: wait}
+}
+
+// Takes free slot of pool, waiting for it up to wait duration or until
+// request is canceled. Returns whether slot is taken.
+func (p *parsePool) acquire(r *http.Request) bool {
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	default:
+	}
+	timer := time.NewTimer(p.wait)
+	defer timer.Stop()
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+	case <-r.Context().Done():
+	}
+	return false
+}
+
+// Returns slot taken with acquire back to pool.
+func (p *parsePool) release() {
+	<-p.slots
+}
+
+// Middleware which serves request only when parse slot of given pool is
+// free, and rejects request with 503 error and Retry-After header otherwise.
+func useParsePool(pool *parsePool, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if pool.acquire(r) {
+			defer pool.release()
+			handler.ServeHTTP(w, r)
+			return
+		}
+		_ = requestLog(r).Warn("Rejected request from %s, all %d parse slots are busy",
+			r.RemoteAddr, cap(pool.slots))
+		seconds := int(math.Ceil(pool.wait.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		msg := "server is busy, retry in " + strconv.Itoa(seconds) + "s"
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			writeAPIError(w, http.StatusServiceUnavailable, codeServerBusy, msg)
+			return
+		}
+		http.Error(w, msg, http.StatusServiceUnavailable)
+	})
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUseParsePool(t *testing.T) {
+	appLog = &nopLogger{}
+	started, unblock := make(chan struct{}), make(chan struct{})
+	pool := newParsePool(2, 10*time.Millisecond)
+	handler := useParsePool(pool, http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("block") == "1" {
+				started <- struct{}{}
+				<-unblock
+			}
+		},
+	))
+
+	request := func(query string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, apiSizeofPath+query, strings.NewReader("{}"))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	// Saturate pool with requests which block in handler
+	done := make(chan int)
+	for i := 0; i < 2; i++ {
+		go func() { done <- request("?block=1").Code }()
+		<-started
+	}
+
+	w := request("")
+	var apiErr apiError
+	if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil || apiErr.Error == nil ||
+		w.Code != http.StatusServiceUnavailable || apiErr.Error.Code != codeServerBusy ||
+		w.Header().Get("Retry-After") != "1" {
+		t.Errorf("request to saturated pool is not rejected: %d %v %s",
+			w.Code, w.Header(), w.Body.String())
+	}
+
+	close(unblock)
+	for i := 0; i < 2; i++ {
+		if code := <-done; code != http.StatusOK {
+			t.Errorf("blocking request #%d failed: %d", i, code)
+		}
+	}
+	if w = request(""); w.Code != http.StatusOK {
+		t.Errorf("request to released pool is rejected: %d", w.Code)
+	}
+}
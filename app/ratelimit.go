@@ -0,0 +1,123 @@
+package app
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits of requests to compute endpoints per client IP, which can be
+// changed with GORATELIMIT and GORATEBURST env vars. Zero rate disables
+// limiting.
+var (
+	rateLimit float64 = 5 // requests per second
+	rateBurst         = 20
+)
+
+// Makes client IP to be taken from X-Forwarded-For header, set with
+// GOTRUSTPROXY env var when server is deployed behind reverse proxy.
+var trustProxy bool
+
+// Buckets which are not used during this period are removed.
+const rateBucketTTL = 10 * time.Minute
+
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Token bucket rate limiter of requests by client key. Each key has bucket
+// of burst tokens refilled with given rate, and each request takes a token.
+type rateLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*rateBucket
+	swept   time.Time
+	now     func() time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*rateBucket),
+		now:     time.Now,
+	}
+}
+
+// Takes token from bucket of given key. Returns whether request is allowed,
+// and if it is not, how long to wait for the next token.
+func (l *rateLimiter) allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if now.Sub(l.swept) > rateBucketTTL {
+		for k, b := range l.buckets {
+			if now.Sub(b.last) > rateBucketTTL {
+				delete(l.buckets, k)
+			}
+		}
+		l.swept = now
+	}
+
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &rateBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := (1 - b.tokens) / l.rate
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// Returns IP of client which has sent given request. Behind trusted proxy
+// it is the last address of X-Forwarded-For header, which is appended by
+// the proxy itself, as preceding addresses can be forged by client.
+func clientIP(r *http.Request) string {
+	if trustProxy {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			addrs := strings.Split(fwd, ",")
+			if ip := strings.TrimSpace(addrs[len(addrs)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Middleware which rejects requests exceeding rate limit of their client IP
+// with 429 error and Retry-After header.
+func useRateLimit(limiter *rateLimiter, handler http.Handler) http.Handler {
+	if limiter.rate <= 0 {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ok, wait := limiter.allow(clientIP(r))
+		if ok {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		seconds := int(math.Ceil(wait.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		msg := "too many requests, retry in " + strconv.Itoa(seconds) + "s"
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			writeAPIError(w, http.StatusTooManyRequests, codeRateLimited, msg)
+			return
+		}
+		http.Error(w, msg, http.StatusTooManyRequests)
+	})
+}
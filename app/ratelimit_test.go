@@ -0,0 +1,87 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := newRateLimiter(2, 3)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := limiter.allow("a"); !ok {
+			t.Fatalf("request #%d within burst is not allowed", i)
+		}
+	}
+	ok, wait := limiter.allow("a")
+	if ok || wait != 500*time.Millisecond {
+		t.Errorf("request exceeding burst is allowed: %t, wait %s", ok, wait)
+	}
+	if ok, _ := limiter.allow("b"); !ok {
+		t.Errorf("request of other client is not allowed")
+	}
+
+	now = now.Add(time.Second)
+	for i := 0; i < 2; i++ {
+		if ok, _ := limiter.allow("a"); !ok {
+			t.Errorf("request #%d after refill is not allowed", i)
+		}
+	}
+	if ok, _ := limiter.allow("a"); ok {
+		t.Errorf("request exceeding refilled tokens is allowed")
+	}
+
+	now = now.Add(2 * rateBucketTTL)
+	limiter.allow("c")
+	if len(limiter.buckets) != 1 {
+		t.Errorf("unused buckets are not removed: %d", len(limiter.buckets))
+	}
+}
+
+func TestUseRateLimit(t *testing.T) {
+	appLog = &nopLogger{}
+	defer func() { trustProxy = false }()
+	handler := useRateLimit(newRateLimiter(1, 5), http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {},
+	))
+
+	request := func(addr, forwarded string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, apiSizeofPath, strings.NewReader("{}"))
+		r.RemoteAddr = addr
+		if forwarded != "" {
+			r.Header.Set("X-Forwarded-For", forwarded)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	limited := 0
+	for i := 0; i < 10; i++ {
+		w := request("10.0.0.1:1234", "")
+		if w.Code == http.StatusTooManyRequests {
+			limited++
+			if w.Header().Get("Retry-After") != "1" ||
+				!strings.Contains(w.Body.String(), "too many requests") {
+				t.Errorf("invalid 429 response: %v %s", w.Header(), w.Body.String())
+			}
+		}
+	}
+	if limited != 5 {
+		t.Errorf("invalid number of limited requests, expected: 5, actual: %d", limited)
+	}
+
+	// Forwarded address is ignored unless proxy is trusted
+	if w := request("10.0.0.1:1234", "10.0.0.2"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("request with forged address is not limited: %d", w.Code)
+	}
+	trustProxy = true
+	if w := request("10.0.0.1:1234", "10.0.0.1, 10.0.0.2"); w.Code != http.StatusOK {
+		t.Errorf("forwarded request of other client is limited: %d", w.Code)
+	}
+}
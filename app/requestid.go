@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/log"
+)
+
+// Header which carries ID of request, both inbound and in response.
+const requestIDHeader = "X-Request-ID"
+
+// Inbound request IDs longer than this are replaced with generated ones.
+const maxRequestIDLength = 64
+
+type requestIDKey struct{}
+
+// Returns new request ID: 48-bit millisecond timestamp followed by 48 random
+// bits, hex encoded, so that IDs are sortable by time like ULIDs.
+func newRequestID() string {
+	var id [12]byte
+	binary.BigEndian.PutUint64(id[:8], uint64(time.Now().UnixNano()/int64(time.Millisecond))<<16)
+	_, _ = rand.Read(id[6:])
+	return hex.EncodeToString(id[:])
+}
+
+// Returns whether inbound request ID can be used as is: it is not too long
+// and consists of printable ASCII characters only, so it cannot break
+// log records.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns ID of request which given context belongs to, or empty string.
+func requestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Returns application logger which prefixes messages with ID of given request.
+func requestLog(r *http.Request) log.Logger {
+	id := requestID(r.Context())
+	if id == "" {
+		return appLog
+	}
+	return appLog.With(map[string]interface{}{"request_id": id})
+}
+
+// Middleware which assigns ID to each request, taken from X-Request-ID
+// header or generated, stores it in request context and echoes it in
+// response header.
+func useRequestID(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, id)
+		ctx := context.WithValue(r.Context(), requestIDKey{}, id)
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUseRequestID(t *testing.T) {
+	var seen string
+	handler := useRequestID(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			seen = requestID(r.Context())
+		},
+	))
+
+	request := func(inbound string) string {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if inbound != "" {
+			r.Header.Set(requestIDHeader, inbound)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if echoed := w.Header().Get(requestIDHeader); echoed != seen {
+			t.Errorf("echoed request ID '%s' differs from context one '%s'", echoed, seen)
+		}
+		return seen
+	}
+
+	first, second := request(""), request("")
+	if len(first) != 24 || first == second {
+		t.Errorf("invalid generated request IDs: '%s', '%s'", first, second)
+	}
+	if id := request("upstream-42"); id != "upstream-42" {
+		t.Errorf("inbound request ID is not used: '%s'", id)
+	}
+	for _, inbound := range []string{"bad id\n", strings.Repeat("x", maxRequestIDLength+1)} {
+		if id := request(inbound); id == inbound || len(id) != 24 {
+			t.Errorf("invalid inbound request ID is used: '%s'", id)
+		}
+	}
+}
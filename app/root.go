@@ -0,0 +1,35 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Name of environment variable which sets application root, against which
+// relative paths of log files and (in development mode) assets are resolved.
+const appRootEnv = "GOAPPROOT"
+
+// Application root directory, empty value means working directory.
+var appRoot string
+
+// Returns absolute path of application root: directory given by appRootEnv
+// environment variable, or directory of executable if it is not set. In
+// development mode working directory is used by default instead, as binary
+// built by "go run" is placed into temporary directory.
+func resolveAppRoot() (string, error) {
+	root := os.Getenv(appRootEnv)
+	if root == "" && devMode {
+		root = "."
+	}
+	if root == "" {
+		exe, err := os.Executable()
+		if err != nil {
+			return "", err
+		}
+		if exe, err = filepath.EvalSymlinks(exe); err != nil {
+			return "", err
+		}
+		root = filepath.Dir(exe)
+	}
+	return filepath.Abs(root)
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveAppRoot(t *testing.T) {
+	defer os.Unsetenv(appRootEnv)
+	defer func() {
+		devMode = false
+	}()
+	wd, _ := os.Getwd()
+	exe, _ := os.Executable()
+	exe, _ = filepath.EvalSymlinks(exe)
+
+	for _, c := range []struct {
+		env      string
+		dev      bool
+		expected string
+	}{
+		{"/srv/sizeof", false, "/srv/sizeof"},
+		{"/srv/sizeof", true, "/srv/sizeof"},
+		{"assets", false, filepath.Join(wd, "assets")},
+		{"", false, filepath.Dir(exe)},
+		{"", true, wd},
+	} {
+		os.Setenv(appRootEnv, c.env)
+		devMode = c.dev
+		root, err := resolveAppRoot()
+		if err != nil || root != filepath.FromSlash(c.expected) {
+			t.Errorf("GOAPPROOT=%s (dev %t): expected root '%s', got '%s' (%v)",
+				c.env, c.dev, c.expected, root, err)
+		}
+	}
+}
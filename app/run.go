@@ -1,63 +1,312 @@
 package app
 
 import (
+	"context"
+	"crypto/tls"
+	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
-	"runtime"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/chappjc/go-sizeof-webapp/internal/log"
 )
 
+// Run starts application and blocks until it is stopped by SIGINT or SIGTERM
+// signal, returning exit code of process.
 func Run() (exitCode int) {
+	return RunContext(context.Background())
+}
+
+// RunContext is like Run, but application is also gracefully shut down when
+// given context is canceled, so that embedding process or tests can stop it
+// without sending signals.
+func RunContext(ctx context.Context) (exitCode int) {
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+
+	devMode = os.Getenv("GODEV") == "1"
 	var err error
-	appLog, err = log.NewApplicationLogger()
+	if appRoot, err = resolveAppRoot(); err != nil {
+		log.StdErr("could not resolve application root, reason -> %s", err.Error())
+		return 1
+	}
+	appLog, err = log.NewApplicationLoggerWithErrorLog(appRoot)
+	if err != nil {
+		log.StdErr("could not create application log, reason -> %s", err.Error())
+		return 1
+	}
+	defer appLog.Close()
+
+	accessLog, err = log.NewAccessLogger(appRoot)
 	if err != nil {
 		log.StdErr("could not create access log, reason -> %s", err.Error())
 		return 1
 	}
+	defer accessLog.Close()
 
 	if err = prepareTemplates(); err != nil {
 		log.StdErr("could not parse html templates, reason -> %s", err.Error())
 		return 1
 	}
+	logLifecycle("templates_parsed", "HTML templates parsed", map[string]interface{}{
+		"count": len(templates),
+	})
 
-	// Override any http flags with GOHTTP env var
-	httpPortEnv := os.Getenv("GOHTTP")
-	if httpPortEnv != "" {
-		httpPort = httpPortEnv
+	var httpAddrSource string
+	httpPort, httpAddrSource, err = resolveHttpAddr(httpPort)
+	if err != nil {
+		log.StdErr("invalid HTTP address from %s, reason -> %s", httpAddrSource, err.Error())
+		return 1
 	}
 
-	bindHttpHandlers()
+	debugToken = os.Getenv("GODEBUGTOKEN")
+	pprofEnabled = os.Getenv("GOPPROF") == "1"
+
+	if value := os.Getenv("GOMAXINPUT"); value != "" {
+		if maxInputSize, err = strconv.ParseInt(value, 10, 64); err != nil || maxInputSize < 1 {
+			log.StdErr("invalid GOMAXINPUT '%s', positive number of bytes expected", value)
+			return 1
+		}
+	}
+	if parseTimeout, err = durationEnv("GOPARSETIMEOUT", parseTimeout); err != nil {
+		log.StdErr("invalid GOPARSETIMEOUT, reason -> %s", err.Error())
+		return 1
+	}
+	if value := os.Getenv("GOPARSEBUCKETS"); value != "" {
+		buckets, err := parseBuckets(value)
+		if err != nil {
+			log.StdErr("invalid GOPARSEBUCKETS, reason -> %s", err.Error())
+			return 1
+		}
+		parseDuration.SetBuckets(buckets)
+	}
 
-	canExit, httpErr := make(chan sig, 1), make(chan error, 1)
+	if value := os.Getenv("GOMAXPARSE"); value != "" {
+		if maxParse, err = strconv.Atoi(value); err != nil || maxParse < 1 {
+			log.StdErr("invalid GOMAXPARSE '%s', positive number of requests expected", value)
+			return 1
+		}
+	}
+	if value := os.Getenv("GORATELIMIT"); value != "" {
+		if rateLimit, err = strconv.ParseFloat(value, 64); err != nil || rateLimit < 0 {
+			log.StdErr("invalid GORATELIMIT '%s', requests per second expected", value)
+			return 1
+		}
+	}
+	if value := os.Getenv("GORATEBURST"); value != "" {
+		if rateBurst, err = strconv.Atoi(value); err != nil || rateBurst < 1 {
+			log.StdErr("invalid GORATEBURST '%s', positive number of requests expected", value)
+			return 1
+		}
+	}
+	trustProxy = os.Getenv("GOTRUSTPROXY") == "1"
+	corsOrigins := parseCORSOrigins(os.Getenv("GOCORSORIGINS"))
+
+	tlsCert, tlsKey := os.Getenv("GOTLSCERT"), os.Getenv("GOTLSKEY")
+	if (tlsCert == "") != (tlsKey == "") {
+		log.StdErr("both GOTLSCERT and GOTLSKEY must be set to enable TLS")
+		return 1
+	}
+
+	tlsState := "off"
+	if tlsCert != "" {
+		tlsState = "on"
+	}
+	logLifecycle("config_resolved", "Configuration resolved", map[string]interface{}{
+		"addr":        httpPort,
+		"addr_source": httpAddrSource,
+		"root":        appRoot,
+		"log_level":   log.LevelName(),
+		"tls":         tlsState,
+	})
+
+	handler := bindHttpHandlers(corsOrigins)
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	server := &http.Server{Addr: httpPort, Handler: handler}
+	for _, t := range []struct {
+		env   string
+		value *time.Duration
+		def   time.Duration
+	}{
+		{"GOHTTPREADHEADERTIMEOUT", &server.ReadHeaderTimeout, 5 * time.Second},
+		{"GOHTTPREADTIMEOUT", &server.ReadTimeout, 30 * time.Second},
+		{"GOHTTPWRITETIMEOUT", &server.WriteTimeout, 30 * time.Second},
+		{"GOHTTPIDLETIMEOUT", &server.IdleTimeout, 120 * time.Second},
+	} {
+		if *t.value, err = durationEnv(t.env, t.def); err != nil {
+			log.StdErr("invalid %s, reason -> %s", t.env, err.Error())
+			return 1
+		}
+	}
+	// Keep-alives can be disabled, so that each request opens new
+	// connection, e.g. to benchmark handlers without connection reuse.
+	keepAlives := os.Getenv("GOHTTPKEEPALIVE") != "0"
+	server.SetKeepAlivesEnabled(keepAlives)
+	// Period of TCP keep-alive probes of accepted connections, zero means
+	// default of net package and negative value disables probes.
+	tcpKeepAlive, err := durationEnv("GOTCPKEEPALIVE", 0)
+	if err != nil {
+		log.StdErr("invalid GOTCPKEEPALIVE, reason -> %s", err.Error())
+		return 1
+	}
+	if tlsCert != "" {
+		certs, err := newCertReloader(tlsCert, tlsKey)
+		if err != nil {
+			log.StdErr("could not load TLS certificate, reason -> %s", err.Error())
+			return 1
+		}
+		server.TLSConfig = &tls.Config{GetCertificate: certs.GetCertificate}
+	}
+	lc := &net.ListenConfig{KeepAlive: tcpKeepAlive}
+	ln, err := lc.Listen(ctx, "tcp", httpPort)
+	if err != nil {
+		err = fmt.Errorf(
+			"creating HTTP server on port '%s' FAILED, reason -> %s",
+			httpPort, err.Error(),
+		)
+		_ = appLog.Error(err.Error())
+		log.StdErr(err.Error())
+		return 1
+	}
+
+	httpErr := make(chan error, 1)
 	go func() {
-		defer close(canExit)
-		if err = http.ListenAndServe(httpPort, nil); err != nil {
+		var err error
+		if server.TLSConfig != nil {
+			err = server.ServeTLS(ln, "", "")
+		} else {
+			err = server.Serve(ln)
+		}
+		if err != http.ErrServerClosed {
 			httpErr <- fmt.Errorf(
-				"creating HTTP server on port '%s' FAILED, reason -> %s",
+				"serving HTTP on port '%s' FAILED, reason -> %s",
 				httpPort, err.Error(),
 			)
 		}
 	}()
 
-	// let ListenAndServe try to bind
-	runtime.Gosched()
+	logLifecycle("server_started", fmt.Sprintf("Listening on %v", ln.Addr()), map[string]interface{}{
+		"addr": ln.Addr(),
+		"tls":  tlsState,
+	})
+	appLog.Info(
+		"HTTP keep-alives enabled: %t, TCP keep-alive period: %s, timeouts: "+
+			"read header %s, read %s, write %s, idle %s",
+		keepAlives, keepAlivePeriod(tcpKeepAlive),
+		server.ReadHeaderTimeout, server.ReadTimeout,
+		server.WriteTimeout, server.IdleTimeout,
+	)
+
+	if !nodaemon {
+		notifyParentProcess()
+	}
+
 	select {
 	case err = <-httpErr:
 		_ = appLog.Error(err.Error())
-		log.StdErr(err.Error())
 		return 1
-	case <-time.After(30 * time.Millisecond):
+	case s := <-stop:
+		logLifecycle("signal_received", fmt.Sprintf("Received %s signal", s), map[string]interface{}{
+			"signal": s,
+		})
+	case <-ctx.Done():
+		logLifecycle("context_done", "Context of application is done", map[string]interface{}{
+			"reason": ctx.Err(),
+		})
+	}
+
+	logLifecycle("shutdown_started", "Shutting down HTTP server", map[string]interface{}{
+		"timeout": shutdownTimeout,
+	})
+	start := time.Now()
+	// Shutdown is not bound to canceled context of application
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err = server.Shutdown(shutdownCtx); err != nil {
+		_ = appLog.Error(
+			"shutting down HTTP server FAILED, reason -> %s", err.Error(),
+		)
+		return 1
 	}
+	logLifecycle("shutdown_complete", "HTTP server shut down", map[string]interface{}{
+		"drain": time.Since(start).Round(time.Millisecond),
+	})
+	return
+}
 
-	appLog.Info("Listening on %v", httpPort)
+// Helper function which logs lifecycle event of application with given name
+// and fields. Event name is logged as "event" field, so that startup and
+// shutdown markers can be found by grepping "event=". Call site of this
+// function is recorded as source of record.
+func logLifecycle(event, msg string, fields map[string]interface{}) {
+	fields["event"] = event
+	lgr := appLog.With(fields)
+	lgr.SetCallDepth(1)
+	lgr.Info(msg)
+}
 
-	if !nodaemon {
-		notifyParentProcess()
+// Returns normalized HTTP address to listen on and where it comes from:
+// GOHTTP environment variable overrides given "-http" flag value, and
+// DefaultHttpAddr is used if neither is set.
+func resolveHttpAddr(flagAddr string) (addr, source string, err error) {
+	switch addr = os.Getenv("GOHTTP"); {
+	case addr != "":
+		source = "env"
+	case flagAddr != "":
+		addr, source = flagAddr, "flag"
+	default:
+		addr, source = DefaultHttpAddr, "default"
 	}
+	addr, err = normalizeAddr(addr)
+	return addr, source, err
+}
 
-	<-canExit
-	return
+// Helper function which converts given HTTP address to "host:port" form.
+// Port without host is accepted both with and without leading colon.
+// Port must be in range 1-65535.
+func normalizeAddr(addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	if _, err := strconv.ParseUint(addr, 10, 16); err == nil {
+		addr = ":" + addr
+	}
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+	if num, err := strconv.ParseUint(port, 10, 16); err != nil || num == 0 {
+		return "", fmt.Errorf("invalid port '%s' in address '%s', 1-65535 expected", port, addr)
+	}
+	return addr, nil
+}
+
+// Helper function which returns duration from given environment variable,
+// or given default value if variable is not set.
+func durationEnv(name string, def time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// Returns description of TCP keep-alive period of net.ListenConfig.
+func keepAlivePeriod(period time.Duration) string {
+	switch {
+	case period < 0:
+		return "disabled"
+	case period == 0:
+		return "default"
+	}
+	return period.String()
 }
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/log"
+)
+
+func TestNormalizeAddr(t *testing.T) {
+	for addr, expected := range map[string]string{
+		"7777":           ":7777",
+		" :7777 ":        ":7777",
+		"127.0.0.1:8080": "127.0.0.1:8080",
+		"[::1]:65535":    "[::1]:65535",
+		"0":              "",
+		":0":             "",
+		":65536":         "",
+		":http":          "",
+		"localhost":      "",
+	} {
+		actual, err := normalizeAddr(addr)
+		if actual != expected || (err == nil) != (expected != "") {
+			t.Errorf("'%s': expected '%s', got '%s' (error %v)", addr, expected, actual, err)
+		}
+	}
+}
+
+func TestResolveHttpAddr(t *testing.T) {
+	defer func(addr string) {
+		DefaultHttpAddr = addr
+		os.Unsetenv("GOHTTP")
+	}(DefaultHttpAddr)
+	DefaultHttpAddr = "8000"
+
+	for _, c := range []struct {
+		env, flag, addr, source string
+	}{
+		{"", "", ":8000", "default"},
+		{"", "9000", ":9000", "flag"},
+		{"127.0.0.1:9100", "9000", "127.0.0.1:9100", "env"},
+	} {
+		os.Setenv("GOHTTP", c.env)
+		addr, source, err := resolveHttpAddr(c.flag)
+		if err != nil || addr != c.addr || source != c.source {
+			t.Errorf("env '%s', flag '%s': expected '%s' from %s, got '%s' from %s (error %v)",
+				c.env, c.flag, c.addr, c.source, addr, source, err)
+		}
+	}
+
+	DefaultHttpAddr = ":99999"
+	os.Unsetenv("GOHTTP")
+	if _, source, err := resolveHttpAddr(""); err == nil || source != "default" {
+		t.Errorf("expected error for invalid default address, got %v from %s", err, source)
+	}
+}
+
+func TestRunContext(t *testing.T) {
+	root, err := ioutil.TempDir("", "run_test_")
+	if err != nil {
+		t.Fatalf("failed to create temp directory, reason -> %s", err.Error())
+	}
+	defer os.RemoveAll(root)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find free port, reason -> %s", err.Error())
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	defer func(daemonized bool, port string) {
+		nodaemon, httpPort = daemonized, port
+		appLog = &nopLogger{}
+		os.Unsetenv(appRootEnv)
+		os.Unsetenv("GOHTTP")
+		os.Unsetenv("GOLOGSTDOUT")
+	}(nodaemon, httpPort)
+	// Parent process must not be notified as daemon
+	nodaemon = true
+	os.Setenv(appRootEnv, root)
+	os.Setenv("GOHTTP", addr)
+	os.Setenv("GOLOGSTDOUT", "0")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	exitCode := make(chan int, 1)
+	go func() { exitCode <- RunContext(ctx) }()
+
+	url := "http://" + addr + healthzPath
+	started := false
+	for i := 0; i < 100 && !started; i++ {
+		if resp, err := http.Get(url); err == nil {
+			resp.Body.Close()
+			started = resp.StatusCode == http.StatusOK
+		} else {
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+	if !started {
+		cancel()
+		t.Fatalf("application is not started on '%s'", addr)
+	}
+
+	cancel()
+	select {
+	case code := <-exitCode:
+		if code != 0 {
+			t.Errorf("expected exit code 0, got %d", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("application is not stopped by canceled context")
+	}
+	if _, err = http.Get(url); err == nil {
+		t.Error("HTTP server is still serving after shutdown")
+	}
+	data, err := ioutil.ReadFile(filepath.Join(root, log.ApplicationLogFile))
+	if err != nil {
+		t.Fatalf("failed to read application log, reason -> %s", err.Error())
+	}
+	for _, event := range []string{"event=context_done", "event=shutdown_complete"} {
+		if !strings.Contains(string(data), event) {
+			t.Errorf("application log has no '%s' record:\n%s", event, data)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	sharePath        = "/share"
+	sharedPathPrefix = "/s/"
+
+	// Maximum size of shared source code. It keeps permalinks short enough
+	// to be used in URLs.
+	maxShareSize = 8 << 10
+)
+
+var errShareTooLarge = errors.New("source code is too large to be shared")
+
+// Handler which accepts source code (as "source" form value) and responds
+// with permalink, which contains gzipped and base64 encoded code.
+func shareHandler(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 2*maxShareSize)
+	id, err := encodeShareID(r.FormValue("source"))
+	if err != nil {
+		code := codeInvalidRequest
+		if err == errShareTooLarge {
+			code = codeInputTooLarge
+		}
+		writeAPIError(w, http.StatusBadRequest, code, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, &struct {
+		URL string `json:"url"`
+	}{sharedPathPrefix + id})
+}
+
+// Handler which renders layout of source code shared by permalink.
+func sharedHandler(w http.ResponseWriter, r *http.Request) {
+	code, err := decodeShareID(strings.TrimPrefix(r.URL.Path, sharedPathPrefix))
+	if err != nil {
+		write404(w)
+		return
+	}
+	renderDiscover(w, r, code)
+}
+
+// Returns permalink ID of given source code.
+func encodeShareID(code string) (string, error) {
+	if code == "" {
+		return "", errors.New("source code is empty")
+	}
+	if len(code) > maxShareSize {
+		return "", errShareTooLarge
+	}
+	var buf bytes.Buffer
+	zw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if _, err := io.WriteString(zw, code); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// Returns source code of given permalink ID.
+func decodeShareID(id string) (string, error) {
+	if base64.RawURLEncoding.DecodedLen(len(id)) > 2*maxShareSize {
+		return "", errShareTooLarge
+	}
+	data, err := base64.RawURLEncoding.DecodeString(id)
+	if err != nil {
+		return "", err
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+	code, err := ioutil.ReadAll(io.LimitReader(zr, maxShareSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(code) > maxShareSize {
+		return "", errShareTooLarge
+	}
+	return string(code), nil
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestShareID(t *testing.T) {
+	code := "type A struct {\n\ta bool\n\tb int64\n}\n"
+	id, err := encodeShareID(code)
+	if err != nil {
+		t.Fatalf("failed to encode share ID, reason -> %s", err.Error())
+	}
+	if decoded, err := decodeShareID(id); err != nil || decoded != code {
+		t.Errorf("invalid decoded source code: '%s', %v", decoded, err)
+	}
+
+	if _, err = encodeShareID(strings.Repeat("a", maxShareSize+1)); err != errShareTooLarge {
+		t.Errorf("expected error for too large source code, actual: %v", err)
+	}
+	if _, err = decodeShareID("not-gzip"); err == nil {
+		t.Errorf("expected error for invalid share ID")
+	}
+	id, _ = encodeShareID(strings.Repeat(" ", maxShareSize))
+	if _, err = decodeShareID(id); err != nil {
+		t.Errorf("failed to decode share ID of maximum size, reason -> %v", err)
+	}
+
+	// Decompressed code must be limited as well
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write(bytes.Repeat([]byte(" "), 100*maxShareSize))
+	_ = zw.Close()
+	bomb := base64.RawURLEncoding.EncodeToString(buf.Bytes())
+	if _, err = decodeShareID(bomb); err != errShareTooLarge {
+		t.Errorf("expected error for too large decompressed code, actual: %v", err)
+	}
+}
+
+func TestShareHandler(t *testing.T) {
+	appLog = &nopLogger{}
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+
+	form := url.Values{"source": {"struct{a bool; b int64}"}}
+	r := httptest.NewRequest(http.MethodPost, sharePath, strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	shareHandler(w, r)
+	var resp struct{ URL string }
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("invalid response: %d %s", w.Code, w.Body.String())
+	}
+	if !strings.HasPrefix(resp.URL, sharedPathPrefix) {
+		t.Fatalf("invalid permalink: %s", resp.URL)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, resp.URL, nil)
+	w = httptest.NewRecorder()
+	sharedHandler(w, r)
+	if !strings.Contains(w.Body.String(), "Type size: 16") {
+		t.Errorf("shared layout is not rendered:\n%s", w.Body.String())
+	}
+
+	r = httptest.NewRequest(http.MethodGet, sharePath, nil)
+	w = httptest.NewRecorder()
+	useAPIMethods(http.HandlerFunc(shareHandler), http.MethodPost).ServeHTTP(w, r)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("invalid status code, expected: 405, actual: %d", w.Code)
+	}
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/parser"
+)
+
+const apiStdTypePath = "/api/stdtype"
+
+// Layout of well-known standard library type.
+type apiStdType struct {
+	Arch  string `json:"arch"`
+	Pkg   string `json:"pkg"`
+	Name  string `json:"name"`
+	Size  uint64 `json:"size"`
+	Align uint64 `json:"align"`
+}
+
+// Handler which responds with size and alignment of well-known standard
+// library type given by "pkg" and "name" query params, on architecture
+// given by "arch" query param, so that it can be looked up without
+// submitting source. Unknown type results in 404 error listing known ones.
+func apiStdTypeHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	pkg, name := query.Get("pkg"), query.Get("name")
+	if pkg == "" || name == "" {
+		writeAPIError(w, http.StatusBadRequest, codeInvalidRequest,
+			"both 'pkg' and 'name' query params are required")
+		return
+	}
+	arch, err := parser.LookupArch(query.Get("arch"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+	typ, err := parser.StdlibType(pkg, name, arch)
+	if err != nil {
+		status, apiErr := newParseAPIError(err)
+		writeJSON(w, status, apiErr)
+		return
+	}
+	if typ == nil {
+		apiErr := newAPIError(codeUnknownType,
+			"unknown standard library type '"+pkg+"."+name+"'")
+		apiErr.Error.Supported = parser.StdlibTypeNames()
+		writeJSON(w, http.StatusNotFound, apiErr)
+		return
+	}
+	writeJSON(w, http.StatusOK, &apiStdType{
+		Arch:  arch.Name,
+		Pkg:   pkg,
+		Name:  name,
+		Size:  typ.Sizeof,
+		Align: typ.Alignof,
+	})
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIStdTypeHandler(t *testing.T) {
+	appLog = &nopLogger{}
+	for query, expected := range map[string]apiStdType{
+		"?pkg=time&name=Time":               {Arch: "amd64", Pkg: "time", Name: "Time", Size: 24, Align: 8},
+		"?pkg=time&name=Time&arch=386":      {Arch: "386", Pkg: "time", Name: "Time", Size: 20, Align: 4},
+		"?pkg=sync&name=WaitGroup&arch=arm": {Arch: "arm", Pkg: "sync", Name: "WaitGroup", Size: 16, Align: 8},
+	} {
+		w := httptest.NewRecorder()
+		apiStdTypeHandler(w, httptest.NewRequest(http.MethodGet, apiStdTypePath+query, nil))
+		var actual apiStdType
+		if err := json.Unmarshal(w.Body.Bytes(), &actual); err != nil || w.Code != http.StatusOK {
+			t.Errorf("invalid response for '%s': %d %s", query, w.Code, w.Body.String())
+			continue
+		}
+		if actual != expected {
+			t.Errorf("invalid layout for '%s'\n\texpected: %+v\n\tactual: %+v", query, expected, actual)
+		}
+	}
+
+	for query, c := range map[string]struct {
+		status int
+		code   string
+	}{
+		"?pkg=time&name=Timer":           {http.StatusNotFound, codeUnknownType},
+		"?pkg=time":                      {http.StatusBadRequest, codeInvalidRequest},
+		"?pkg=time&name=Time&arch=pdp11": {http.StatusBadRequest, codeInvalidRequest},
+	} {
+		w := httptest.NewRecorder()
+		apiStdTypeHandler(w, httptest.NewRequest(http.MethodGet, apiStdTypePath+query, nil))
+		var apiErr apiError
+		if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil || apiErr.Error == nil ||
+			w.Code != c.status || apiErr.Error.Code != c.code {
+			t.Errorf("invalid error for '%s': %d %s", query, w.Code, w.Body.String())
+			continue
+		}
+		if (len(apiErr.Error.Supported) > 0) != (c.code == codeUnknownType) {
+			t.Errorf("supported types expected only for unknown type '%s': %s", query, w.Body.String())
+		}
+	}
+}
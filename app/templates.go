@@ -1,39 +1,153 @@
 package app
 
 import (
+	"bytes"
+	"fmt"
 	"html/template"
+	"io/fs"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
 
-	bin "github.com/chappjc/go-sizeof-webapp/internal/bindata/templates"
+const (
+	templatesDir = "templs/"
+	staticDir    = "pub"
 )
 
-const templatesDir = "templs/"
+// Assets contains HTML templates in templatesDir and static files
+// in staticDir. It is set by main package to files embedded into binary.
+var Assets fs.FS
 
 var templates map[string]*template.Template
 
-func prepareTemplates() error {
-	templates = make(map[string]*template.Template)
-	baseData, err := bin.Asset(templatesDir + "parts/base.tmpl")
-	if err != nil {
-		return err
+// Functions which can be used in templates. Packages embedding application
+// can register more of them with AddTemplateFuncs.
+var templateFuncs = template.FuncMap{
+	"unvischunk": func(x int, len int) bool {
+		return x > 2 && x < (len-1)
+	},
+	"fieldstatus": fieldStatus,
+	// Formats difference of sizes with explicit sign
+	"signed": func(x int64) string {
+		if x > 0 {
+			return fmt.Sprintf("+%d", x)
+		}
+		return fmt.Sprintf("%d", x)
+	},
+	"humanBytes": humanBytes,
+}
+
+// AddTemplateFuncs registers given functions to be used in templates, which
+// replace already registered functions of the same names. Functions are added
+// to templates when they are parsed, so it must be called before Run.
+func AddTemplateFuncs(fns template.FuncMap) {
+	for name, fn := range fns {
+		templateFuncs[name] = fn
+	}
+}
+
+// Returns given number of bytes in binary units, like "1.2 KiB". Number
+// less than KiB is returned in bytes, and fraction is omitted if it is zero.
+func humanBytes(n uint64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, unit := float64(n)/1024, 0
+	for value >= 1024 && unit < 5 {
+		value, unit = value/1024, unit+1
+	}
+	s := strconv.FormatFloat(value, 'f', 1, 64)
+	return strings.TrimSuffix(s, ".0") + " " + []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}[unit]
+}
+
+// Makes templates and static files to be re-read from application root
+// on each request, so they can be changed without restart.
+var devMode bool
+
+// Returns file system of assets, which is application root in development
+// mode.
+func assetsFS() fs.FS {
+	if devMode {
+		if appRoot == "" {
+			return os.DirFS(".")
+		}
+		return os.DirFS(appRoot)
 	}
-	var fns = template.FuncMap{
-		"unvischunk": func(x int, len int) bool {
-			return x > 2 && x < (len-1)
-		},
+	return Assets
+}
+
+// Parses HTML templates of assets, which are read from application root
+// in development mode.
+func prepareTemplates() (err error) {
+	templates, err = parseTemplates(assetsFS())
+	return
+}
+
+// Helper function which parses all templates from given file system.
+func parseTemplates(fsys fs.FS) (map[string]*template.Template, error) {
+	parsed := make(map[string]*template.Template)
+	baseData, err := fs.ReadFile(fsys, templatesDir+"parts/base.tmpl")
+	if err != nil {
+		return nil, err
 	}
 	for _, name := range []string{
-		"index", "404", "500",
+		"index", "compare", "404", "500",
 	} {
-		assetData, err := bin.Asset(templatesDir + name + ".tmpl")
+		assetData, err := fs.ReadFile(fsys, templatesDir+name+".tmpl")
 		if err != nil {
-			return err
+			return nil, err
 		}
-		templates[name], err = template.New(name).Funcs(fns).Parse(
+		parsed[name], err = template.New(name).Funcs(templateFuncs).Parse(
 			string(baseData) + string(assetData),
 		)
 		if err != nil {
-			return err
+			return nil, err
+		}
+	}
+	return parsed, nil
+}
+
+// Helper function which renders template with given name and data with
+// 200 status. In development mode templates are re-parsed from disk and
+// parsing errors are rendered as error page.
+func renderTemplate(w http.ResponseWriter, name string, data interface{}) {
+	renderTemplateStatus(w, http.StatusOK, name, data)
+}
+
+// Helper function which renders template with given name and data with given
+// status. Template is rendered into buffer first, so failed execution is
+// logged and answered with 500 error page instead of partially written page.
+func renderTemplateStatus(w http.ResponseWriter, status int, name string, data interface{}) {
+	tmpls := templates
+	if devMode {
+		var err error
+		if tmpls, err = parseTemplates(assetsFS()); err != nil {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(http.StatusInternalServerError)
+			templateErrorPage.Execute(w, err.Error())
+			return
 		}
 	}
-	return nil
+	var buf bytes.Buffer
+	if err := tmpls[name].ExecuteTemplate(&buf, "base", data); err != nil {
+		_ = appLog.Error("rendering template '%s' FAILED, reason -> %s", name, err.Error())
+		buf.Reset()
+		if name == "500" || tmpls["500"].ExecuteTemplate(&buf, "base", nil) != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError),
+				http.StatusInternalServerError)
+			return
+		}
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = buf.WriteTo(w)
 }
+
+// Page which is rendered when templates cannot be parsed in development mode.
+var templateErrorPage = template.Must(template.New("error").Parse(
+	`<!DOCTYPE html><html><head><title>Template error</title></head>` +
+		`<body><h1>Template error</h1><pre>{{ . }}</pre></body></html>`,
+))
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRenderTemplateError(t *testing.T) {
+	if err := prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	lgr := &nopLogger{}
+	appLog = lgr
+	defer func() {
+		appLog = nil
+	}()
+	prepared := templates["index"]
+	defer func() {
+		templates["index"] = prepared
+	}()
+	// Template fails after part of page is rendered
+	templates["index"] = template.Must(template.New("index").Parse(
+		`{{ define "base" }}<h1>partial</h1>{{ .Result.Sizeof }}{{ end }}`,
+	))
+
+	w := httptest.NewRecorder()
+	renderTemplate(w, "index", &struct{ Result *struct{ Sizeof uint64 } }{})
+	body := w.Body.String()
+	if w.Code != http.StatusInternalServerError || strings.Contains(body, "partial") ||
+		!strings.Contains(body, "Something went wrong") {
+		t.Errorf("failed template must be answered with 500 page: %d\n%s", w.Code, body)
+	}
+	if lgr.errors != 1 {
+		t.Errorf("template failure expected to be logged as error")
+	}
+
+	w = httptest.NewRecorder()
+	renderTemplateStatus(w, http.StatusNotFound, "404", nil)
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "No page here") {
+		t.Errorf("invalid rendering of template with status: %d\n%s", w.Code, w.Body.String())
+	}
+}
+
+func TestHumanBytes(t *testing.T) {
+	for n, expected := range map[uint64]string{
+		0:             "0 B",
+		1023:          "1023 B",
+		1024:          "1 KiB",
+		1229:          "1.2 KiB",
+		5 << 20:       "5 MiB",
+		3<<30 + 1<<29: "3.5 GiB",
+		1<<64 - 1:     "16 EiB",
+	} {
+		if actual := humanBytes(n); actual != expected {
+			t.Errorf("%d: expected '%s', got '%s'", n, expected, actual)
+		}
+	}
+}
+
+func TestAddTemplateFuncs(t *testing.T) {
+	defer delete(templateFuncs, "shout")
+	AddTemplateFuncs(template.FuncMap{"shout": strings.ToUpper})
+	fsys := fstest.MapFS{
+		templatesDir + "parts/base.tmpl": {Data: []byte(`{{ define "base" }}{{ shout . }}{{ end }}`)},
+	}
+	for _, name := range []string{"index", "compare", "404", "500"} {
+		fsys[templatesDir+name+".tmpl"] = &fstest.MapFile{}
+	}
+	parsed, err := parseTemplates(fsys)
+	if err != nil {
+		t.Fatalf("failed to parse templates, reason -> %s", err.Error())
+	}
+	var buf strings.Builder
+	if err = parsed["index"].ExecuteTemplate(&buf, "base", "gopher"); err != nil || buf.String() != "GOPHER" {
+		t.Errorf("registered function is not used: %q, %v", buf.String(), err)
+	}
+
+	// Results page shows large sizes in binary units
+	appLog = &nopLogger{}
+	if err = prepareTemplates(); err != nil {
+		t.Fatalf("failed to prepare templates, reason -> %s", err.Error())
+	}
+	w := httptest.NewRecorder()
+	renderDiscover(w, httptest.NewRequest(http.MethodGet, "/", nil), "[1200]int64")
+	if body := w.Body.String(); !strings.Contains(body, "Type size: 9600 (9.4 KiB)") {
+		t.Errorf("size in binary units is not shown:\n%s", body)
+	}
+}
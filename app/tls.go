@@ -0,0 +1,90 @@
+package app
+
+import (
+	"crypto/tls"
+	"os"
+	"sync"
+	"time"
+)
+
+// How often certificate files are checked for changes.
+const certCheckInterval = 10 * time.Second
+
+// Provides TLS certificate loaded from given files, reloading it when files
+// are changed, so certificate renewal doesn't require restart.
+type certReloader struct {
+	certFile, keyFile string
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// Creates new certificate reloader and loads certificate from given files.
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	modTime, err := r.filesModTime()
+	if err != nil {
+		return nil, err
+	}
+	if err = r.load(modTime); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate returns current certificate, reloading it if its files were
+// changed. Suitable for tls.Config.GetCertificate.
+func (r *certReloader) GetCertificate(
+	*tls.ClientHelloInfo,
+) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.checkedAt) < certCheckInterval {
+		return r.cert, nil
+	}
+	r.checkedAt = time.Now()
+	modTime, err := r.filesModTime()
+	if err != nil {
+		_ = appLog.Error(
+			"Checking TLS certificate FAILED, reason -> %s", err.Error(),
+		)
+		return r.cert, nil
+	}
+	if modTime.After(r.modTime) {
+		if err = r.load(modTime); err != nil {
+			_ = appLog.Error(
+				"Reloading TLS certificate FAILED, reason -> %s", err.Error(),
+			)
+		} else {
+			appLog.Info("TLS certificate reloaded")
+		}
+	}
+	return r.cert, nil
+}
+
+// Helper function which loads certificate from files.
+func (r *certReloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+	r.cert, r.modTime = &cert, modTime
+	return nil
+}
+
+// Helper function which returns latest modification time of certificate
+// files.
+func (r *certReloader) filesModTime() (latest time.Time, err error) {
+	for _, name := range []string{r.certFile, r.keyFile} {
+		fi, err := os.Stat(name)
+		if err != nil {
+			return latest, err
+		}
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+	return latest, nil
+}
@@ -1,9 +1,8 @@
 module github.com/chappjc/go-sizeof-webapp
 
-go 1.12
+go 1.16
 
 require (
 	github.com/alecthomas/log4go v0.0.0-20180109082532-d146e6b86faa
-	github.com/elazarl/go-bindata-assetfs v1.0.1-0.20180223160309-38087fe4dafb
 	github.com/tyranron/daemonigo v0.3.1
 )
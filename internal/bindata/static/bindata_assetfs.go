@@ -1,351 +0,0 @@
-package static
-
-import (
-	"bytes"
-	"compress/gzip"
-	"fmt"
-	"io"
-	"io/ioutil"
-	"os"
-	"path"
-	"path/filepath"
-	"reflect"
-	"strings"
-	"time"
-	"unsafe"
-
-	"github.com/elazarl/go-bindata-assetfs"
-)
-
-func bindata_read(data, name string) ([]byte, error) {
-	var empty [0]byte
-	sx := (*reflect.StringHeader)(unsafe.Pointer(&data))
-	b := empty[:]
-	bx := (*reflect.SliceHeader)(unsafe.Pointer(&b))
-	bx.Data = sx.Data
-	bx.Len = len(data)
-	bx.Cap = bx.Len
-
-	gz, err := gzip.NewReader(bytes.NewBuffer(b))
-	if err != nil {
-		return nil, fmt.Errorf("Read %q: %v", name, err)
-	}
-
-	var buf bytes.Buffer
-	_, err = io.Copy(&buf, gz)
-	gz.Close()
-
-	if err != nil {
-		return nil, fmt.Errorf("Read %q: %v", name, err)
-	}
-
-	return buf.Bytes(), nil
-}
-
-type asset struct {
-	bytes []byte
-	info  os.FileInfo
-}
-
-type bindata_file_info struct {
-	name    string
-	size    int64
-	mode    os.FileMode
-	modTime time.Time
-}
-
-func (fi bindata_file_info) Name() string {
-	return fi.name
-}
-func (fi bindata_file_info) Size() int64 {
-	return fi.size
-}
-func (fi bindata_file_info) Mode() os.FileMode {
-	return fi.mode
-}
-func (fi bindata_file_info) ModTime() time.Time {
-	return fi.modTime
-}
-func (fi bindata_file_info) IsDir() bool {
-	return false
-}
-func (fi bindata_file_info) Sys() interface{} {
-	return nil
-}
-
-var _pub_ds_store = "\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xec\x98\x3d\x6f\xda\x40\x18\x80\xef\x8c\x4b\xcf\xae\xd4\x5e\xa5\x0e\x74\xbb\xad\x0b\x83\x4b\x3f\x18\x6b\xb9\x74\xea\x12\xc9\x51\x18\x20\x22\xb6\x6c\x81\x25\xc7\x87\xb0\x89\x07\x82\xe4\x31\x9f\x73\xfe\x42\x94\xfc\xa1\x0c\xf9\x31\xc1\xf8\x4d\xe4\x10\x18\x32\x81\xc8\xfb\x48\xa7\xe7\x38\xde\x3b\x78\x75\xd6\x7d\x98\x10\x42\xad\xb1\xf7\x9d\x10\x3e\xab\x32\x52\x58\xd1\xc8\x52\x18\x94\x17\x28\x25\xd3\xf9\x18\x3e\x89\xdd\x34\x1e\xba\xa1\x74\x97\x8f\x85\x6c\x18\xf9\xdc\x55\x49\x40\x0e\x89\x43\xfa\x0b\xf3\x77\xef\x0e\xc3\x20\x4e\x0c\xe3\x8e\x2a\x15\xf5\x5d\xf5\x3d\xd3\x74\x5d\xd3\x3f\x7e\xd2\xf7\xed\x81\x4c\xed\xc4\x49\xc6\xb1\xe5\x8c\x3a\xf9\xa7\x1d\x27\x19\xb8\x50\xdf\x95\x32\x7c\xaa\x3b\xee\x5e\xe0\xa7\x3d\xfe\xe5\xaf\x8c\x12\x27\x88\xfc\xd1\xbc\x73\xe0\xf9\xb3\x90\x6e\x3b\x88\x3c\x99\x5a\x72\x1c\x79\x71\x17\x1a\xdb\x81\x97\x0c\x3a\xa5\x28\xa6\x69\x4c\xeb\xf1\xda\x64\xd2\x68\x34\xeb\xe2\x97\xd1\x98\xd6\xc5\xa4\xd9\x34\xea\xe2\xe7\x6f\x63\x3a\xe5\x57\x1a\xfb\xfa\xed\xc7\x9f\xff\xae\x3c\x3e\x39\x3d\x3b\xbf\xb8\xbc\xbe\x29\xd2\xa3\x14\xf2\xfc\xbc\x90\xf7\xed\x62\xde\x47\xf6\x28\x0a\x65\xd4\x27\xf3\x67\x19\x41\x10\x64\xab\x81\x75\x8e\x7d\x58\xef\xdf\x40\x10\x64\x03\xc9\xd7\x07\x01\x36\xc1\x59\x61\x0a\xdf\x2b\x60\xb5\xd4\x87\x83\x05\xd8\x04\x67\x85\x29\xc4\x29\x60\x15\xcc\xc0\x1c\x2c\xc0\x26\x38\x2b\x0c\x8b\x16\x85\xcb\x07\x85\x5f\xa6\x70\x43\xa1\x1c\x2c\xc0\xe6\x2b\x93\x46\x90\x37\x42\xa5\x10\xcf\xf7\xff\x7f\xab\xef\xff\x08\x82\x6c\x31\x54\x6d\xd9\x2d\x8b\xac\x7e\xf1\x91\xef\xb5\x62\x56\x0e\x1e\x3b\x90\xe7\x07\x01\x68\x53\x21\x36\xdf\x8a\x6b\xa5\x76\x01\x36\xc1\x59\x61\x3c\x08\x20\xc8\xba\x78\x08\x00\x00\xff\xff\x28\x3f\x32\xb7\x04\x18\x00\x00"
-
-func pub_ds_store_bytes() ([]byte, error) {
-	return bindata_read(
-		_pub_ds_store,
-		"pub/.DS_Store",
-	)
-}
-
-func pub_ds_store() (*asset, error) {
-	bytes, err := pub_ds_store_bytes()
-	if err != nil {
-		return nil, err
-	}
-
-	info := bindata_file_info{name: "pub/.DS_Store", size: 6148, mode: os.FileMode(420), modTime: time.Unix(1422215090, 0)}
-	a := &asset{bytes: bytes, info: info}
-	return a, nil
-}
-
-var _pub_images_ds_store = "\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xec\x98\x3b\x0e\xc2\x30\x10\x44\x77\x8c\x0b\x4b\x34\x2e\x29\xdd\x70\x00\x6e\x60\x45\xe1\x04\x5c\x80\x82\x2b\xd0\xfb\xe8\x24\xda\x11\xb2\x14\x52\x50\x25\x82\x79\x92\xf5\x56\x8a\x9d\x4f\xe3\xec\xd8\xcc\x30\x3c\x1f\x17\xb3\x3c\x95\xc9\xdc\x76\xb6\x8f\x24\x8e\x05\xa1\xab\xc1\x7b\x08\x21\x84\x10\x62\xdf\xc0\x95\x8e\xdb\xbe\x86\x10\x62\x87\xcc\xfb\x43\xa1\x2b\xdd\xdc\xe0\xf5\x40\xc7\x6e\x4d\xa6\x0b\x5d\xe9\xe6\x06\xe7\x05\x3a\xd2\x89\xce\x74\xa1\x2b\xdd\xdc\xdc\xb4\xc0\xf0\x01\x3e\x19\x4c\x28\x60\x0a\x41\xa1\xeb\x97\x1f\x2d\xc4\x9f\x70\x70\xe5\xf9\xff\x7f\xb5\xd5\xfc\x2f\x84\xf8\x61\x10\xc7\xdb\x38\xd8\x3b\x10\x2c\x27\x4c\xe3\xde\xd5\xcd\xd6\x9b\x80\xe0\x87\x85\xa7\x6e\x6d\xa1\x2b\xdd\xdc\x6a\x04\x84\xd8\x8a\x57\x00\x00\x00\xff\xff\x6a\x00\x88\x6d\x04\x18\x00\x00"
-
-func pub_images_ds_store_bytes() ([]byte, error) {
-	return bindata_read(
-		_pub_images_ds_store,
-		"pub/images/.DS_Store",
-	)
-}
-
-func pub_images_ds_store() (*asset, error) {
-	bytes, err := pub_images_ds_store_bytes()
-	if err != nil {
-		return nil, err
-	}
-
-	info := bindata_file_info{name: "pub/images/.DS_Store", size: 6148, mode: os.FileMode(420), modTime: time.Unix(1422215273, 0)}
-	a := &asset{bytes: bytes, info: info}
-	return a, nil
-}
-
-var _pub_images_favicon_ico = "\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x00\x3e\x03\xc1\xfc\x89\x50\x4e\x47\x0d\x0a\x1a\x0a\x00\x00\x00\x0d\x49\x48\x44\x52\x00\x00\x00\x12\x00\x00\x00\x12\x08\x03\x00\x00\x00\x61\x10\x7e\x65\x00\x00\x01\xaa\x50\x4c\x54\x45\x00\x00\x00\x6b\xd9\xe7\x6a\xd7\xe5\x24\x49\x4e\x5a\xb7\xc3\x6a\xd8\xe6\x52\xa7\xb1\x43\x86\x8e\x6a\xd7\xe5\x01\x03\x03\x6c\xdb\xe9\x60\xc0\xcb\x34\x6d\x74\x6a\xd7\xe5\x6a\xd7\xe5\x05\x12\x13\x00\x00\x00\x6a\xd7\xe5\x53\xd9\xf2\x51\xad\xb5\x57\xb1\xbc\x67\xdf\xf1\x6a\xd7\xe5\x5c\xb7\xc2\x6a\xd7\xe5\x6a\xd7\xe5\x50\xb9\xc5\x4d\x9c\xa6\x4b\x98\xa1\x52\xa8\xb1\x4a\x97\xa0\xdf\xad\x7a\x32\x94\x9e\x46\x8d\x96\x47\x90\x96\x43\x88\x90\x35\x6b\x72\x31\x83\x8b\x6a\xd7\xe5\x36\x69\x6e\x23\x5d\x63\x6a\xd7\xe5\x1b\x65\x6c\x2f\x60\x66\x9e\x77\x52\x6a\xd7\xe5\x55\xc1\xd2\x6e\xe3\xf3\x6a\xd7\xe5\x6a\xd7\xe5\x6b\xef\xfa\x2e\x5d\x61\x2a\x4f\x53\x0a\x2f\x33\x12\x49\x4d\x4f\x7d\x7d\x6d\x67\x54\x1b\x37\x3a\x6a\xd7\xe5\x6a\xd7\xe5\x00\x00\x00\x6a\xd7\xe5\xff\xff\xff\x6a\xd5\xe3\x76\xf0\xff\x80\xff\xff\x7d\xfb\xff\x78\xf5\xff\x5e\xe0\xf0\x6a\xda\xea\x7a\xf8\xff\xb8\xfa\xfe\xff\xfe\xfc\xff\xf7\xf5\x62\xe4\xf5\x65\xdf\xef\x6a\xd9\xe7\x5d\xd6\xe6\x70\xd7\xe2\x46\xce\xe2\x55\xcc\xdc\x65\xc8\xd6\x5b\xc3\xd1\x61\xc3\xcf\x69\xc2\xcc\x72\xbe\xc7\x4a\xae\xb9\xff\xdb\xaf\x8c\xaf\x9c\x5a\x59\x59\x61\x3a\x19\x76\xff\xff\x6e\xf8\xff\x6c\xee\xfd\x74\xed\xfc\xcd\xf6\xfb\x8d\xf0\xfb\x65\xeb\xfb\xe4\xf8\xf9\xa5\xf0\xf9\x65\xe5\xf7\x91\xec\xf4\x73\xe8\xf3\xf1\xf3\xf2\x6e\xde\xec\x53\xda\xe9\x92\xde\xe8\xd5\xe4\xe6\x54\xd2\xe1\xb2\xdb\xe0\xe8\xde\xdc\x66\xce\xdb\xdc\xdb\xd9\xbd\xd1\xd4\x87\xc7\xd3\x7d\xc9\xd2\x9b\xc7\xd1\x6f\xc4\xd0\x63\xc1\xcb\x56\xbd\xcb\xa1\xc6\xca\x6b\xbe\xc8\x61\xc2\xc7\x82\xbe\xc4\xbf\xb2\xb7\xcc\xb0\xb3\x3c\xa3\xb2\xbb\xb1\xb0\x99\xbc\xae\x49\x9f\xa9\xbe\xb5\x96\xd6\xbc\x92\x97\x90\x8f\xcd\xb3\x8b\x3f\x82\x8a\x8f\x87\x87\x23\x79\x84\x7d\x79\x79\x4e\x4d\x4d\x00\x3b\x4d\x00\x29\x37\x26\x26\x26\x3a\x72\xe8\x01\x00\x00\x00\x3d\x74\x52\x4e\x53\x00\x05\xf8\x02\xf7\xf5\xe6\xc4\x71\x18\x0c\xee\xa0\x85\x7a\x3e\x13\xfc\xf9\xf6\xf3\xef\xee\xec\xeb\xe6\xe4\xd8\xd6\xd5\xd1\xd1\xce\xbc\xba\xb3\xb1\xb0\xad\xac\xaa\x9f\x9b\x94\x94\x93\x86\x82\x81\x75\x71\x70\x70\x6e\x6d\x69\x69\x61\x21\x13\x0e\xbe\x56\x2d\xd3\x00\x00\x01\x06\x49\x44\x41\x54\x18\xd3\x6d\xc8\xe5\x4e\xc3\x00\x14\x40\xe1\xdb\x96\xae\x32\xc3\xdd\xdd\xdd\xa1\xb7\xac\xc2\xdc\x7d\xb8\xbb\xbb\xbb\xc3\x3b\x53\x9a\x90\x40\xb2\xf3\xeb\xe4\x83\x74\x11\x3c\x3f\x3c\xc2\x13\xda\x10\x40\xe8\x32\xd8\xde\xd1\x56\xdf\x64\xb6\x74\xb1\x6c\xa7\xd9\xc2\x76\x0f\xb4\xc2\x43\x28\x8f\xce\x22\x8b\x48\x32\xbf\x90\x14\x68\x3a\xfb\xb6\x02\xac\xc7\xd3\xc2\x9f\x6c\x0d\x14\x40\xc6\xd8\x3f\x32\x01\x64\xea\x24\x0a\x8a\xe2\x11\xb5\x91\x98\x5f\x52\xd5\x68\x54\x50\xb5\x19\x2f\x05\x18\xcd\xfd\x21\x77\xf8\xf2\xea\xc8\x25\x6a\x64\xa0\x80\x32\xc8\xa2\x67\x31\xf0\x14\x8f\xdf\xfb\x15\xb7\x20\x6b\x04\xe5\xd2\xea\x66\xe8\x24\x91\x4a\x25\x82\x07\x61\xaf\x54\x02\x00\x2d\xb6\xc0\xc5\x0a\x1e\x7e\x7e\xec\x23\xee\x46\x26\x6a\x01\x38\x66\xf2\xcc\x79\xf3\x88\x0b\x5e\x7c\x49\xe2\xde\x9a\x71\x08\xaa\xc4\xeb\x75\x4c\x7e\xe1\x56\x0c\xdf\xde\x71\xe6\xf5\x79\x07\x6a\x96\xef\xa6\x36\x9c\x8e\xed\x88\xff\xd4\x81\xf3\xbe\xd8\xb9\x09\xb8\x6a\xa6\x31\x68\xb7\xfb\x5c\xf2\xdc\xec\x52\x4e\x5f\x6f\x0f\xe8\x59\x9b\x2b\x8b\x8d\x05\x65\x75\xfd\x1c\xa4\xef\x1b\x10\x66\x3f\xb4\xf3\x4b\xa9\xf2\x00\x00\x00\x00\x49\x45\x4e\x44\xae\x42\x60\x82\x01\x00\x00\xff\xff\xd6\x33\xaa\xe5\x3e\x03\x00\x00"
-
-func pub_images_favicon_ico_bytes() ([]byte, error) {
-	return bindata_read(
-		_pub_images_favicon_ico,
-		"pub/images/favicon.ico",
-	)
-}
-
-func pub_images_favicon_ico() (*asset, error) {
-	bytes, err := pub_images_favicon_ico_bytes()
-	if err != nil {
-		return nil, err
-	}
-
-	info := bindata_file_info{name: "pub/images/favicon.ico", size: 830, mode: os.FileMode(416), modTime: time.Unix(1422215120, 0)}
-	a := &asset{bytes: bytes, info: info}
-	return a, nil
-}
-
-var _pub_images_gopher_png = "\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x00\x39\x0f\xc6\xf0\x89\x50\x4e\x47\x0d\x0a\x1a\x0a\x00\x00\x00\x0d\x49\x48\x44\x52\x00\x00\x00\x6a\x00\x00\x00\x46\x08\x03\x00\x00\x00\x13\x91\xf1\xe8\x00\x00\x02\xfa\x50\x4c\x54\x45\x00\x00\x00\x6a\xd7\xe5\x05\x0a\x0c\x22\x43\x45\x01\x03\x04\x00\x00\x00\x02\x05\x05\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0d\x1c\x1d\x00\x00\x00\x00\x00\x00\x2b\x55\x57\x17\x2e\x2e\x00\x00\x00\x00\x00\x00\x2e\x5c\x5e\x06\x0c\x0c\x00\x00\x00\x14\x29\x29\x20\x41\x42\x00\x00\x00\x00\x00\x00\x1a\x35\x35\x10\x20\x20\x08\x11\x11\x6f\xe1\xf0\x07\x0f\x0e\x6a\xd7\xe5\xff\xff\xff\xff\xff\x00\x00\x00\x00\x67\xd2\xe2\x6a\xd6\xe4\x69\xd5\xe3\x66\xd0\xe0\x7d\xfd\xff\x80\xff\xff\x6b\xcd\xcf\x7a\xfa\xff\x6a\xd8\xe7\x69\xd4\xe1\x68\xd4\xe3\x6f\xe1\xef\x68\xd0\xda\x85\xff\xff\x70\xe3\xf2\x68\xcf\xd5\x77\xef\xfe\x76\xee\xf8\x7e\xfa\xff\x78\xf3\xff\x74\xe9\xf7\x78\xf7\xff\x7a\xf5\xff\x6b\xda\xe9\x6d\xdd\xed\x6c\xdb\xea\x7c\xf5\xf8\x75\xeb\xf0\xcb\xbd\x03\x66\xcb\xcf\xf7\xd2\xa2\xf5\xd0\xa0\xf2\xee\x01\xff\xfd\xfb\x1c\x39\x39\x72\xe6\xf5\x64\xce\xde\x68\xd2\xdd\x60\xc4\xcb\x6c\xcc\xc7\xff\xff\x09\x4c\x95\x96\x41\x81\x82\x68\xd5\xe6\xff\xf6\xbe\x1d\x1b\x1b\x57\xad\xb1\x3d\x77\x78\x31\x30\x2c\x0b\x26\x25\xff\xff\x15\xdb\xd3\x05\xea\xe6\x02\x36\x6c\x6d\x8a\x73\x6c\x09\x0b\x0c\x73\xf4\xfa\xfb\xf5\xf6\x60\xdc\xe4\x6f\xdf\xe2\x5e\xbf\xc4\x2b\x7f\x82\x1b\x50\x54\x29\x27\x26\xf7\xf5\x01\x89\xff\xff\xff\xfe\xc7\x43\xaa\xb0\x51\xa2\xa5\x68\x87\x62\x58\x4b\x46\xff\xfe\x31\xff\xff\x23\xe5\xdf\x05\x6c\xec\xf3\xe8\xd9\xd8\x6b\xd5\xd7\x6e\xc9\xbf\x65\xbd\xbf\xcb\xb8\xb7\x6e\xc3\xaf\x93\x7e\x7b\x6a\x9f\x72\x6a\x93\x67\x1f\x5f\x62\x5b\x53\x52\x4b\x49\x4a\x4c\x4a\x3b\x3e\x3c\x3b\x25\x36\x37\xda\xc8\x0d\xfd\xfc\x01\xb7\xa6\x01\x64\xc9\xd4\x6e\xc7\xb8\x64\xb5\xb5\x3d\xa2\xa6\x45\x8b\x8e\x6c\xab\x87\x73\x73\x71\x66\x7b\x67\x6d\x5d\x5b\x73\x80\x59\x27\x50\x51\xff\xfd\x41\xa9\x97\x39\x4c\x3a\x37\x17\x2e\x2e\x43\x30\x2c\xc3\xb3\x06\xaf\x9b\x05\x73\xe5\xe8\xdf\xce\xcd\xff\xe3\xaf\xbe\xab\xa8\xfe\xd9\xa7\x99\x98\xa5\xb6\xa2\x9e\xa3\x8e\x8d\x2f\x87\x8b\x8a\x86\x86\x6b\xa4\x81\x5e\x89\x81\x29\x76\x78\x7d\x6a\x68\x35\x65\x64\xf3\xe2\x60\x60\x61\x5e\x71\x6b\x50\x7e\x7a\x4e\xce\xbd\x26\x0e\x1b\x1a\x97\x7c\x02\x67\xe5\xea\xf0\xe3\xe1\x5c\xd5\xdd\x6a\xda\xdc\x58\xcc\xd5\x4f\xc1\xcb\xc2\xc1\xc9\xd6\xc4\xc3\x5c\xbb\xbe\x4a\xb6\xba\xfe\xed\xb7\x59\xb4\xb7\xf5\xcb\x9c\xaf\x99\x97\x94\x93\x92\x5d\x94\x8d\xa4\x8c\x84\x7f\x7e\x7f\xa1\x97\x73\x23\x6d\x71\x96\x7b\x60\xff\xf0\x56\x17\x4a\x4c\x13\x41\x40\x77\x60\x3f\x9a\x88\x30\xcf\xc9\x02\x8e\xff\xff\xf6\xed\xec\x5e\x9e\x9a\x37\x94\x9a\x6c\xb1\x90\x7b\x7b\x66\xe4\xcd\x5d\xff\xfe\x52\x2e\x48\x48\x33\x3d\x3b\xb3\x9c\x20\xab\x90\x1c\x3d\x35\x11\x17\x10\x0f\x9e\xa0\x09\xbf\xac\x06\xaa\x95\x02\x70\xea\xfa\xe9\xe9\xe9\xd2\xd3\xd6\x5e\xa6\xa6\x6f\xbe\xa3\xc2\xb1\x76\x66\x87\x75\x6b\x76\x60\x89\x89\x48\xc1\xab\x21\x5a\x5d\x1b\x87\x71\x08\xa6\xa7\xa6\x42\x9d\x9b\x6e\xb8\x98\xc9\xb6\x92\xd5\xc7\x8c\xe2\xd8\x7e\xd1\xbe\x68\xa7\x88\x5c\x8f\x75\x28\xe0\xe0\xe3\xe7\xdc\xab\x68\xb5\xa4\x67\xb0\x9d\xd1\xbe\x98\xe1\xca\x97\xaf\x9b\x82\xb4\xa5\x77\x79\x8b\x6e\x69\x68\x6d\xc4\xa2\x5a\xd9\xb7\x53\xc4\xa5\x45\xb5\xb8\x2e\xed\xee\x1c\xda\xda\xda\xb1\xb3\xb1\xba\xa7\x84\xe7\xcc\x3f\x96\xff\xff\x95\xff\xff\x91\xff\xff\xef\x94\xef\xb3\x00\x00\x00\x1e\x74\x52\x4e\x53\x00\xe2\xb1\xfe\xa3\x80\xba\x5e\x1e\x8c\x3a\xc5\x2d\x05\xfd\xd7\x4d\x11\xfe\xcc\x75\xed\xea\x94\x6d\xea\xd7\xdf\xe1\xee\x1a\x2a\x62\x57\x00\x00\x0b\xd0\x49\x44\x41\x54\x58\xc3\xac\x96\x4d\x4c\xd3\x60\x18\xc7\x0d\x63\x20\x8a\x88\x20\x7e\xc6\x36\x7d\xdb\xb7\xb5\xdd\x6a\xeb\xd6\x6d\x5d\x37\xb2\x03\xdb\x82\xd9\x16\xf6\x71\x98\x9b\xe8\x46\x16\x08\x07\x24\x12\x12\x40\x38\x18\x21\x86\x90\x30\x12\x8c\x51\x31\x01\xe2\xc1\x28\x82\x4a\x0c\x26\x86\x04\x0e\x20\x17\x3c\x78\x10\xcf\x7a\xf4\xea\x49\x13\x35\xf1\xed\xc6\x3e\xdc\xaa\x31\xea\xff\xda\xa7\xcf\xef\xf9\x3f\xcf\xd3\xbe\xef\x9e\x3f\xd6\x81\xbd\xb5\xf5\xc7\xf4\x95\x15\xfb\x0f\x1e\x3f\x7e\x10\x69\xff\xfe\x8a\xca\x1a\x7d\xf5\xbe\xda\x43\x7b\xfe\xa7\xf6\xd6\xeb\x2b\xf6\x1f\x69\x08\x8d\x05\x5b\x7b\xa7\xcd\x36\x9b\x6c\x31\x37\x37\x4f\xf7\xba\x2e\x75\xf9\xa3\x75\x07\xab\x6a\xaa\xf7\xfe\x27\xcc\xd1\x46\x5d\xa8\xab\xd5\x6e\xa6\x19\x9a\xb5\x98\xac\x22\xcf\xf3\xa2\xe8\xf1\x58\x4d\x16\x1b\xcb\x30\x6c\xf3\x99\xa0\x5f\xd7\xa8\xdf\x77\xe0\xdf\x38\x87\xaa\x2b\x8e\x87\x9e\x4f\xdb\x68\xda\xe2\x51\x1c\x1c\x49\x19\x91\x28\x55\x46\x55\x14\x89\x71\x0e\xde\xcc\xd2\xec\xc5\xae\xba\xc6\xc3\xff\xe0\x6d\x5f\x65\xd3\x58\xab\x93\x61\xad\x76\x08\x0d\x24\xf6\x2b\x51\x10\x72\x56\x9a\xb9\xe8\x6b\xaa\xf9\x4b\x58\x7d\xd5\xc4\x33\x0b\x63\xe1\x39\x00\x7e\x8d\x21\x29\x8a\x44\xa2\x00\xb0\x9b\x19\xb9\xab\x49\x7f\xe0\x2f\x1c\x35\x36\x9c\x15\x64\x05\x1a\x28\x03\xf5\x2b\x12\x87\x41\xa3\x47\x96\x59\x96\xb5\xc9\xd6\x80\x17\x2a\x8c\xe4\x3b\x59\x9f\x6d\x7c\x4d\x65\x45\xe5\xe1\xda\xdf\x2c\x74\xae\xa6\x43\x35\x3a\x17\x23\xdb\xbd\xd0\x68\xe7\x3d\xa2\x23\x00\x28\x2d\x14\x08\xc8\xcc\xc3\xe5\xad\xd9\xd5\xd5\x99\xce\x76\x46\x0e\x40\x28\x0a\x17\x75\x7a\xf4\x76\x53\xe8\x79\xeb\xb5\x4b\x3e\x5d\x55\xad\xf6\x06\x54\xee\x3f\x88\x16\x77\x9f\x6a\xe9\xa4\x4f\x60\x81\x97\xb7\xb0\x3d\xed\x8b\x4b\x4b\x0b\x3d\xac\x2c\x02\x63\x09\xc8\xc0\x49\xf1\xd1\x64\x3a\x3c\x38\x37\x37\x37\x3e\x9c\x5e\x1b\x65\x2d\xc0\x00\x4c\x82\xbf\xe2\xe0\x33\x27\x83\x76\x55\xa6\x19\x97\xfb\x70\x39\xe9\x58\x93\xff\x12\xd7\xcc\x9f\x09\x36\x34\xee\xab\x76\x3f\x16\x94\x6b\x0e\x21\xde\x39\xb0\xbe\xbd\xfd\x7a\x7b\x7b\x7d\x60\xb4\x47\x70\x40\xae\xb8\x79\x50\x11\x3a\xd3\x53\xfd\x37\x2f\xe0\xaa\x2e\x5c\xee\x0f\xaf\xb7\x32\x80\xf4\x2a\x29\x5f\xc4\x29\x79\x21\x30\x40\xaf\x5d\x12\xa2\x35\xa5\x1f\x4f\x95\xdf\xc9\xc8\x56\x51\x34\xb1\xa9\x5e\xa2\x8e\x91\x01\x90\xe3\x33\x91\xe1\xf3\x77\x9e\x3e\xda\x7c\x74\xf3\x56\xff\x64\x24\x11\x37\x01\xb2\x40\x02\xe6\x78\xb2\xed\x0e\x5e\xac\xfe\x58\x27\x13\xc0\x8c\x9c\xe0\x8b\xf5\x5a\x00\x47\x92\x1c\x05\xad\xa9\xf9\x63\x3f\x93\x4e\x06\x53\x1e\x2f\x44\x99\xbc\x9c\xb4\xdd\x20\x78\xbc\x9c\xb0\x1c\xe9\xee\x6b\x29\xe4\x79\xda\x1d\x59\x62\x60\xc1\x93\xe9\x61\x64\x3c\x6b\xa8\x28\xa6\xed\x03\x0a\xa1\x40\x6a\x2c\x36\x2d\x30\xb4\x1d\xa2\x8a\x44\x67\xba\xb6\x78\x1b\xaa\xae\xa7\x02\x90\x44\x8b\x4b\x29\x82\xce\x2d\xf0\x5e\xbb\x30\xf3\xe6\x4e\x49\xa2\x15\x54\x74\xce\x17\x10\x7b\x22\xe7\x71\xa4\x52\xd6\x33\xd5\x4e\x20\x15\x72\x77\x45\xbb\x68\x05\xa0\x9a\xa4\xeb\x95\x45\x28\xfd\x58\x0a\x60\x40\xa1\x19\x8b\x22\x44\xdd\x82\x08\x49\x26\x31\xf5\xa8\x2c\x51\x5f\x6c\x49\xda\x9d\x97\x83\x59\x9f\xc3\x35\x74\x27\xd2\x2c\x62\x98\x31\x20\x44\x09\x82\x88\xd2\x0e\x12\x23\x1d\xce\x23\x7b\x0b\xed\x6b\x72\xf2\x00\xf2\xce\xe0\x58\xeb\xc6\xab\xc8\xb4\x15\x02\xe9\x43\x07\x22\x95\xe9\x56\xe4\x21\x4f\x65\x06\x45\x6f\x4d\xe1\x9a\xea\x4e\xb0\x00\x3d\xf7\x38\xdd\x88\x15\xb4\xa9\x2d\x64\xc6\xf4\x79\xd4\x61\x1f\x03\x49\xd1\x39\x81\x1e\xea\x22\xe7\x2c\x90\xb2\x3e\x7e\xd0\xa7\x99\x68\x7c\x80\x06\x08\x45\x29\xed\x23\xda\x01\x78\xdf\xc8\x43\x3b\x6a\x32\x90\x4f\xc7\x50\x36\x96\x23\x31\x8a\x7d\x5e\x95\x47\x55\x9d\x31\x91\x80\xf6\x13\xaa\x7c\x02\xe0\x30\x7a\x60\x4e\x3b\xd1\xe5\x8e\x05\x2b\xfa\xbc\x20\x3b\xd3\x8d\x23\xb5\xb4\x94\x87\x0c\x8f\x5a\x50\x35\x24\x14\xfc\x2a\x0a\x43\x1d\x34\x5d\x6b\xcc\xa3\x8e\x37\xf3\x94\xc8\x3f\x50\x49\x13\x82\x9d\xc2\xc4\xc5\xf4\x65\x5c\x5b\xe3\x09\x9a\xc2\x48\xbb\x6d\x6d\x05\x81\x5e\x0c\x87\x6f\xec\x94\x46\xcc\xad\x66\x8c\x1b\x3c\xcd\x0f\x88\x4b\x19\xaa\xc9\x55\x40\xe9\x6c\xbc\xd1\xe3\x88\xa9\xa8\x73\x26\x88\x01\xdb\xcc\x20\x7a\xe7\xdd\xfd\xe1\xfb\x4f\xca\xa6\xb5\x16\x77\x60\xa4\x75\x61\x04\xd5\x72\x9f\x40\x0a\x6f\x96\x2e\x46\xd2\x86\x96\x41\x1d\x51\xa8\x4e\x08\x70\xa8\xdb\x74\xb0\xa2\x80\x72\xf2\x58\x80\x0d\xa1\x17\xe7\xa5\x00\x6a\x8f\x6d\xa0\x1f\xc7\xbf\x10\xaa\xde\x97\x24\xba\x39\xb2\xc0\xa3\x3a\x97\x87\x2f\xe0\xef\x89\x8c\x3e\x95\x46\xe8\x7a\x44\x84\x42\x41\x57\xdc\x17\x15\xc4\x34\x32\xa1\xea\x3c\xea\xc4\xc5\xb7\x18\xc5\x4b\x75\x04\xe1\xb2\xa9\x8e\xdf\x4e\xdc\xc2\x77\x3a\x08\x55\x6d\x1f\x7f\x4e\xb4\xf9\x7a\xc9\x4a\x92\xf2\xe8\x10\x8e\x0f\x11\xd9\x88\x9d\x92\x71\x8e\xb4\x5b\x33\x28\x4e\xd2\xf9\x6c\x90\xf3\x9a\xa7\x1b\x0b\x07\x4b\x4d\x90\x86\x1c\xe4\x53\xf3\x6e\x46\x41\x31\x0e\x51\xd7\x87\xdf\x26\xb2\x7a\xf1\x73\xa2\x96\xa1\x4e\x33\x49\xb2\x5b\x68\x2b\x32\xb5\xbc\x1c\x24\x9e\x68\xa3\x30\x28\xf9\x27\x24\x00\x1c\x1b\xf3\xc8\x54\x4e\xb5\xc7\x05\x1e\xe1\x9d\xf3\x7e\x09\xe4\x50\x2f\x33\x83\xb8\x47\x7c\xd2\x42\x59\x3a\xaf\xb6\x64\x5c\xb5\xe1\x4f\xda\x3e\x97\xfc\x30\x74\x6f\xf9\x0c\xca\x20\xba\xdc\x8f\x79\x73\xca\x7f\x74\x4f\x91\xf4\xa1\x0d\xc5\x2b\x3e\x76\x9f\x15\x0d\xd8\x6e\x03\x5f\x64\x2d\x85\x6f\x68\x35\x50\x5d\x8b\xac\xef\x7b\xc3\x43\x25\xb3\xba\x9b\x64\xb1\xac\x02\xcd\x6e\xdf\xd7\xe6\x06\x44\x2a\x56\x65\x48\x30\x49\x63\x3a\x27\x50\x43\x80\xba\x16\x9f\xd5\x85\x1c\xbc\x1d\x7b\x52\x32\x74\x77\x3b\x8f\xc6\xc0\x26\xef\xe2\xf8\xa0\xd6\x30\xf1\xee\x19\x09\x64\x51\x46\xda\x5f\xf7\xfc\x64\xae\x7b\x05\x5f\x6e\xd7\xb7\x06\x3f\xbb\x8b\x4a\x8c\xe3\xc8\x56\xa6\xea\xf2\x9a\xed\x28\x42\xde\x9a\x44\x47\xd4\xbd\x70\xc7\xfd\x1d\x8d\x15\xa5\xb2\x28\xd2\xd4\x7b\xea\xd8\x21\x8d\xab\x44\x55\x5d\xda\xf5\x96\xcc\x84\x78\x96\xc2\xe8\x0f\xf8\x3e\xdc\xd6\xf1\x02\x2f\x19\xd5\xe4\x07\xc9\x88\x91\x14\xd7\x93\x5e\xf9\xc5\x37\xbe\x4a\xe7\x4f\x1a\xde\x79\x44\xfb\xe6\x5b\x3f\x21\x29\xbb\x31\x74\xb2\x5f\x3d\x58\x77\x2e\x94\x26\x5a\x71\xc7\xed\x10\xd8\x15\xcc\xd2\xd9\xb1\xa9\x45\xba\x9b\x46\x7f\xf6\xbc\x98\x68\xbd\xf6\x15\x29\xc4\x70\xbb\x4d\x36\xb5\xb6\x69\xfe\x99\x2e\x84\x47\x2d\x5e\x33\x8d\x24\x6d\xac\x4e\xb6\x68\xfc\xfa\x63\x97\x64\x90\x27\x01\xb6\x4b\xaf\x89\xaa\x09\xb2\xc6\x5c\x0c\x33\x3b\xa9\x85\x9a\x5a\xa5\x45\xa6\xd7\xd7\xa0\xab\xf3\xbb\xbe\x0f\x4c\x6d\x96\x79\x7a\xb0\x2c\x41\xac\x80\x32\x9f\xae\xd0\x44\x55\xf4\x9a\x73\x05\x51\x76\x7a\x6d\xb0\xdc\xd3\xd4\x1a\x6d\x75\x46\x89\xac\xa2\x1b\xb3\x25\xe7\xf4\xcd\xc9\xc8\x22\x03\x8b\xaf\x54\xa2\xe7\xc4\x01\xad\x2b\xe0\x09\xb3\x98\xbf\x7e\x19\x45\xe7\xda\x64\x49\x0f\xfb\xda\x06\x18\x8f\x3c\x41\xe4\x34\xbf\xb1\x1c\x99\xba\x9b\x0b\x7a\xb4\xd2\xfd\x20\x61\x33\x41\xb2\x08\x45\x06\xe4\x3a\xad\x7b\xe0\xde\x23\x36\x47\xe1\x5e\x09\x15\x26\xf1\xe6\xfc\xa3\x82\xa3\xbe\xc9\xc8\xa8\x10\x10\xba\x88\xbc\x62\xae\x54\x7c\x6b\x7d\x64\x68\x70\x7c\x6e\x7c\x70\xe8\xf5\x7a\xe2\xa1\xc0\x41\x0e\x2b\x16\x90\x42\xf5\x5a\x5b\x11\xa5\x8d\xc5\x51\x0a\xb3\x98\x6c\xeb\xbe\x7b\xeb\xe9\xcd\xa7\x7d\x2b\x73\xe1\x74\xa2\x47\xb2\xd3\x3d\x6e\xa2\xa0\x31\xc1\x64\x89\x2f\x74\x26\x66\x67\x67\x13\xa3\x8b\x71\xda\x53\x76\x29\x05\xb4\xef\x98\x06\xaa\xda\x4f\x83\xe2\x30\x12\x5a\x99\xf6\x99\x81\x75\xf7\xb6\xce\x9d\x9c\x5d\x8e\x33\x01\x9e\x9d\x0d\x77\x14\x48\x57\xaf\x26\xdb\x25\xaf\x62\xa2\x55\x59\x78\xa3\x01\x2b\x93\x81\x0d\x1e\xd5\x40\x1d\xf6\xfd\xe8\xbd\xdc\x63\x92\x8a\xe2\x38\xbe\x9a\xbd\xdb\x7a\xac\xd7\x5a\x33\x86\x84\x10\xc6\x42\x96\x56\xc6\x45\x6e\x0f\xc9\xda\xed\x31\x2f\x3d\x75\xc9\x28\x53\x5a\x31\x7b\x5a\x80\x51\x5a\x26\x2d\x68\xa4\x95\xb6\x55\x40\xb4\xd5\x1f\x45\xf6\x32\x6c\xc0\x66\x65\x4b\x51\xd8\xf2\xad\x95\xa6\xce\x67\xa6\xe9\xaa\xbf\x3a\xf7\x5e\x5a\x59\x27\xa8\x45\x7d\x06\x3b\x97\x8d\xf3\xfd\x9c\xfb\x3a\xe7\x77\x86\xaa\xc0\xe2\x16\xc6\x67\x82\x42\x7a\xeb\xd6\xeb\x9b\x99\x0b\x41\xbd\xc6\xde\x76\xa6\xc0\x9a\xf6\xf5\xf2\x19\x31\xab\xfd\xda\xed\x50\x3a\x05\xbc\xa6\x67\x84\x8b\x61\x8f\xe0\x88\xb5\x6c\xfa\x4f\x7f\x65\x04\x2f\x8a\x8a\x5a\x0c\xd6\x5d\x90\x14\xfa\xe8\xb4\xcc\x60\xc0\x3e\x19\x77\xa6\xed\x34\x16\xb6\xcb\x72\x72\x64\x4d\xe5\xc7\xbc\x13\x1e\x9c\x90\x88\xf8\x71\x90\x47\x70\xcc\x9d\x48\xdf\xbd\x6e\x26\xb6\x1b\x0a\x44\x39\x98\x0c\xb3\x12\x5f\x91\x48\x54\x20\x6b\xba\x76\x33\xc2\x57\xaf\xf9\x82\x09\x90\xa9\x29\x88\xac\x39\xe0\x50\x73\xb0\x5d\x26\x8a\x8e\x16\x89\xa2\x73\x72\xc8\x06\x50\x20\xb3\x91\xd3\x2f\x1c\x6a\x16\x1c\x0d\x79\x83\xc5\x0b\x38\x3e\x55\x07\x6c\x18\x91\x2f\x02\x67\x66\x30\x44\x17\x90\x2e\x43\xf7\x91\xad\xf3\x7d\xf4\x8a\x62\xc2\x5e\xac\x91\xe2\x70\x5f\x2a\x4e\xe4\x45\xbb\x8c\x3c\x27\xcc\x6a\x68\x17\x59\xb1\x1c\xe2\x0a\x62\xcd\xcb\x76\xcd\x83\x77\xa0\x66\x9d\xd0\xcb\x63\x21\xaa\xf4\xf0\xb0\x60\x1f\x2c\xbf\x7e\xad\x5a\x66\xc5\xb0\x6e\x5b\xe2\x9a\x35\x87\xcb\x8d\xdd\x32\xcc\x80\x61\xae\x27\x6c\x06\x64\x7f\xcc\xe1\xa6\x8b\x13\x6e\x81\xa7\x98\xb9\x61\xe2\x9f\xab\xe8\xec\xed\xe5\xf6\x42\x63\x62\x5a\x26\x28\x10\xb7\xbc\xca\x7b\x91\x66\x6b\xfa\xe4\x7a\x1c\x9f\xa0\x50\xac\xad\x2c\x5e\x75\x1f\x70\x89\xa0\xa6\xa6\xb7\xb7\x37\xab\xce\x64\x72\x68\xf3\x43\xe6\x32\x57\x8f\xfa\x7d\x15\x27\x86\x27\x14\x2b\x34\x1a\x4d\xa5\x66\xdd\x8b\xf5\x07\x3f\xbe\x9c\x3d\x3b\xf3\xd0\x52\x50\x91\xae\x3f\x63\xb7\xd7\xd5\xd9\xdb\xb4\x5a\x87\x56\x6b\x6e\x2b\x2b\x2d\xad\x2f\x33\x2b\x89\x03\x44\xe9\x76\x5b\x70\xa5\x6a\x20\x9d\xc1\x06\xd3\x05\xfc\x5e\x89\x2b\x2b\x8b\x8b\xc1\xf0\xc0\xd8\x4e\x9c\xa8\xad\xad\xcd\xca\x32\x9a\x4c\x75\x0e\x87\x43\xdb\xe6\x28\x2b\xad\x2f\x6d\x20\x4a\x8d\xa5\x57\x4f\x12\x4b\xd5\xd5\x0a\x25\x82\x2a\x3d\x08\x2a\x47\x70\xa5\xd4\xe2\x51\xeb\xd4\x16\xc4\x89\x58\xd4\x6a\xc4\xe9\x46\x3c\x88\x7c\xb0\x50\xc1\x5d\xb2\x63\x0a\xfc\x09\xac\x74\x98\xcb\xcc\xa5\xf5\xf5\x66\xb3\xbe\x4c\xa7\xd4\xeb\x74\x72\x65\xa3\x52\x29\x71\x23\x6a\x44\x0d\x22\x70\x7d\xc3\x4b\x42\x95\xf6\x90\x52\x39\x11\x89\xc4\x83\x48\x51\x09\x8a\xa2\x8d\x1e\x94\x38\x90\x7a\xf4\x34\x12\x44\x4d\x4b\xce\xeb\xe0\x2d\x59\x3b\x02\xa2\x02\xef\xd5\xa5\x64\x1a\x8e\xea\xdd\x38\x2a\xd1\xe9\x11\x1c\x7c\x50\xbc\x11\x97\xcb\x75\x08\x82\x5b\x74\x12\x39\x2a\x07\x67\x45\xaa\x66\x03\x32\xd5\x44\xa4\x1e\xa1\xa2\xf5\x3a\xaa\x75\x7a\x7f\x4b\x24\xb4\x94\xc2\x4e\x16\xff\x0e\x44\x35\xfd\xd6\x42\xfa\xfd\xcf\x34\x27\x8a\x4b\x50\x95\x4a\x6e\x41\x55\x34\x00\xda\x28\x27\x7b\xaa\x2c\x64\xdb\x98\x4b\xa8\x76\x52\xaa\x0a\x32\x12\xa5\xa2\x55\xb4\x1f\x01\xaa\xa7\x2c\xfe\xad\xe9\xb0\xf5\x7e\x21\xbd\xf8\xb3\xca\x2d\xa5\x11\x48\x75\x54\xab\xc2\xa5\xde\x51\x92\xed\xc0\x41\xb2\xc2\x7d\xb6\x94\x52\xf9\x26\xee\x57\xaa\x11\x3b\x22\xe7\x14\xf7\xc7\x4a\x54\xbe\x7a\x4b\x5d\xe0\x0a\xe6\xba\xd2\x40\x9d\xb9\xe5\xb0\xd3\xaf\x2a\x83\xc5\x4f\x08\x82\x2c\x22\xaf\xd9\xf4\xce\x0f\xb1\x7e\xba\xcb\xd7\xe4\xbe\x77\x49\xf1\x4d\xb9\xb9\x89\x15\x34\x7f\xaa\x77\x19\x2c\x01\x4c\x35\xea\x38\x93\xde\x01\x54\x7e\x70\x36\x54\x80\xbb\x83\xbb\x1a\xcc\xb4\xdf\x52\x89\x61\x2a\xb0\x0d\xd1\xf8\x57\xa9\xc8\x9b\x16\x3b\x38\x18\xeb\x57\x15\x5b\x9d\xb1\x52\x20\x0c\x82\xd5\x16\xec\x30\x8d\x16\x04\x04\x0a\xa0\xca\x87\xab\xc6\x4f\x8e\x0c\xd6\x68\xe3\x68\x01\xa4\x25\x9f\x05\x55\x8d\x9e\xb6\x20\x4a\xf1\x57\xaa\x58\x40\x5c\x5c\x5c\x4a\x4a\x72\x72\x76\x76\xf6\xf3\xe7\xcf\x4b\xf2\x79\x67\x79\x90\xe2\x62\xd2\xd4\xf0\x79\x8a\xd6\x14\xff\x89\xde\x3c\x22\x91\xcc\x2c\x2a\xba\x72\xe5\xca\x5b\x40\x73\x73\x73\x57\x57\x57\xd3\xb9\xf3\xe7\xef\x5e\xb8\x97\x97\xf7\xf0\x61\x5d\x07\x57\x00\x54\x3f\x33\x53\x30\x5f\x31\xf0\xdd\xbd\x02\x89\x64\x1c\x39\xc0\xa2\x22\x22\xb1\xa4\xa4\xa5\xa5\xa5\xba\xfa\x5d\x4f\x4f\xcf\x03\x8a\xbd\x7b\xf7\x6e\x7c\x96\x99\x59\x5b\x7b\x82\x22\x29\x29\x69\xff\xfe\x7d\xfb\xf6\xe5\xe7\x67\x3c\xed\x54\x08\xd3\xf9\xbb\x61\xaa\x71\xf1\x11\xe2\xfa\x6c\x10\x08\x12\x4b\xfa\xfb\xfb\xfa\xfa\x5a\x5b\x5b\x6d\x36\xdb\xc6\xaf\x3c\x03\x64\x65\x25\x51\xec\xa7\x12\x33\x32\x9e\xbe\x79\x53\x55\x55\x95\x9a\x9a\x7a\xf4\xe8\xd1\x3d\x7b\xf6\xac\x24\x60\x11\xf0\xb8\x31\xdc\x39\x70\xd5\x70\x1e\x9f\x5e\x63\x34\x81\x55\xc3\x94\x05\x00\x61\x64\x1c\x95\x07\x02\x7f\xc8\x63\x0d\x81\x47\xc2\xf5\x12\x43\xc0\xe1\x70\x18\x74\xb8\x2a\x28\x21\x7c\x45\xb0\x50\x91\xea\x0d\x84\x24\x02\x84\x42\xe1\x77\xa1\x44\x20\x05\x83\x24\x84\x60\xc8\xd2\xcd\xbf\x01\x54\xb0\x42\x10\xec\x23\x62\xb8\x20\x08\x32\x46\x32\x93\x8a\x0b\xf6\x83\x7f\xd5\x94\x1d\x6c\x46\x08\xc3\x4b\x88\x8f\xc4\xbf\x57\xcd\x58\xc5\x0c\x0e\x30\x40\x15\x0f\x53\x8d\x02\x7b\xe1\x80\xab\x22\xa0\xaa\x89\x1b\x42\x17\xfd\x03\xd5\x38\xd8\x5e\xee\xf2\x7f\x53\x8d\x9f\xcc\x8c\x0a\xb8\x6a\xf9\x59\xa8\xea\x14\x7b\x71\xa0\x55\x61\xcb\x05\x33\xa1\xfb\xee\x25\xb3\x86\x05\x9a\x59\x82\x6f\x7b\xb9\x2f\x7d\xf5\x69\x9a\x2a\x16\xdb\x7a\x00\x00\x00\x00\x49\x45\x4e\x44\xae\x42\x60\x82\x01\x00\x00\xff\xff\x97\xb7\xfb\x68\x39\x0f\x00\x00"
-
-func pub_images_gopher_png_bytes() ([]byte, error) {
-	return bindata_read(
-		_pub_images_gopher_png,
-		"pub/images/gopher.png",
-	)
-}
-
-func pub_images_gopher_png() (*asset, error) {
-	bytes, err := pub_images_gopher_png_bytes()
-	if err != nil {
-		return nil, err
-	}
-
-	info := bindata_file_info{name: "pub/images/gopher.png", size: 3897, mode: os.FileMode(416), modTime: time.Unix(1422215172, 0)}
-	a := &asset{bytes: bytes, info: info}
-	return a, nil
-}
-
-var _pub_styles_main_css = "\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8c\x79\x67\x6f\xac\xd8\x96\xf6\xf7\xfe\x15\x96\x46\xa3\x3b\x77\xb8\xa7\xc9\x50\xb8\xa5\x91\xc8\x45\x2a\x0a\x28\x42\xf1\x8d\x0c\x45\xce\x14\xaf\xee\x7f\x7f\xb1\x8f\xdd\x27\x74\x98\xc1\xb2\x0d\x7b\xaf\xf8\xec\xb5\xd3\x5a\xf9\x54\x57\xff\xfa\x25\x6c\xe3\xe7\xcb\xff\xfb\xe5\xe5\x78\xda\x25\x19\xd2\xaa\x5d\xbf\x6c\xaf\x2f\x79\x11\xc7\x49\xf3\xdb\x0b\xf8\xdf\x2f\xd7\x21\x59\x92\x66\x7a\x19\xa3\xa1\xad\xaa\x97\xb6\x79\x69\x82\x61\x68\xd7\x97\x38\x59\x8a\x28\x19\x5f\xfe\x1b\x7c\x67\xaf\x8b\xe6\xcb\x5a\xc4\x53\xfe\xfa\x02\x43\xd0\x7f\xfe\xf6\xcb\xbf\x7f\x79\x53\xf1\x21\x3c\x4f\x8a\x2c\x9f\xbe\x75\x7d\xa7\xb7\x6b\xc7\x62\x2a\xda\xe6\xf5\x65\x48\xaa\x60\x2a\x96\xe4\xb7\xaf\xed\x41\x1c\x17\x4d\xf6\x65\x6a\xbb\xd7\x17\x12\xea\xb6\x1f\x9b\xc3\x76\x9a\xda\xfa\xf5\x85\xf8\xbd\xe7\xcd\x82\x9f\xf5\x7c\x55\xf4\x3f\x2f\xbf\x46\x6d\x33\x05\x45\x93\x0c\x9f\x5a\x7f\x12\x83\xbf\x8b\x39\x18\xd2\xb6\x9d\xbe\x51\xfd\x6e\x5b\x10\x8e\x6d\x35\x4f\x1f\xb6\x7d\x72\x41\x5f\x3f\x7f\xf0\xfb\x7b\x77\xbf\x19\x17\xb6\xdb\x97\xb1\xd8\x0f\x95\xaf\xc7\xfb\x10\x27\xc3\xa1\xfa\xf7\xbe\xf7\xef\x77\x47\xe1\x6e\x7b\x39\x34\x15\xf1\xcb\x7f\xa4\xf8\xdb\xcf\x07\x49\x10\x95\xd9\xd0\xce\x4d\xfc\x25\x6a\xab\x76\x78\xfd\xd6\xfd\xef\x4f\x93\xbb\x0f\xa3\xeb\x60\xc8\x8a\xc3\x64\xf8\x74\xc8\x82\xbe\x27\x78\x4d\x8b\x61\x9c\xbe\x44\x79\x51\xc5\x1f\xc4\xc7\x88\x07\x87\xa1\xc3\x9b\xbd\x7f\x49\x1a\x7c\x10\x7f\xa8\x2e\x9a\x3c\x19\x8a\x77\xf2\x5f\x7e\xc9\x91\x1f\xd4\xfe\x0e\x28\xfc\x01\x68\x8e\xfc\x1a\x55\x07\x8c\x4d\xf6\x23\xdd\xbb\xb7\x1f\xf8\xfd\xc4\x8a\x7c\x8e\xc5\x7f\x24\x71\x31\xb5\xc3\xef\x8c\xdb\x67\x84\x11\xd0\xef\xc0\xfe\x01\xfb\xef\xc3\x80\xfc\x46\xf7\x89\x0a\xf4\x12\xcc\x53\xfb\x03\xf0\x43\x10\x17\xf3\xf8\xfa\x82\x7d\xa8\xfd\x35\x6b\xbb\xfc\x4f\x62\xe0\xcf\xe3\xb3\x4a\xd2\x43\x13\x06\xfd\xc4\x3c\xd6\x41\xf5\x19\xfd\x71\x31\x76\x55\xf0\x3c\x46\xbe\x6a\xa3\xf2\x2b\xfb\x94\x6c\xd3\x97\xa0\x2a\xb2\x43\x70\x74\x4c\xb0\x64\xf8\x8e\xfb\x35\x4c\xd2\x76\x48\x7e\x87\xfd\xe8\x6e\x0e\x25\xff\xf8\xc7\x6f\x7f\x1b\x96\xbf\x63\x41\x7c\x7a\xfd\x0d\x88\xcf\x96\x77\xdc\xbf\x7c\xfb\x1e\xbe\x12\x9c\xfe\xf3\xe7\x40\x7b\x83\x0a\x7a\x69\xda\x2f\x43\xd2\x25\xc1\xf4\x87\x38\x2c\xea\x20\x4b\x5e\x5f\xe6\xa1\xfa\xaf\x7f\xc4\xc1\x14\xbc\xbe\x37\x80\x5d\x93\xfd\x16\x06\x63\x42\x60\xff\x2a\x1c\x46\x37\x57\x48\x11\xb3\x96\x3e\x9e\x8b\x65\xe7\xbc\x9d\x1d\x6f\xe2\xdb\x37\x23\xb2\xb4\xf6\xd6\x7e\x2b\xd3\xf3\x5b\x03\x0b\x54\x8c\xe6\xf0\xf6\x5b\x5f\x0f\x03\xb6\xc0\x66\x6b\x61\x40\x36\x43\xaf\xc6\x1b\x1d\xcb\x18\x6f\x7d\x3f\x3c\x97\x33\x03\x7d\xbe\x0f\x8e\x13\x79\x6a\x81\x7d\x7e\xcf\x9e\x80\x89\x1c\xbd\xbe\xbd\xdb\x8a\x55\x66\x86\x2d\x7d\xf6\x05\x97\x9b\x6d\x48\x2c\x2d\xf1\xa6\xb0\x60\x29\x7d\xe6\x56\xfc\xd0\xe9\x81\xef\xcf\x3b\x0b\xd3\x40\xb3\xdc\xc3\xb3\xd9\xc1\xc9\xa5\x86\x74\x86\x02\x53\x80\x06\x41\x6a\xd8\x23\x8a\x00\x16\xaa\x47\xf4\xb8\x83\x75\xa1\x85\xf5\xcb\x82\x25\x54\x0b\x5d\x7a\xe1\xe8\x5f\x31\x50\x6e\x77\xca\x41\x49\x10\x47\xc8\x25\x07\x0e\x5a\xec\x04\x52\x10\x91\xc6\x18\x05\x52\x04\x95\x52\x03\x32\x57\x13\x9a\xc0\x23\x02\x74\x27\x2a\xcd\x61\x02\xe4\xd4\xc5\xb8\xd4\xcf\x13\x88\x42\xbd\x0a\x43\x0a\xf7\x24\xb3\x33\x08\xa6\x63\xa4\xdf\xaa\x27\xbe\x38\xe5\x3e\xe1\x2d\x34\xc1\xd9\xa6\x4d\xe3\xae\xa5\x87\x9d\x95\x56\xf9\x3e\x93\xdd\xe5\x16\x4e\xc2\xc3\x32\x2c\x16\xb7\xc9\x1b\x42\x9e\x8a\xd1\x83\x8c\x5d\x55\xb9\x38\x7c\xda\xbc\x10\x5a\xbd\x1e\xcf\x24\x24\x14\x11\x25\x42\x56\x99\x5d\xb7\x9d\xba\x0e\x24\x95\xfa\x19\xaa\x9b\x0b\x0a\xc8\xc9\x72\x32\x86\x14\x93\x42\x5b\x30\x3a\xf9\x19\xa2\x94\x21\xca\xef\x78\x8c\x31\xd7\x0f\x8c\xd9\xf6\x4e\x9b\x23\xf2\xdd\x82\x42\x10\x7c\x1c\xb6\x3d\xaf\x15\xba\x3a\x23\x79\xbd\xb6\x48\x9c\x0f\x70\x1c\xcf\xcf\x90\xaa\x96\x10\x54\x67\x35\x9e\x57\x02\xb8\xa5\xcd\xd4\x37\xa8\xdc\x97\x48\x94\x7a\x88\x1c\xda\xb0\xac\x5a\x76\xa7\x59\x8f\x11\xb8\x72\x63\x75\x79\xa4\xc1\x93\x46\x29\x90\x16\xd1\x2e\x13\xca\x67\x6c\xce\x1b\x99\x97\x53\x68\x53\x6d\xef\x0b\x05\x86\x8f\x3d\x16\xef\x11\x2a\xd4\x09\x12\x4f\x9e\x33\xed\x07\x59\x63\x0b\x87\x7e\x5b\xec\xaa\xbd\xd2\xf4\xc7\xdb\xb8\xe3\x9c\xc6\xee\xdc\xb8\x06\x4b\xbd\x3a\x3b\x9e\x3c\xd2\x7d\x44\x41\x8c\x00\x80\x9e\x78\x00\x48\x90\xf8\xb5\xe2\x22\x6d\x47\x3c\x1e\x2d\xb4\xe4\xd8\xac\xe4\xad\x3f\x74\x92\x90\x14\x77\xbe\x8b\x9b\x9c\x0a\xea\x0c\xf6\xdd\xdb\x8e\xd5\x07\xa4\x0e\xbe\x05\x30\x03\x24\x25\xa9\x2f\xd6\x5d\x17\xb7\xde\x4b\x69\xb9\xc1\x93\x61\xc5\x00\x21\x82\x63\xb8\x47\xa6\xed\xbe\x5f\x9c\xeb\x1a\x2d\xec\x1a\x35\xee\xa6\x5d\xa2\x99\xc4\x95\x69\x18\x00\x32\x8c\xfd\x49\x4d\xe1\x27\xfe\x5c\x6a\x3f\xb1\x4b\x5c\x8e\x2b\x09\x29\x1f\x92\x09\xa6\x0d\x90\x57\xa8\xf6\x08\x3d\xd1\x4d\x10\x0e\x3c\x09\x77\xcf\x2a\xd7\x9b\x61\x33\xe8\x9d\x03\x82\x82\xe3\xae\x4f\x43\xd1\x0f\xe4\x11\x32\xd9\x92\xa6\x6b\xd1\x4a\xee\xc6\xd1\x67\xc8\x04\x09\xcb\xdd\x41\x41\xb0\x92\x66\x8b\xcf\xf6\xeb\x6d\xde\x1b\x96\x1d\x4a\x66\xa5\x2e\x26\xef\xf1\x34\x90\xb4\x74\x0d\x0e\x74\xd0\x57\x86\xbc\x12\xcb\xd0\x11\x49\x63\x41\x94\x9f\x74\xbd\xbf\x2c\xfd\x95\x1d\x3d\xbf\xce\x51\x67\x88\x6b\x56\x2e\xec\x07\xd3\x3f\x85\xc0\x33\xe7\x73\x64\x14\x75\x47\xf8\x6c\xe3\x4f\xf3\x2c\x3f\xe4\xa9\x53\x9d\x0d\xdb\x0b\xe4\x4c\x9a\xcb\xb1\xac\x16\xc2\xf3\x1a\x5b\x8f\x0c\xd3\xaf\x0d\xaa\x4c\xed\x14\x98\xcd\x28\xa3\xe6\xd2\x3d\xf2\x67\x97\x2c\x35\xa6\x40\x9d\x17\xe3\x05\x82\x77\x81\x88\xf2\x6d\x35\xf8\x13\x7c\xe5\x3b\xdb\x85\x67\x96\x9c\xc8\x7c\x0f\x90\xa9\xdf\x46\x52\x24\x3a\xec\xd6\xec\x1c\xe0\x82\x20\xe0\x1c\xbf\xe6\xe1\xeb\x52\xe9\xc0\xfe\x36\xf3\xce\x8d\x69\xdd\x2a\x8d\xc6\x86\x33\xd0\x62\x2c\xe1\xe5\x84\xa6\x8f\xf6\xc4\xa4\xa8\x77\x33\xcf\xc0\x7e\xf6\x26\xa2\x33\x27\x62\x4a\x53\x2c\xc1\x02\xa5\x96\xbd\x83\x4d\x85\xf8\x8a\x37\x1c\xf3\xbe\x12\x4f\xf7\x66\xef\xb7\x3b\xf3\x38\x73\xae\x96\x15\x6d\x96\xa5\xe3\x1d\x49\xe3\x19\x9d\x8e\x71\x23\x28\x7f\xf2\x6e\xc9\x93\xa6\x66\x16\x31\x43\x24\xf2\x8b\x90\xe0\xef\x2e\x4b\x47\x25\x9f\xcb\xb4\xce\x64\x79\xde\xd1\x2b\xdf\x6e\xa6\x66\x9c\x8b\xd5\x2a\x58\x2b\x5b\xeb\x3c\x33\x74\x89\x89\x4e\x09\xc3\x5d\x93\xe5\xd6\x5b\xa6\x06\x9f\x12\x54\xbc\x4e\xcf\x5e\x4b\x06\x10\xe9\xd3\x2b\x09\x96\xe0\x4e\xe9\x00\x89\x63\x20\xdc\x8a\xd4\x94\x7a\x67\x4a\xb6\x1d\x60\x3d\x25\x57\x14\x33\x82\x14\x04\x8a\xb1\x0d\xd2\xd4\x05\x90\xf2\x42\x82\x7d\x8a\xc0\xf3\x38\x50\x39\xd5\xb1\x12\x24\xb8\xe8\xdc\xec\xb7\x20\x0c\x39\x1e\xbd\x40\x20\x3a\x17\x38\x0c\x04\x17\x98\x21\xa6\xbe\x5f\x52\x40\x3e\xed\xa6\x59\x21\x6d\x4f\x39\x73\x97\xd7\xc1\xe4\x17\xa3\x34\x82\x7b\x3b\x3f\x36\x97\x87\x5c\xf1\x34\xae\xe1\x55\xf7\x5b\x01\xf4\xfa\x0b\x85\x62\x97\x13\x96\x13\xed\xf0\x68\x93\xe4\x46\x4d\x2d\x32\xcb\xe6\x99\xd9\x64\xd1\xe7\x5b\xc7\xf6\x1d\x41\xaa\x36\x6e\x22\x35\x68\xde\x85\x61\x18\xc4\x15\x04\xb3\xd0\xa1\x2a\x08\x2e\x86\x12\x11\xa7\x38\xcb\x2e\xf2\xf5\x3e\xd8\x26\xf3\x74\xba\x1e\x27\xc4\xb9\x07\xec\xe9\x9e\xc6\x1e\xaf\xad\xd3\x03\xbb\x95\x74\xda\x42\xb9\x72\xef\xf8\x36\x63\xd7\x7a\xab\xf1\x1e\xaa\x40\x8c\x3d\x45\x97\x5b\x10\x09\xae\x21\x88\x45\xc6\x51\xd6\x68\xe5\x90\xf8\x30\x71\xdf\xaf\x06\x4f\x86\x5b\xda\x53\xda\xbd\xd4\xe1\x3a\x0d\xbd\xed\xe2\xdb\x5e\x85\xc5\x43\xaa\x9e\xad\xb9\x2b\x35\x58\xcf\xa3\x07\x42\xa2\x46\xa0\x47\x54\x5b\x68\xb4\xc2\x42\x36\xab\x3d\xb4\x15\x70\x27\x24\xf6\x9c\x3a\xc2\x11\x33\xc3\x69\x85\x6e\xbd\xd8\x1b\xa0\xb8\xba\x1e\x1e\xce\xb0\xb5\xe3\xb1\x13\xa0\x08\x53\xdd\xe6\x2b\x96\xb9\x31\x4e\xf5\x44\x60\x5e\xef\x92\xe8\x4d\x24\x4d\x91\xf7\x49\xb5\xd6\x91\x1a\xd8\x95\xbd\xaf\x8d\xb4\xf2\xcd\x06\xb9\x06\x71\xe5\x32\xf4\xb2\x47\x40\x23\x60\xa1\x6f\xa1\x34\xcd\x3f\xd9\x05\x50\x33\xed\x99\x70\x71\xe5\xd6\xa2\x9f\x92\x11\xd7\x74\xae\x7c\x05\xf9\x29\xd5\x9a\xb5\x44\x2e\x3a\x31\xc4\x8f\x93\x5b\x89\xa3\x2e\xc5\xc7\x42\xa5\x12\x14\xe8\x75\x64\x82\xc2\xd9\xc4\xc7\xab\xa1\x10\x58\xe2\xd0\xa6\x3e\x5d\x6e\xe0\x2d\x5a\x30\xa5\x9d\x3d\x1d\x3b\x56\xdd\xe8\x99\x5d\x8d\x5e\x71\x40\x5e\xf1\xca\x5c\xe3\xd9\xc5\x33\x95\xe2\x12\xd8\xe9\x0d\xf7\xd2\x67\x94\xbb\xf6\xcd\xda\x7a\x87\x28\x1e\x1d\xcf\x94\xba\xb6\x97\xc9\x79\x8f\x71\x0c\x40\x0e\x73\x4f\x70\x08\xfa\xac\x61\x5e\x56\xf1\x69\xd0\x6a\x9d\xa8\xea\xc4\x55\xc4\x08\x56\x59\xc3\x9f\x58\xad\xd1\x59\x95\x72\x5a\xda\xc4\xa5\x88\x09\x90\x0e\x70\x35\xb0\x84\x30\xd1\xde\xb0\xc5\x94\xeb\x53\xa3\xde\x62\xe9\x5c\x1c\xff\xe9\x88\x48\xf0\x41\x49\x4c\x1c\xc9\x61\xff\x5e\x73\x4c\x09\x54\xb9\xcf\x6d\x43\xcf\x16\x35\x69\x68\x4a\x6b\x33\xbd\xca\xdd\x27\x4d\x35\xe2\xb6\xed\x78\x9f\xec\x91\x84\x99\xfb\xa1\x8f\xd8\x92\xb7\x54\xeb\x59\xb3\x7b\x81\xae\xde\x30\xae\x27\xc0\xd7\xad\x40\x72\x9c\xf3\x4d\x17\x36\x86\x94\xe6\x78\xeb\x04\xd5\xd5\x28\xe7\x98\x37\xce\x33\x86\x5c\x32\xa0\xdd\xac\xee\x6f\x43\x65\xdb\x2d\xe8\x64\xca\x9d\x67\x73\xf1\x3e\x16\xe6\x05\x61\x59\xbe\xac\xb5\xd4\x6d\x55\x41\x48\x43\xbe\x09\x1a\x3f\xe1\x5c\x1f\xe4\x2e\x9e\xd1\x40\xc5\x56\xd4\x1c\x9f\x99\x74\xcd\x16\x17\x49\xe7\x0b\x2d\xde\x8b\x0b\xc9\x02\xf0\x10\x2d\x12\x7d\x1a\xc9\xcc\xd5\x17\xb1\x3f\x15\x90\x60\xa8\x9d\xb1\x46\x45\x95\x54\x2e\xce\x0a\x57\xdf\xf0\x85\x10\x42\xf3\x7a\xee\xf4\x91\xa6\x29\x6a\x7f\xc4\x05\xe3\x42\xbd\x05\x97\xdb\x62\xe2\xb0\xc8\xa7\x34\xd4\x98\xfa\x9d\x4f\x88\x42\x6d\x54\x99\x7d\x5e\x1b\xd2\xe8\xf0\xaa\x4d\x25\x5a\x64\x79\xbb\x23\xad\x6b\x40\x7b\x8f\xf4\xc9\x6b\x64\xf0\x50\x8c\xdb\x29\x36\x2e\xdd\xfd\xa1\xdd\x3b\xca\xeb\x9c\xb3\xe3\x43\x5b\x79\xe7\x10\x9e\xe8\x4e\xf4\x4c\xaf\x49\xcd\xe8\x3a\x75\xae\x1e\x99\x3d\x00\xc3\x9a\xe9\x94\x7e\x91\x48\x17\xaa\xa4\x48\x7b\xc0\x9d\x71\xb1\x0c\x1b\x64\x5b\xc5\xd8\x20\xc8\x73\x17\xad\xc5\x30\xf4\x84\xdf\xa4\x2b\xcc\xc8\x3c\xa6\xc4\xeb\x78\x13\x28\xd1\x89\x5c\x40\x93\xeb\x51\x96\x66\x04\x9e\x98\xbb\x90\x70\x52\xbc\x90\xb2\x29\xee\x0a\x5c\x3f\x98\xf1\xb4\x1b\x80\x64\x6d\x75\xff\x74\x1c\x9a\x70\x9f\x27\x28\xd1\xa5\x3e\xc8\x77\xb9\xcd\x18\xe4\xb4\x66\xc9\x9c\xc6\x60\x72\x26\x6f\xa7\xbe\x9e\x4c\x1f\xbd\x2b\x26\x7c\x37\x6e\x4e\xd4\x51\x34\x5b\x03\xae\x5a\x86\x56\xde\xf9\xcc\xd9\x11\xdb\x5b\x96\xaf\xfd\x65\x82\x46\x45\xa1\xa3\x45\xbe\x67\x34\x92\x0b\x40\x52\xd1\x62\x7f\x0d\x03\x3a\xdd\xc8\x93\xd1\x4f\x84\x27\xab\x2d\x3e\x8e\xec\x43\xef\x92\xb3\x0a\x70\x4b\x71\x5f\x81\xca\x0e\xdb\xa4\xb4\x78\xa3\x14\x3c\x94\x89\x9c\x8d\x33\x9e\x6d\xd2\x9e\x88\xc3\x8f\x69\x7b\x08\x16\x6a\xf3\x58\xa8\x74\x8f\x89\xd2\x51\x4d\x46\xf5\x2c\x77\x42\x93\x02\x6f\xc3\x0d\x4f\xc6\x4a\x29\x17\xb5\xe5\x25\xec\xca\xc7\xb5\xd4\xf8\xf2\xf6\x18\xcc\x50\x7b\xe6\x3a\xb4\x54\x63\x79\xd5\x83\x6e\x1b\x55\x06\x9a\x2e\xa5\x74\x8f\x70\xe1\xba\x87\xa5\xcf\x68\x06\x6e\xc2\x58\x9a\xca\x83\x4c\x9c\x3c\xbd\xa8\x7a\x41\xd7\xa0\xfa\xe9\x22\xd9\xa0\x0d\xee\xe9\xcc\x5a\x49\xa0\x68\xab\x72\x2d\x1b\x99\xa6\x23\x11\xbc\x4a\x0e\x4f\xc0\xdb\x6a\x27\x98\x8f\xee\x69\xd6\x94\x36\x7b\xda\x33\x32\x3f\xa7\x17\x99\x75\x6f\xfd\x32\xd5\x66\x34\x25\xc2\x15\x79\x10\x1e\xc8\x31\xc7\x49\xa7\xd4\x9e\x05\x4b\xa0\x12\x85\x47\xa7\x0e\x8c\x79\xc0\xa4\x14\xfe\xb2\x59\x23\x10\x77\x27\x87\x8b\xf3\x99\x86\xa9\x52\xbd\x3b\x4b\xc0\x39\x86\x77\x9f\x47\x12\x5d\x0e\xef\xdd\x7d\xee\xa6\x72\x0c\x56\x76\x77\xc9\x14\x06\xee\xa2\xc9\x76\xae\x6f\x67\xab\xe5\x6a\x84\x0c\x33\x5c\xa6\xb6\x6e\x43\xb9\xc2\x59\x5d\xe3\x04\x96\x9e\xf1\x39\x22\x1f\xa7\x90\x96\x67\xb0\x00\x94\x63\xe7\xba\x9e\x67\xd1\xa8\x4a\xdb\x0e\x03\x0f\x4c\x4f\xbe\x82\x93\x8c\x43\xcd\x30\x19\x89\x57\x72\x56\xad\x76\x7f\x1c\x5b\x77\x4d\x2c\x4a\x40\x5a\x39\x8b\x76\x1d\x6f\x52\x16\x15\x69\x29\x75\xbe\x43\x4e\x17\x4a\xc7\x79\xd1\x24\x89\x29\xcb\x1f\xc1\xc4\x3b\x0e\x1a\x50\x7e\xa8\xe5\xe7\xc2\x5d\xe7\xec\x0a\x70\x64\x04\x2f\x3c\xab\x6e\x8d\x83\xd3\xa9\x5c\xc8\xbc\x0d\xe3\x97\x04\x88\x6c\x74\x29\x87\xe6\xb6\x0d\x49\x62\xdf\x48\xbc\x1a\x41\xc9\xaa\x49\xcd\xb3\x9e\xde\x0e\xdf\xe3\x27\x61\xcb\x55\xb7\x6d\x00\x64\x20\x8c\xa7\x41\x53\xbd\xf5\xb6\x4b\xd4\x90\x7f\x9c\x41\x9f\xf7\x40\x45\x11\xc1\x6a\xf8\x48\x9d\x2e\x97\xc1\x45\x82\x94\xf0\xd5\xd6\x3c\xab\x0e\xb6\x3f\x2c\x73\x1a\x78\x02\x09\xae\x3b\x71\xc7\xe3\xb2\x64\x23\x66\x2a\xe7\xa5\x08\x5a\xf9\x38\xe0\x44\x1c\xa7\x91\xfd\xdd\x0d\xdc\xde\x11\xba\x63\xf3\x6c\x95\x65\x9f\x49\xec\x7a\x13\x11\x37\xf1\xfa\x55\xe5\xc5\x31\xe3\x94\x74\x98\x18\xde\x49\xad\x20\x5a\x1d\x33\x6e\xba\x69\xbc\xec\x37\x98\xe9\xa6\xd8\x0e\xe4\x41\x91\xc2\xf1\xe9\x35\x94\x86\xca\x4f\x56\xe2\x0a\x82\xb2\xdb\x39\xe5\xe9\x00\x1a\x30\xba\xde\x6b\x95\x02\x44\xdb\x6b\x90\xda\x94\x39\x70\x09\x98\xbc\xb8\x5e\xf9\x73\x79\x51\x81\xed\x2c\xd7\x44\xbd\x1e\x47\x8b\x8b\x4a\x49\x22\xbf\x1b\xa1\x24\x99\x46\x58\xea\x3d\x4c\x62\x17\xec\x71\x29\xd3\xdc\xcb\x4a\x47\x5e\xce\x35\xa8\xe8\x6c\x4b\x92\xf2\x53\xc9\x0c\x5e\xed\xa5\x45\x54\x66\xe7\xbc\x0d\xa9\x6b\xb6\xd1\x79\x9b\xd3\x33\xd7\x52\xde\x50\xb4\x11\xc3\x70\xc5\xe8\x96\xbc\x0a\xbb\xd5\x68\x76\x77\x54\xb0\x8d\x7e\xaf\xc5\x7d\x58\x9a\x65\x20\x22\xa4\x49\xad\x69\xc7\x3c\xab\x30\x49\xf5\x76\x75\x89\xa2\xe5\xce\x8c\x2f\x95\x4b\xcc\xfa\xb5\x8f\x44\xb7\x16\x9b\x36\xa4\xba\x20\x8a\xe1\x42\x24\x9e\x89\xfd\xd4\x5d\x33\xe0\xd8\xbe\xaa\xde\xa7\xba\x9a\x89\xd3\x04\xd9\x3b\x24\xf2\xee\xd8\x4e\xfb\x44\xcc\x79\x91\x6d\x09\xc9\x22\x4f\x63\x35\x20\x4a\xe1\x38\x15\xd6\xae\xfc\x50\x62\xfc\xe5\x3c\x39\xf4\x39\x0d\x29\x42\x45\xa3\x3b\xae\x28\xd8\xa3\x00\xea\x01\x0d\x93\x7e\xf7\xdc\x87\x2e\xe6\x0c\x27\x1a\x9d\xef\x6e\xa5\x33\x73\xa7\x26\x20\x6f\xfc\x72\xf5\x3c\x59\x11\x2e\x81\xe6\x4d\x41\x26\x08\x81\x5c\xc0\x6d\x27\xbb\x88\x63\xb3\x10\x6c\x52\x02\xf5\x90\xc7\x55\xf4\x2b\x0b\x3e\xdf\x4f\x3d\xe2\x74\x23\xde\x74\x73\xdd\x2f\xfb\x80\x7a\x55\xe7\xcb\x44\x2e\xa4\xc6\x9d\xac\x30\x74\x07\xfd\x9d\xd4\xe7\x18\xcd\xfb\x81\xc6\xf2\x50\xf4\x31\x99\x4d\xe6\xc5\x25\xf0\xd9\x67\x21\x91\xda\x26\x1c\xe9\x46\x36\xa0\x16\xef\x29\xde\xc4\x01\x8c\x87\xc9\xe6\x5a\x61\x51\xfc\x26\x97\xa6\xfc\x01\xb0\xd2\xe4\xee\xc4\x0d\xa5\x2a\x86\x05\x94\xa2\x6a\x35\xb8\x42\x0c\xe1\x3a\xb4\x93\xb6\x65\x2b\x87\xf9\x31\x11\x4e\x97\x63\xf3\x7b\x1e\x21\xe0\xf3\x68\xb5\x91\xeb\xed\xdc\x3d\x8d\xe2\x9a\x6f\xe5\xb1\x37\xef\x32\x2c\xa1\x24\x3c\xcb\xc3\x3d\x64\x4b\x03\xaf\xe9\xe7\xce\x57\x80\x69\x09\x46\x16\x90\x1d\xa2\x9d\x64\x6f\x00\x6b\x96\xf5\xd9\x4e\xb9\x17\xa3\x9e\x30\x46\x44\x32\xb1\xdc\xf6\xc7\x8d\xaf\x2e\x22\x54\x95\x98\x8a\xce\x46\xfc\x7c\x53\xb7\xad\x17\xf2\xf5\x92\xdc\xaf\x02\xbb\x67\x80\x63\xc4\xa3\x28\xf3\x0b\x5d\xc7\xb5\xc6\x1b\x5e\xc9\xe2\x1c\x85\x8b\xd3\x09\x45\xa1\xa2\x56\x59\xaf\x7f\x9a\x58\xc3\xa4\x4a\x49\x96\x66\x38\x6b\x57\x36\xd5\x8e\xbb\x87\xe7\x86\x7c\x84\x65\x5d\xb1\x47\x13\xb2\xdf\x2f\x68\x2b\x06\x31\xa4\x4d\x0f\x29\x18\xa0\x93\xca\x64\xe7\xe7\x13\x4c\x7b\x38\xf0\x47\x77\x0d\xab\xa5\xa8\x1f\xee\xf9\x89\xa1\xb1\x36\x3e\xe9\x7b\x80\x35\x21\xed\x97\xe9\x69\xcf\xbb\x76\xbc\x85\xad\xd8\xd4\x6a\x95\x63\x1d\x30\x10\xfa\x71\x73\x4c\x7d\xf6\x8e\xc8\xc7\xb8\x65\xc6\x44\xee\x4a\x82\x0b\x75\x00\x0e\x65\x92\x3f\xd3\x56\x60\xc7\xc1\xda\x92\xd8\xa4\xd6\x22\x60\xeb\x6e\x48\xd0\x0b\xb1\xe8\xfe\x59\x6e\x4f\xd0\xb5\xd6\xaa\xe4\x0a\x34\x90\x23\x63\xf7\xe6\x20\x85\xc4\x12\xa9\xfc\x09\x51\x29\x23\x02\x97\x6e\xa5\x74\xf0\x6a\xd8\x15\x6b\x0e\x0f\xea\x58\xde\x53\x07\x89\x67\xc8\x6d\x63\x37\x40\xb0\x41\x79\x22\x93\xba\x6b\x43\x61\x5c\x78\xc2\xf3\xc3\x48\x71\x7a\x49\x46\x19\x66\xd2\x04\x50\x20\x64\xe5\x39\xab\xc5\xe9\xbc\xae\x97\x5c\x6f\x94\x62\x1a\x22\x54\xd1\xba\x4e\x8b\x0d\xe2\x6c\x50\x4b\xad\xb0\xf3\xa4\x22\x50\x66\xb5\x48\xe5\x40\x27\xcb\xa2\x3c\x87\x2d\x5c\x56\x79\x40\x92\x52\xc6\x6b\x17\xdf\x0a\x3b\x59\x6c\x61\xb4\x64\xf7\x62\xf6\x9b\x5e\x89\x57\x29\x3f\x8d\x02\xc7\x78\xa1\xfc\x60\x97\x55\x1e\xce\xd6\xfd\x79\x7a\x56\x87\x69\xf5\x48\xe2\x5a\x47\xdd\x86\xf8\x2c\x57\x2d\x2c\x75\x91\x75\xe9\x4d\x1a\x2a\xa2\x33\xca\x59\x90\xe2\x50\x1d\x63\xe9\xa6\x28\x6f\xd2\x45\xb2\x8d\x5a\x69\x5b\x28\xa9\xb8\xc2\x48\x16\x03\xe2\x4b\xf3\x62\x9d\xc8\x01\x63\x61\x5f\x5f\x95\x82\x9e\x41\xa7\x73\x8d\xe0\x31\x0f\xb1\xce\x0e\x26\x61\xf2\x51\x2a\xb0\x27\xde\x4f\xd5\xd8\x28\x58\x17\x35\x2c\xdd\x6a\xcf\x3e\xe4\xf6\xfe\x71\x92\x84\x6d\xab\x3c\xf7\x62\x41\xdd\xfa\x3e\xf6\x3a\xd0\x7a\x5a\x50\xa5\xc9\xba\xb0\x9c\x74\x93\xf3\x28\xb8\x54\x09\x30\x3a\x41\x32\xd6\xa9\xb6\xec\x28\x61\x29\x38\x17\x9a\x0b\x14\x36\x26\xfb\x62\x2b\x11\x3c\x57\xfb\xb3\x6f\xf5\x59\xfb\xe4\x7b\xb7\x70\x42\x23\x3d\xae\x79\x8d\x7a\x25\x26\x22\xe4\xe0\xb4\x10\x29\x40\xbd\x2a\x6a\x60\x9b\xd6\xd9\xb1\xfb\x93\x52\xa1\x95\x68\x91\xc7\xfa\x33\x8e\x32\x35\x75\xa6\x05\x58\xe1\xec\x10\xad\xa9\x3f\xd5\x46\xb8\xa6\x67\xab\xb2\x8e\x3b\xee\x14\x63\xf4\x50\xcf\x93\x4c\x37\x61\x53\x8d\x17\x7d\x51\xda\xab\x70\x5b\x0b\x56\x65\x95\x05\x49\x6f\x3a\x47\x0a\xc0\x3c\x2e\x1e\xae\xe2\xa8\x95\x0a\x97\x39\xac\x65\xe1\x66\x82\x7d\x13\xf9\x2a\x2d\x68\x97\xa4\xc5\x4a\x0a\x63\x9c\x33\xbe\x5e\x2a\x8b\x9e\x31\x9d\x14\xc7\x27\x84\xc2\x8a\x7c\xb7\xfa\x95\x8b\xcf\x98\x43\x34\x52\xbd\xdd\x49\x9d\x79\x0c\xce\xe6\x84\x4d\x28\x58\x12\xfd\x64\x61\x33\x1c\x35\x09\x72\x19\xda\x50\x5a\xad\x3f\xf7\x27\xff\xfa\xc8\x76\x37\xd0\x2f\x2d\x1d\x18\x55\xb3\x3a\xce\xc3\x27\x10\x89\x1f\x36\x87\xe8\xee\x86\xb0\x45\x1e\xdf\x29\x51\x23\x23\xf1\x12\xee\xf8\x89\x52\x4f\x4d\xd5\x24\x3e\x57\x1c\xc7\x7b\xfb\x74\xae\x15\x38\xbf\x81\x45\x8c\x9d\xa4\xee\xac\xa9\x6c\x4f\x44\xb3\xa7\xb8\x10\xb3\x47\xe8\xc5\x73\xe0\xf4\x36\xa7\xc7\x09\x0f\x26\xb0\x0a\x4f\xd1\x93\x28\xc4\x0c\x1c\xd1\x0e\x77\xda\xed\x9d\x49\xb5\x94\x8b\x17\x43\x91\x7d\x66\xc5\x57\x45\x2a\xa4\x41\x2c\x3b\xc5\xad\xba\x70\x20\x3c\x9b\xba\xee\xab\xae\x2c\xde\x82\x86\xcd\xd3\xad\x9d\x2a\x4c\x30\xa9\x50\xac\xaa\x03\x41\x9c\x02\xc0\xb0\xc2\x91\xe8\x44\x3a\x86\x04\x81\x35\x79\x3f\x4e\x2e\x27\xf3\x7c\x2c\xb0\xab\xc1\x32\xb2\x0a\x2c\x0b\x09\x2c\x5d\xe0\xc2\xd3\x74\x99\x16\x71\xd8\x57\xce\x17\xed\xca\x4e\x9e\x0d\xff\xd0\x9e\xcd\x80\xe3\x36\x7c\xac\xd6\x7e\x1f\x1c\x77\xcc\x16\xba\xc1\x24\xd5\xaf\xe5\x9d\x39\xaf\x33\x77\x8e\x74\x8f\x73\x22\x86\x48\xbd\x5a\x83\x32\xc7\xe3\x2a\xda\xa6\xef\xae\x11\x55\x46\xc4\xa6\xa7\xfc\xc1\xdd\x85\xf3\xba\xd8\x96\x71\xe2\xf2\x88\xc9\xa1\x99\x6d\x5b\xc9\xdb\x84\x51\x60\x4d\x22\x43\x78\x99\x56\x78\x63\x16\xd0\xd6\x36\x32\xe6\xae\x95\xb9\xc8\x49\xd0\x88\x80\xb4\x63\xad\x46\xce\x8d\x12\xa2\x60\x12\x9d\x3d\xcd\x87\x72\x2b\xb2\x05\xc9\x50\xd0\xa9\x18\x78\x34\xb3\x07\x67\x61\xc5\x75\x53\x29\x6f\x77\x1e\x02\x47\x63\xab\xc1\xd8\x57\x1b\x83\xd8\x04\x10\xe9\xfe\xd8\x04\xfb\xb6\x0c\x8d\x3c\xa5\x68\xca\xc6\x10\x81\x9c\x4f\x0d\x75\x7b\xf8\xa0\xf6\xa0\x7b\x2c\x58\x0e\x8f\xf4\xd6\x3e\xae\xcb\x99\xe3\x9e\x55\xe6\xa6\xe7\x00\x80\x55\x23\x76\x17\xea\xaa\x66\x43\x74\xc6\x55\x14\x3d\x16\xb7\xb6\x17\xa6\x89\x78\xcf\xd2\xf1\x95\x70\x2b\xad\xd9\x38\x96\xe1\x7f\xfc\xf3\x6b\x02\x72\xff\x52\x34\x71\xb2\xbd\xbe\xa0\x10\xf4\x35\xdf\x3a\x24\xe3\x5c\x4d\xe3\x8f\x79\xfd\xd7\x17\x02\xed\xb6\xf7\x4c\xf2\x0b\x41\x1c\x7f\xbe\xe5\x67\x3f\xe9\x7f\x9d\x82\xb0\x4a\xbe\xac\x43\xd0\x7d\x97\x60\xfe\x4c\x98\x62\x6f\xd9\xf3\xdf\x7e\x2c\x8c\x3c\x5f\xbf\xcb\x1d\xff\x49\xb9\xe4\x07\xf1\xef\xd2\x5f\xa6\xcf\x3c\xfb\x41\x3d\x15\x51\x50\x7d\xa6\x7c\xeb\x83\xa5\x4a\xfe\xd7\xe4\xfe\x0f\x2e\x21\x6f\xce\x74\x3f\x16\x0d\x0e\x86\x2a\xe8\xc6\xe4\xf5\xe5\xf3\xed\xfb\xee\x1f\xea\x09\x51\x14\xfd\xb9\x89\xff\x7a\xf9\xb9\x29\xff\xb0\xfa\x8f\x49\xea\x8f\x44\x73\x5e\x4c\xc9\x97\xb1\x0b\xa2\x43\x71\xd3\xbe\x61\xf8\x26\xfa\xaf\xc4\x7c\x1b\x94\xaf\x83\xf0\x33\xdd\xf0\xf2\x3f\x87\x19\x7f\x52\x9d\xf8\xcc\xb1\x7f\x24\xa9\x61\xfc\x6f\xf9\x9b\x29\xff\xca\xfd\x5f\xc8\x3f\x3f\x04\x54\x45\x93\x7c\x2b\x0a\xfd\x9f\x78\xd1\x7f\xfe\xa4\xfc\x6b\x82\x1f\x26\x7e\xae\x40\x7d\x1a\xf5\xf7\x4e\x7d\x2f\xf8\x35\xa9\xbb\xe9\xf9\x53\x51\xa0\x69\x9b\xe4\x87\x71\x39\x22\xfc\x5b\x9d\xea\xfb\xa2\x07\xf2\x37\x45\x8f\x9f\xaa\x1b\x6f\xe2\xa2\xbc\x29\x7f\x2e\x40\x14\xcd\x3b\x22\xdf\xd5\x21\x3e\x44\x7d\x93\xfd\x89\x16\xf2\x87\xd2\x09\xfa\x7f\x2a\x66\xfd\x31\x98\xf1\x28\x3c\xe1\xd1\x5f\xc6\x25\x16\x05\x09\xf6\x1e\x9a\xef\x26\xff\x7a\xe0\xfb\x61\xf6\x9f\xc8\x8a\x12\xec\x84\x61\x3f\x4f\x81\xf7\xae\x00\xc5\x08\x0c\xf9\x26\xe7\x7b\xb0\xdb\x23\x54\x8b\xe9\xf9\xfa\xf2\xb1\x68\x34\xc1\x12\x06\xc3\x97\xb4\x38\x4e\x3d\x6f\xa5\xa8\x97\x5f\xc3\xa9\xf9\xd3\x92\xd8\xf7\x00\x90\x1f\x4b\xca\x57\x19\xe1\xf8\xe5\x98\xd0\x55\x3b\x4f\x3f\x87\xf9\x1f\xa1\x83\xfe\xd2\xfb\x24\xf9\x61\xc6\xbe\x47\xdb\xe7\x88\xe3\x3f\x4d\xf6\xcf\x42\x15\xfa\x47\x13\xbe\xc4\x41\x93\xfd\x1e\x35\xdf\xcb\xfa\x19\xb9\x7f\xff\x19\x5b\x8e\xfd\x58\xe2\xfb\x0b\xea\xa2\x49\xdb\xbf\x51\x01\x87\x27\x88\x4a\xfe\x94\xe9\x0f\x0a\xbe\xd1\xfe\xff\x00\x00\x00\xff\xff\x43\x98\xda\xbc\x8a\x1e\x00\x00"
-
-func pub_styles_main_css_bytes() ([]byte, error) {
-	return bindata_read(
-		_pub_styles_main_css,
-		"pub/styles/main.css",
-	)
-}
-
-func pub_styles_main_css() (*asset, error) {
-	bytes, err := pub_styles_main_css_bytes()
-	if err != nil {
-		return nil, err
-	}
-
-	info := bindata_file_info{name: "pub/styles/main.css", size: 7818, mode: os.FileMode(420), modTime: time.Unix(1422215906, 0)}
-	a := &asset{bytes: bytes, info: info}
-	return a, nil
-}
-
-var _pub_styles_main_min_css = "\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x84\x79\x67\x8f\xa4\xc8\x96\xf6\x5f\x69\x69\xb5\xda\xbd\xe2\xf6\xe0\x21\xa9\x96\x56\xc2\x27\x2e\x49\x20\x31\xc9\x37\x3c\x24\xde\x9b\xd6\xfc\xf7\x97\xea\xa9\xee\xe9\xb9\x3d\xa3\x97\x52\x55\x05\x11\xcf\x39\x71\x4c\x10\xe6\x89\xb0\x8d\xf7\x7f\xe7\x53\x5d\x7d\x6d\x97\x64\x48\xab\x76\xfd\xbc\xbd\xe5\x45\x1c\x27\xcd\x97\xba\x68\x3e\xaf\x45\x3c\xe5\x6f\x30\x04\xfd\xf7\xef\xdf\x50\x79\x52\x64\xf9\xf4\x47\xc5\xbb\xec\xd7\xae\x1d\x8b\xa9\x68\x9b\xb7\x21\xa9\x82\xa9\x58\x92\x2f\x5d\x10\xc7\x45\x93\x7d\x9e\xda\xee\x8d\x84\xba\xed\x47\x45\xd8\x4e\x53\x5b\xbf\x11\xef\x75\xef\xba\xff\x53\xd7\xff\xfd\x16\xb5\xcd\x14\x14\x4d\x32\x7c\xfd\x0f\x19\xfc\x94\xf9\x3d\x6d\xdb\xe9\xbd\xe9\x7b\x8f\x41\x38\xb6\xd5\x3c\x25\x5f\x3e\x40\xd0\x97\x3f\xad\xfd\xf2\xa1\xfc\x5b\x67\x61\xbb\x7d\x1e\x8b\xe3\xd4\xf7\x16\xb6\x43\x9c\x0c\xa7\xda\xf7\xda\x6f\xc5\x77\x33\xe1\x6e\xfb\x74\xea\x2a\xe2\x4f\xff\x95\xe2\xef\x3f\x5f\xc2\x20\x2a\xb3\xa1\x9d\x9b\xf8\x73\xd4\x56\xed\xf0\xf6\xd1\xf0\x61\xc4\xa7\xee\x6b\x1d\x0c\x59\xd1\xbc\xc1\x97\x53\x16\xfa\x51\xfd\x96\x16\xc3\x38\x7d\x8e\xf2\xa2\x8a\xbf\x9e\xe1\x0c\xa6\xb7\xe1\xdd\x90\xbf\x05\x7c\x0a\xbe\xfe\xa1\xbc\x68\xf2\x64\x28\xa6\xdf\x73\xe4\x43\xed\x77\xbf\xe1\x77\xbf\x73\xe4\xb7\xa8\x3a\x9d\x6e\xb2\xef\xad\xef\x36\x43\x5f\xfe\x0a\x45\xde\xa1\xff\x95\xc4\xc5\xd4\x0e\x27\x6e\xfb\x48\x1d\x01\xbd\x47\xe0\xa7\xc0\xfc\x14\x79\xf2\x5b\xdb\x87\x23\xd0\xa7\x60\x9e\xda\xef\x51\x19\x82\xb8\x98\xc7\x37\xec\xd4\xf9\x5b\xd6\x76\xf9\xcf\x71\xff\x25\xd3\x55\x92\x4e\x6f\x18\xf4\x27\xf6\xd3\x58\x07\x55\xf5\x35\x2e\xc6\xae\x0a\xf6\xb7\xb0\x6a\xa3\xf2\xcb\x94\x6c\xd3\xe7\xa0\x2a\xb2\xe6\x2d\x4a\x9a\x33\x18\xdf\xd1\x6f\x61\x92\xb6\x43\xf2\xf5\x3d\xfb\x67\xc3\xdb\xff\xfc\xcf\x97\x5f\x73\xfc\xdd\x03\xe2\xb4\xf8\x87\xf9\x67\xf9\x3d\x14\x9f\xbf\x95\xbe\xc5\xf9\xed\xf2\xdf\x3f\xe5\xee\x74\x0a\xfa\xd4\xb4\x9f\x87\xa4\x4b\x82\xe9\xe7\xa4\x16\x75\x90\x25\x6f\xf3\x50\xfd\x6f\x1c\x4c\xc1\xdb\xb7\x57\xb0\x6b\xb2\x13\x33\x26\x04\xf6\xef\xc2\x61\x74\x73\x85\x14\x31\x6b\xe9\xf3\xb9\x59\x76\xce\xdb\xd9\x59\x12\xdf\xdf\x19\x91\xa5\xb5\xf7\xfa\x47\x99\x5e\xdf\x2b\x58\xa0\x62\x34\x87\xb7\xdf\xdb\x7a\x18\xb0\x05\x36\x5b\x0b\x03\xb2\x19\x7a\x35\xde\x71\x2c\x63\xbc\xb7\xfd\xe5\xb9\x5d\x19\xe8\x7b\x79\x70\x9c\xc8\x53\x0b\xec\xfb\xfb\xec\x09\x98\xc8\xd1\xeb\x7b\xd9\x56\xac\x32\x33\x6c\xe9\x7b\x5b\x70\x7b\xd8\x86\xc4\xd2\x12\x6f\x0a\x0b\x96\xd2\x57\x6e\xc5\xcf\x3e\x3d\xf0\xdb\xf3\x4d\x84\x69\xa0\x59\xee\xe1\xd9\xec\xe0\xe4\x56\x43\x3a\x43\x81\x29\x40\x83\x20\x35\x1c\x11\x45\x00\x0b\xd5\x23\x7a\xdc\xc1\xba\xd0\xc2\xfa\x6d\xc1\x12\xaa\x85\x6e\xbd\x70\xb6\xaf\x18\x28\xb7\x07\xe5\xa0\x24\x88\x23\xe4\x92\x03\x27\x16\xbb\x80\x14\x44\xa4\x31\x46\x81\x14\x41\xa5\xd4\x80\xcc\xd5\x84\x26\xf0\x88\x00\xdd\x85\x4a\x73\x98\x00\x39\x75\x31\x6e\xf5\x7e\x01\x51\xa8\x57\x61\x48\xe1\x76\x32\xbb\x82\x60\x3a\x46\xfa\xa3\xda\xf1\xc5\x29\x8f\x09\x6f\xa1\x09\xce\x36\x6d\x1a\x0f\x2d\x3d\xed\xac\xb4\xca\xf7\x99\xec\x29\xb7\x70\x12\x9e\x96\x61\xb1\xb8\x4d\xde\x10\xf2\x54\x8c\x9e\x30\x76\x55\xe5\xe2\xf4\x69\xf3\x42\x68\xf5\x7a\x3c\x93\x90\x50\x44\x94\x08\x59\x65\x76\xdd\x0e\xea\x3e\x90\x54\xea\x67\xa8\x6e\x2e\x28\x20\x27\xcb\xc5\x18\x52\x4c\x0a\x6d\xc1\xe8\xe4\x3d\x44\x29\x43\x94\xbf\xc5\x63\x8c\xb9\x7e\x60\xcc\xb6\x77\xda\x1c\x91\x9f\x16\x14\x82\xe0\xeb\xb4\x6d\xbf\x57\xe8\xea\x8c\xe4\xfd\xde\x22\x71\x3e\xc0\x71\x3c\xef\x21\x55\x2d\x21\xa8\xce\x6a\x3c\xaf\x04\xf0\x48\x9b\xa9\x6f\x50\xb9\x2f\x91\x28\xf5\x10\x39\xb4\x61\x59\xb5\xec\x4e\xb3\x5e\x23\x70\xe7\xc6\xea\xf6\x4a\x83\x9d\x46\x29\x90\x16\xd1\x2e\x13\xca\x3d\x36\xe7\x8d\xcc\xcb\x29\xb4\xa9\xb6\xf7\x85\x02\xc3\xc7\x1e\x8b\x8f\x08\x15\xea\x04\x89\x27\xcf\x99\x8e\x13\xd6\xd8\xc2\xd9\xbf\x2d\x76\xd5\x51\x69\xfa\xeb\x3d\xef\x38\xa7\xb1\x07\x37\xae\xc1\x52\xaf\xce\x81\x27\xaf\xf4\x18\x51\x10\x23\x00\xa0\x27\x5e\x00\x12\x24\x7e\xad\xb8\x48\xdb\x11\xaf\x57\x0b\x2d\x39\x36\x2b\x79\xeb\x0f\x9d\x24\x24\xc5\x93\xef\xe2\x26\xa7\x82\x3a\x83\x7d\xf7\x71\x60\xf5\x19\x52\x07\xdf\x02\x98\x01\x92\x92\xd4\x17\xeb\xa9\x8b\x5b\xef\xa5\xb4\xdc\xe0\xc9\xb0\x62\x80\x10\xc1\x31\xdc\x23\xd3\xf6\x3c\x6e\xce\x7d\x8d\x16\x76\x8d\x1a\x77\xd3\x6e\xd1\x4c\xe2\xca\x34\x0c\x00\x19\xc6\xfe\xa4\xa6\xf0\x8e\xef\x4b\xed\x27\x76\x89\xcb\x71\x25\x21\xe5\x4b\x32\xc1\xb4\x01\xf2\x0a\xd5\x5e\xa1\x27\xba\x09\xc2\x81\x17\xe1\xe9\x59\xe5\xfa\x30\x6c\x06\x7d\x72\x40\x50\x70\xdc\x7d\x37\x14\xfd\x8c\x3c\x42\x26\x5b\xd2\x74\x2d\x5a\xc9\xdd\x38\xfa\x0c\x99\x20\x61\x79\x38\x28\x08\x56\xd2\x6c\xf1\xd9\x71\x7f\xcc\x47\xc3\xb2\x43\xc9\xac\xd4\xcd\xe4\x3d\x9e\x06\x92\x96\xae\xc1\x81\x0e\xfa\xca\x90\x57\x62\x19\x3a\x22\x69\x2c\x88\xf2\x93\xae\xf7\x97\xa5\xbf\xb3\xa3\xe7\xd7\x39\xea\x0c\x71\xcd\xca\x85\xfd\x62\xfa\x5d\x08\x3c\x73\xbe\x46\x46\x51\x77\x84\xcf\x36\xfe\x34\xcf\xf2\x4b\x9e\x3a\xd5\xd9\xb0\xa3\x40\xae\xa4\xb9\x9c\x53\x5e\x21\xec\xf7\xd8\x7a\x65\x98\x7e\x6f\x50\x65\x6a\xa7\xc0\x6c\x46\x19\x35\x97\xee\x95\xef\x5d\xb2\xd4\x98\x02\x75\x5e\x8c\x17\x08\xde\x05\x22\xca\xb7\xd5\xe0\x4f\xf0\x9d\xef\x6c\x17\x9e\x59\x72\x22\xf3\x23\x40\xa6\x7e\x1b\x49\x91\xe8\xb0\x47\x73\x70\x80\x0b\x82\x80\x73\xfe\x9a\xa7\xaf\x4b\xa5\x03\xc7\xfb\x97\x77\x6d\x4c\xeb\x51\x69\x34\x36\x5c\x81\x16\x63\x09\x2f\x27\x34\x7d\xb4\x27\x26\x45\xbd\x87\x79\x05\x8e\xab\x37\x11\x9d\x39\x11\x53\x9a\x62\x09\x16\x28\xb5\xec\x9d\x62\x2a\xc4\x57\xbc\xe1\x98\xcf\x95\xd8\xdd\x87\x7d\x3c\x9e\xcc\xeb\xca\xb9\x5a\x56\xb4\x59\x96\x8e\x4f\x24\x8d\x67\x74\x3a\xf3\x46\x50\xfe\xe4\x3d\x92\x9d\xa6\x66\x16\x31\x43\x24\xf2\x8b\x90\xe0\x9f\x2e\x4b\x47\x25\x9f\xcb\xb4\xce\x64\x79\xde\xd1\x2b\xdf\x6e\xa6\x66\x5c\x8b\xd5\x2a\x58\x2b\x5b\xeb\x3c\x33\x74\x89\x89\x2e\x09\xc3\xdd\x93\xe5\xd1\x5b\xa6\x06\x5f\x12\x54\xbc\x4f\x7b\xaf\x25\x03\x88\xf4\xe9\x9d\x04\x4b\xf0\xa0\x74\x80\xc4\x31\x10\x6e\x45\x6a\x4a\xbd\x2b\x25\xdb\x0e\xb0\x5e\x92\x3b\x8a\x19\x41\x0a\x02\xc5\xd8\x06\x69\xea\x02\x48\x79\x23\xc1\x3e\x45\xe0\x79\x1c\xa8\x9c\xea\x58\x09\x12\x5c\x74\x6e\x8e\x47\x10\x86\x1c\x8f\xde\x20\x10\x9d\x0b\x1c\x06\x82\x1b\xcc\x10\x53\xdf\x2f\x29\x20\x5f\x0e\xd3\xac\x90\xb6\xa7\x9c\xb9\xcb\xeb\x60\xf2\x8b\x51\x1a\xc1\xa3\x9d\x5f\x9b\xcb\x43\xae\x78\x19\xd7\xf0\xae\xfb\xad\x00\x7a\xfd\x8d\x42\xb1\xdb\x05\xcb\x89\x76\x78\xb5\x49\xf2\xa0\xa6\x16\x99\x65\xf3\xca\x6c\xb2\xe8\xf3\xad\x63\xfb\x8e\x20\x55\x1b\x37\x91\x1a\x34\x1f\xc2\x30\x0c\xe2\x0a\x82\x59\xe8\x50\x15\x04\x17\x43\x89\x88\x53\x9c\x65\x37\xf9\xfe\x1c\x6c\x93\xd9\x9d\xae\xc7\x09\x71\xee\x01\x7b\x7a\xa6\xb1\xc7\x6b\xeb\xf4\xc2\x1e\x25\x9d\xb6\x50\xae\x3c\x3b\xbe\xcd\xd8\xb5\xde\x6a\xbc\x87\x2a\x10\x63\x2f\xd1\xed\x11\x44\x82\x6b\x08\x62\x91\x71\x94\x35\x5a\x39\x24\xbe\x4c\xdc\xf7\xab\xc1\x93\xe1\x96\xf6\x94\xf6\x28\x75\xb8\x4e\x43\x6f\xbb\xf9\xb6\x57\x61\xf1\x90\xaa\x57\x6b\xee\x4a\x0d\xd6\xf3\xe8\x85\x90\xa8\x11\xe8\x11\xd5\x16\x1a\xad\xb0\x90\xcd\x6a\x2f\x6d\x05\xdc\x09\x89\x3d\xa7\x8e\x70\xc4\xcc\x70\x5a\xa1\x5b\x2f\xf6\x06\x28\xae\xee\xa7\x87\x33\x6c\x1d\x78\xec\x04\x28\xc2\x54\x8f\xf9\x8e\x65\x6e\x8c\x53\x3d\x11\x98\xf7\xa7\x24\x7a\x13\x49\x53\xe4\x73\x52\xad\x75\xa4\x06\x76\x65\x9f\x6b\x23\xad\x7c\xb3\x41\xae\x41\xdc\xb9\x0c\xbd\x1d\x11\xd0\x08\x58\xe8\x5b\x28\x4d\xf3\x3b\xbb\x00\x6a\xa6\xed\x09\x17\x57\x6e\x2d\xfa\x29\x19\x71\x4d\xe7\xca\x77\x90\x9f\x52\xad\x59\x4b\xe4\xa6\x13\x43\xfc\xba\xb8\x95\x38\xea\x52\x7c\x4e\x54\x2a\x41\x81\x5e\x47\x26\x28\x9c\x4d\x7c\xbc\x1a\x0a\x81\x25\x0e\x6d\xea\xd3\xed\x01\x3e\xa2\x05\x53\xda\xd9\xd3\xb1\x73\xd6\x8d\xf6\xec\x6e\xf4\x8a\x03\xf2\x8a\x57\xe6\x1a\xcf\x2e\x9e\xa9\x14\xb7\xc0\x4e\x1f\xb8\x97\xee\x51\xee\xda\x0f\x6b\xeb\x1d\xa2\x78\x75\x3c\x53\xea\xda\x51\x26\xd7\x23\xc6\x31\x00\x39\xcd\xbd\xc0\x21\xe8\xb3\x86\x79\x5b\xc5\xdd\xa0\xd5\x3a\x51\xd5\x89\xab\x88\x11\xac\xb2\x86\xbf\xb0\x5a\xa3\xb3\x2a\xe5\xb4\xb4\x89\x4b\x11\x13\x20\x1d\xe0\x6a\x60\x09\x61\xa2\xbd\x61\x8b\x29\xd7\x97\x46\x7d\xc4\xd2\xb5\x38\xff\xd3\x11\x91\xe0\x83\x92\x98\x38\x92\xc3\xfe\xb3\xe6\x98\x12\xa8\x72\x9f\xdb\x86\x9e\x2d\x6a\xd2\xd0\x94\xd6\x66\x7a\x95\x7b\x4e\x9a\x6a\xc4\x6d\xdb\xf1\x3e\xd9\x23\x09\x33\xf7\x43\x1f\xb1\x25\x6f\xa9\xd6\x5e\xb3\x47\x81\xae\xde\x30\xae\x17\xc0\xd7\xad\x40\x72\x9c\xeb\x43\x17\x36\x86\x94\xe6\x78\xeb\x04\xd5\xd5\x28\xe7\xfc\x6e\x9c\x3d\x86\x5c\x32\xa0\xdd\xac\xee\x1f\x43\x65\xdb\x2d\xe8\x64\xca\x93\x67\x73\xf1\x39\x16\xe6\x0d\x61\x59\xbe\xac\xb5\xd4\x6d\x55\x41\x48\x43\xbe\x09\x1a\x3f\xe1\x5c\x1f\xe4\x6e\x9e\xd1\x40\xc5\x56\xd4\x1c\x9f\x99\x74\xcd\x16\x37\x49\xe7\x0b\x2d\x3e\x8a\x1b\xc9\x02\xf0\x10\x2d\x12\x7d\x19\xc9\xcc\xd5\x17\xb1\xbf\x14\x90\x60\xa8\x9d\xb1\x46\x45\x95\x54\x2e\xce\x0a\x77\xdf\xf0\x85\x10\x42\xf3\x7a\xee\xf4\x91\xa6\x29\xea\x78\xc5\x05\xe3\x42\xbd\x05\x97\xdb\x62\xe2\xb0\xc8\xa7\x34\xd4\x98\xfa\x93\x4f\x88\x42\x6d\x54\x99\xdd\xef\x0d\x69\x74\x78\xd5\xa6\x12\x2d\xb2\xbc\xdd\x91\xd6\x3d\xa0\xbd\x57\xba\xf3\x1a\x19\xbc\x14\xe3\x71\x89\x8d\x5b\xf7\x7c\x69\xcf\x8e\xf2\x3a\xe7\xea\xf8\xd0\x56\x3e\x39\x84\x27\xba\x0b\x3d\xd3\x6b\x52\x33\xba\x4e\x5d\xab\x57\x66\x0f\xc0\xb0\x66\x3a\xa5\xdf\x24\xd2\x85\x2a\x29\xd2\x5e\x70\x67\xdc\x2c\xc3\x06\xd9\x56\x31\x36\x08\xf2\xdc\x45\x6b\x31\x0c\xbd\xe0\x0f\xe9\x0e\x33\x32\x8f\x29\xf1\x3a\x3e\x04\x4a\x74\x22\x17\xd0\xe4\x7a\x94\xa5\x19\x81\x27\xe6\x29\x24\x9c\x14\x2f\xa4\x6c\x8a\x87\x02\xd7\x2f\x66\xbc\x1c\x06\x20\x59\x5b\xdd\xef\x8e\x43\x13\xee\x7e\x81\x12\x5d\xea\x83\xfc\x90\xdb\x8c\x41\x2e\x6b\x96\xcc\x69\x0c\x26\x57\xf2\x71\xe9\xeb\xc9\xf4\xd1\xa7\x62\xc2\x4f\xe3\xe1\x44\x1d\x45\xb3\x35\xe0\xaa\x65\x68\xe5\x9d\xcf\x5c\x1d\xb1\x7d\x64\xf9\xda\xdf\x26\x68\x54\x14\x3a\x5a\xe4\x67\x46\x23\xb9\x00\x24\x15\x2d\xf6\xf7\x30\xa0\xd3\x8d\xbc\x18\xfd\x44\x78\xb2\xda\xe2\xe3\xc8\xbe\xf4\x2e\xb9\xaa\x00\xb7\x14\xcf\x15\xa8\xec\xb0\x4d\x4a\x8b\x37\x4a\xc1\x43\x99\xc8\xd9\x38\x63\x6f\x93\xf6\x42\x9c\x7e\x4c\xdb\x4b\xb0\x50\x9b\xc7\x42\xa5\x7b\x4d\x94\x8e\x6a\x32\xaa\x67\xb9\x13\x9a\x14\xf8\x18\x1e\x78\x32\x56\x4a\xb9\xa8\x2d\x2f\x61\x77\x3e\xae\xa5\xc6\x97\xb7\xd7\x60\x86\xda\x9e\xeb\xd0\x52\x8d\xe5\x5d\x0f\xba\x6d\x54\x19\x68\xba\x95\xd2\x33\xc2\x85\xfb\x11\x96\x3e\xa3\x19\xb8\x09\x63\x69\x2a\x0f\x32\x71\xf1\xf4\xa2\xea\x05\x5d\x83\xea\xdd\x45\xb2\x41\x1b\xdc\xcb\x95\xb5\x92\x40\xd1\x56\xe5\x5e\x36\x32\x4d\x47\x22\x78\x97\x1c\x9e\x80\xb7\xd5\x4e\x30\x1f\x3d\xd2\xac\x29\x6d\xf6\x72\x64\x64\x7e\x4d\x6f\x32\xeb\x3e\xfa\x65\xaa\xcd\x68\x4a\x84\x3b\xf2\x22\x3c\x90\x63\xce\x9d\x4e\xa9\xed\x05\x4b\xa0\x12\x85\x47\x97\x0e\x8c\x79\xc0\xa4\x14\xfe\xb6\x59\x23\x10\x77\x17\x87\x8b\xf3\x99\x86\xa9\x52\x7d\x3a\x4b\xc0\x39\x86\xf7\x9c\x47\x12\x5d\x4e\xef\xdd\x63\xee\xa6\x72\x0c\x56\xf6\x70\xc9\x14\x06\x9e\xa2\xc9\x76\xae\x6f\x67\xab\xe5\x6a\x84\x0c\x33\x5c\xa6\xb6\x6e\x43\xb9\xc2\x55\x5d\xe3\x04\x96\xf6\xf8\x1a\x91\xaf\x4b\x48\xcb\x33\x58\x00\xca\xb9\x72\xdd\xaf\xb3\x68\x54\xa5\x6d\x87\x81\x07\xa6\x17\x5f\xc1\x49\xc6\xa1\x66\x98\x8c\xc4\x3b\x39\xab\x56\x7b\xbc\xce\xa5\xbb\x26\x16\x25\x20\xad\x9c\x45\xbb\x8e\x37\x29\x8b\x8a\xb4\x94\xba\x3e\x21\xa7\x0b\xa5\x73\xbf\x68\x92\xc4\x94\xe5\xaf\x60\xe2\x1d\x07\x0d\x28\x3f\xd4\xf2\x6b\xe1\xae\x73\x76\x07\x38\x32\x82\x17\x9e\x55\xb7\xc6\xc1\xe9\x54\x2e\x64\xde\x86\xf1\x5b\x02\x44\x36\xba\x94\x43\xf3\xd8\x86\x24\xb1\x1f\x24\x5e\x8d\xa0\x64\xd5\xa4\xe6\x59\xbb\x77\xc0\xcf\x78\x27\x6c\xb9\xea\xb6\x0d\x80\x0c\x84\xf1\x34\x68\xaa\xb7\xde\x76\x89\x1a\xf2\xcf\x3d\xe8\xfe\x0c\x54\x14\x11\xac\x86\x8f\xd4\xe9\x76\x1b\x5c\x24\x48\x09\x5f\x6d\xcd\xab\xea\x60\xc7\xcb\x32\xa7\x81\x27\x90\xe0\x7e\x10\x4f\x3c\x2e\x4b\x36\x62\xa6\x72\x5e\x8a\xa0\x95\xcf\x0d\x4e\xc4\x71\x1a\xd9\x3f\xdd\xc0\xed\x1d\xa1\x3b\x17\xcf\x56\x59\x8e\x99\xc4\xee\x0f\x11\x71\x13\xaf\x5f\x55\x5e\x1c\x33\x4e\x49\x87\x89\xe1\x9d\xd4\x0a\xa2\xd5\x31\xe3\xa6\x9b\xc6\xdb\xf1\x80\x99\x6e\x8a\xed\x40\x1e\x14\x29\x1c\x77\xaf\xa1\x34\x54\xde\x59\x89\x2b\x08\xca\x6e\xe7\x94\xa7\x03\x68\xc0\xe8\xfa\xa8\x55\x0a\x10\x6d\xaf\x41\x6a\x53\xe6\xc0\x25\x60\xf2\xe2\x7e\xe7\xaf\xe5\x4d\x05\xb6\xab\x5c\x13\xf5\x7a\x6e\x2d\x6e\x2a\x25\x89\xfc\x61\x84\x92\x64\x1a\x61\xa9\xf7\x30\x89\xdd\xb0\xd7\xad\x4c\x73\x2f\x2b\x1d\x79\xb9\xd6\xa0\xa2\xb3\x2d\x49\xca\xbb\x92\x19\xbc\xda\x4b\x8b\xa8\xcc\xce\x75\x1b\x52\xd7\x6c\xa3\xeb\x36\xa7\x57\xae\xa5\xbc\xa1\x68\x23\x86\xe1\x8a\xd1\x2d\x79\x15\x76\xab\xd1\xec\x9e\xa8\x60\x1b\xfd\x51\x8b\xc7\xb0\x34\xcb\x40\x44\x48\x93\x5a\xd3\x81\x79\x56\x61\x92\xea\xe3\xee\x12\x45\xcb\x5d\x19\x5f\x2a\x97\x98\xf5\x6b\x1f\x89\x1e\x2d\x36\x6d\x48\x75\x43\x14\xc3\x85\x48\x3c\x13\xfb\xa9\xbb\x67\xc0\xb9\x7c\x55\xbd\x4f\x75\x35\x13\xa7\x09\x72\x74\x48\xe4\x3d\xb1\x83\xf6\x89\x98\xf3\x22\xdb\x12\x92\x45\x9e\xc6\x6a\x40\x94\xc2\x71\x2a\xac\x5d\xf9\xa1\xc4\xf8\xdb\x75\x72\xe8\x6b\x1a\x52\x84\x8a\x46\x4f\x5c\x51\xb0\x57\x01\xd4\x03\x1a\x26\xfd\xe1\xb9\x2f\x5d\xcc\x19\x4e\x34\x3a\xdf\xdd\x4a\x67\xe6\x2e\x4d\x40\x3e\xf8\xe5\xee\x79\xb2\x22\xdc\x02\xcd\x9b\x82\x4c\x10\x02\xb9\x80\xdb\x4e\x76\x11\xc7\x66\x21\xd8\xa4\x04\xea\x25\x8f\xab\xe8\x57\x16\x7c\x7d\x5e\x7a\xc4\xe9\x46\xbc\xe9\xe6\xba\x5f\x8e\x01\xf5\xaa\xce\x97\x89\x5c\x48\x8d\x27\x59\x61\xe8\x01\xfa\x07\xa9\xcf\x31\x9a\xf7\x03\x8d\xe5\xa1\xe8\x63\x32\x9b\xcc\x8b\x4b\xe0\xb3\xcf\x42\x22\xb5\x4d\x38\xd2\x8d\x6c\x40\x2d\xde\x2e\x3e\xc4\x01\x8c\x87\xc9\xe6\x5a\x61\x51\xfc\x26\x97\xa6\xfc\x05\xb0\xd2\xe4\x1e\xc4\x03\xa5\x2a\x86\x05\x94\xa2\x6a\x35\xb8\x42\x0c\xe1\x3e\xb4\x93\xb6\x65\x2b\x87\xf9\x31\x11\x4e\xb7\x73\xf1\xdb\xcf\x21\xe0\xf3\x68\xb5\x91\xeb\xe3\xda\xed\x46\x71\xcf\xb7\xf2\x5c\x9b\x0f\x19\x96\x50\x12\x9e\xe5\xe1\x19\xb2\xa5\x81\xd7\xf4\x7e\xf0\x15\x60\x5a\x82\x91\x05\x64\x87\x68\x17\xd9\x1b\xc0\x9a\x65\x7d\xb6\x53\x9e\xc5\xa8\x27\x8c\x11\x91\x4c\x2c\xb7\xfd\x79\xe2\xab\x8b\x08\x55\x25\xa6\xa2\xb3\x11\xbf\x3e\xd4\x6d\xeb\x85\x7c\xbd\x25\xcf\xbb\xc0\x1e\x19\xe0\x18\xf1\x28\xca\xfc\x42\xd7\x71\xad\xf1\x86\x57\xb2\x38\x47\xe1\xe2\x74\x41\x51\xa8\xa8\x55\xd6\xeb\x77\x13\x6b\x98\x54\x29\xc9\xd2\x0c\x67\xed\xce\xa6\xda\x79\xf6\xf0\xdc\x90\x8f\xb0\xac\x2b\x8e\x68\x42\x8e\xe7\x0d\x6d\xc5\x20\x86\xb4\xe9\x25\x05\x03\x74\x51\x99\xec\xba\xef\x60\xda\xc3\x81\x3f\xba\x6b\x58\x2d\x45\xfd\x72\xaf\x3b\x86\xc6\xda\xb8\xd3\xcf\x00\x6b\x42\xda\x2f\xd3\xcb\x91\x9f\x07\xff\x47\xd8\x8a\x4d\xad\x56\x39\xd6\x01\x03\xa1\x9f\x27\xc7\xd4\x67\x9f\x88\x7c\xe6\x2d\x33\x26\xf2\x50\x12\x5c\xa8\x03\x70\x28\x93\x7c\x4f\x5b\x81\x1d\x07\x6b\x4b\x62\x93\x5a\x8b\x80\xad\xbb\x21\x41\x6f\xc4\xa2\xfb\x57\xb9\xbd\x40\xf7\x5a\xab\x92\x3b\xd0\x40\x8e\x8c\x3d\x9b\x13\x0a\x89\x25\x52\xf9\x13\xa2\x52\x46\x04\x2e\xdd\x4a\xe9\xe0\xdd\xb0\x2b\xd6\x1c\x5e\xd4\x39\xbd\xa7\x0e\x12\xcf\x90\xdb\xc6\x6e\x80\x60\x83\xb2\x23\x93\x7a\x68\x43\x61\xdc\x78\xc2\xf3\xc3\x48\x71\x7a\x49\x46\x19\x66\xd2\x04\x50\x20\x64\x65\x9f\xd5\xe2\x72\x5d\xd7\x5b\xae\x37\x4a\x31\x0d\x11\xaa\x68\x5d\xa7\xc5\x06\x71\x35\xa8\xa5\x56\xd8\x79\x52\x11\x28\xb3\x5a\xa4\x72\xa0\x8b\x65\x51\x9e\xc3\x16\x2e\xab\xbc\x20\x49\x29\xe3\xb5\x8b\x1f\x85\x9d\x2c\xb6\x30\x5a\xb2\x7b\x33\xfb\x4d\xaf\xc4\xbb\x94\x5f\x46\x81\x63\xbc\x50\x7e\xb1\xcb\x2a\x0f\x57\xeb\xb9\x5f\xf6\xea\x34\xad\x1e\x49\x5c\xeb\xa8\xc7\x10\x5f\xe5\xaa\x85\xa5\x2e\xb2\x6e\xbd\x49\x43\x45\x74\x45\x39\x0b\x52\x1c\xaa\x63\x2c\xdd\x14\xe5\x4d\xba\x49\xb6\x51\x2b\x6d\x0b\x25\x15\x57\x18\xc9\x62\x40\x7c\x69\xde\xac\x0b\x39\x60\x2c\xec\xeb\xab\x52\xd0\x33\xe8\x74\xae\x11\xbc\xe6\x21\xd6\xd9\xc1\x24\x4c\x3e\x4a\x05\xf6\xc2\xfb\xa9\x1a\x1b\x05\xeb\xa2\x86\xa5\x5b\xed\xd5\x87\xdc\xde\x3f\x77\x92\xb0\x6d\x95\xd7\x5e\x2c\xa8\x47\xdf\xc7\x5e\x07\x5a\xbb\x05\x55\x9a\xac\x0b\xcb\x45\x37\x39\x8f\x82\x4b\x95\x00\xa3\x0b\x24\x63\x9d\x6a\xcb\x8e\x12\x96\x82\x73\xa3\xb9\x40\x61\x63\xb2\x2f\xb6\x12\xc1\x73\xb5\xbf\xfa\x56\x9f\xb5\x3b\xdf\xbb\x85\x13\x1a\xe9\x79\xcc\x6b\xd4\x3b\x31\x11\x21\x07\xa7\x85\x48\x01\xea\x5d\x51\x03\xdb\xb4\xae\x8e\xdd\x5f\x94\x0a\xad\x44\x8b\x3c\xe7\x9f\x71\x94\xa9\xa9\x33\x2d\xc0\x0a\x67\x87\x68\x4d\x7d\x57\x1b\xe1\x9e\x5e\xad\xca\x3a\xcf\xb8\x53\x8c\xd1\x43\x3d\x4f\x32\xdd\x84\x4d\x35\xde\xf4\x45\x69\xef\xc2\x63\x2d\x58\x95\x55\x16\x24\x7d\xe8\x1c\x29\x00\xf3\xb8\x78\xb8\x8a\xa3\x56\x2a\xdc\xe6\xb0\x96\x85\x87\x09\xf6\x4d\xe4\xab\xb4\xa0\xdd\x92\x16\x2b\x29\x8c\x71\xae\xf8\x7a\xab\x2c\x7a\xc6\x74\x52\x1c\x77\x08\x85\x15\xf9\x69\xf5\x2b\x17\x5f\x31\x87\x68\xa4\x7a\x7b\x92\x3a\xf3\x1a\x9c\xcd\x09\x9b\x50\xb0\x24\x7a\x67\x61\x33\x1c\x35\x09\x72\x19\xda\x50\x5a\xad\xbf\xf6\x17\xff\xfe\xca\x0e\x37\xd0\x6f\x2d\x1d\x18\x55\xb3\x3a\xce\xcb\x27\x10\x89\x1f\x36\x87\xe8\x9e\x86\xb0\x45\x1e\xdf\x29\x51\x23\x23\xf1\x12\x1e\xf8\x85\x52\x2f\x4d\xd5\x24\x3e\x57\x9c\xdb\x7b\xfb\x72\xad\x15\x38\x7f\x80\x45\x8c\x5d\xa4\xee\xaa\xa9\x6c\x4f\x44\xb3\xa7\xb8\x10\x73\x44\xe8\xcd\x73\xe0\xf4\x31\xa7\xe7\x0e\x0f\x26\xb0\x0a\x4f\xd1\x8b\x28\xc4\x0c\x1c\xd1\x0e\x77\x39\xec\x83\x49\xb5\x94\x8b\x17\x43\x91\x7d\x66\xc5\x57\x45\x2a\xa4\x41\x2c\x3b\xc5\xad\xba\x70\x20\x3c\x9b\xba\x1f\xab\xae\x2c\xde\x82\x86\xcd\xee\xd6\x4e\x15\x26\x98\x54\x28\x56\xd5\x81\x20\x4e\x01\x60\x58\xe1\x48\x74\x21\x1d\x43\x82\xc0\x9a\x7c\x9e\x3b\x97\x8b\x79\x3d\x27\xd8\xd5\x60\x19\x59\x05\x96\x85\x04\x96\x2e\x70\xe1\x69\xba\x4d\x8b\x38\x1c\x2b\xe7\x8b\x76\x65\x27\x7b\xc3\xbf\xb4\xbd\x19\x70\xdc\x86\xcf\xd9\xda\xef\x83\xf3\x8c\xd9\x42\x0f\x98\xa4\xfa\xb5\x7c\x32\xd7\x75\xe6\xae\x91\xee\x71\x4e\xc4\x10\xa9\x57\x6b\x50\xe6\x78\x5c\x45\xdb\xf4\xd3\x35\xa2\xca\x88\xd8\xf4\x92\xbf\xb8\xa7\x70\x5d\x17\xdb\x32\x2e\x5c\x1e\x31\x39\x34\xb3\x6d\x2b\x79\x9b\x30\x0a\xac\x49\x64\x08\x2f\xd3\x0a\x6f\xcc\x02\xda\xda\x46\xc6\x3c\xb5\x32\x17\x39\x09\x1a\x11\x90\x76\xac\xd5\xc8\xb9\x51\x42\x14\x4c\xa2\xb3\xdd\x7c\x29\x8f\x22\x5b\x90\x0c\x05\x9d\x8a\x81\x47\x33\x7b\x71\x16\x56\xdc\x37\x95\xf2\x0e\xe7\x25\x70\x34\xb6\x1a\x8c\x7d\xb7\x31\x88\x4d\x00\x91\xee\xcf\x45\xb0\x6f\xcb\xd0\xc8\x53\x8a\xa6\x6c\x0c\x11\xc8\xf9\xd2\x50\x8f\x97\x0f\x6a\x2f\xba\xc7\x82\xe5\xf4\x48\x6f\xed\xf3\xb8\x9c\x39\xee\x55\x65\x1e\x7a\x0e\x00\x58\x35\x62\x4f\xa1\xae\x6a\x36\x44\x67\x5c\x45\xd1\x73\x72\x6b\x7b\x61\x9a\x88\x6f\x2c\x1d\x5f\x09\x8f\xd2\x9a\x8d\x73\x1a\xfe\xd7\x97\xe3\x73\xd1\xc4\xc9\xf6\x86\x42\xd0\xef\xbf\x0d\xc9\x38\x57\xd3\xf8\x9d\xe2\x7e\x23\xd0\x6e\xfb\xf4\xce\xde\x7e\x22\x88\xf3\xcf\x1f\x3c\xea\x07\xe8\xd3\x6f\x53\x10\x56\xc9\xe7\x75\x08\xba\x6f\xa4\xee\x07\xf5\x89\xbd\xd3\xce\x5f\x7e\x50\xf6\xfb\xdb\x37\xee\xf6\x17\x0a\xff\x4f\x3d\xdf\xd4\x7c\x9a\xe2\xaf\x27\x64\x2a\xa2\xa0\xfa\x60\x62\xeb\x13\x57\x25\xff\x48\x77\x7f\xa7\x78\xdf\x90\x77\xf3\xba\x1f\xa4\xf9\x09\xaa\x82\x6e\x4c\xde\xbe\x17\x3e\x1a\x7e\x66\xd2\xa3\x28\xfa\xb5\xff\x7f\xff\x67\x4d\xfe\xf5\x17\x5e\xf8\xcb\x9a\x17\x53\xf2\x79\xec\x82\x28\x79\x6b\xda\x77\xdf\x7f\x51\x94\xff\x19\xbe\x9f\xc3\xf5\xd1\x3a\xfc\xdf\x14\xff\x85\x89\xff\xce\x54\xff\xc1\x11\xc3\xf8\x3f\xc9\x34\x53\xfe\x87\xc4\xff\x22\xff\xfa\x5a\x15\x4d\xf2\xe3\x92\xe2\xff\x87\x47\xff\xf5\xf5\x2f\x74\x38\x4c\xfc\x74\xf3\xf1\xd1\xed\x3f\x9a\xfa\xb3\x9a\xb7\xa4\xee\xa6\xfd\x07\x77\xde\xb4\x4d\xf2\x43\xe8\x1c\x46\xef\x97\x23\x3f\xb1\xfb\xc8\x7f\xb2\xfb\x3f\x93\xf9\xbf\xff\x16\xe5\x4d\xf9\x43\x55\xd1\x7c\xf3\xe8\x0f\x36\xfe\x0f\x99\x6f\xe2\x1f\x2e\x22\x3f\x5d\x06\xa0\xff\x7c\x6b\xf2\xcb\x48\xc1\xa3\xf0\x82\x47\x7f\x33\x00\xb0\x28\x48\xb0\xe8\x0f\x23\x7e\x3b\x43\xf1\xf5\x57\xd9\x28\xc1\x2e\x18\xf6\xd3\xa8\x7a\xaf\x0c\x50\x8c\xc0\x90\x0f\xb9\x3f\xa2\xd1\x9e\x63\xa1\x98\xf6\xb7\xf3\xf3\x69\x82\x25\x0c\x86\xcf\x69\x71\x2e\xfa\xef\x17\x21\x9f\x7e\x0b\xa7\xe6\xe7\xab\x96\xef\x3e\x90\x1f\x9f\xd5\xef\xbf\x85\xe3\xe7\x73\xc4\x57\xed\x3c\xfd\x18\x35\x3f\x3b\x0b\xfd\x8d\xed\x49\xf2\x7d\x48\x7f\x4b\xe7\x47\xb8\xf1\x3f\xbf\x80\x8f\x0b\x12\xf4\xaf\xfa\x3f\xc7\x41\x93\x9d\x19\xfa\x59\xf4\x2f\xae\xfe\x0d\xf8\x53\x8e\x7d\xfd\x67\x4c\xd1\xa4\xed\xdf\xa9\x83\xc3\x0b\x44\x25\xbf\x40\x7f\x52\xf6\x81\xf8\x7f\x01\x00\x00\xff\xff\x0d\xab\xae\xdb\xe2\x1b\x00\x00"
-
-func pub_styles_main_min_css_bytes() ([]byte, error) {
-	return bindata_read(
-		_pub_styles_main_min_css,
-		"pub/styles/main.min.css",
-	)
-}
-
-func pub_styles_main_min_css() (*asset, error) {
-	bytes, err := pub_styles_main_min_css_bytes()
-	if err != nil {
-		return nil, err
-	}
-
-	info := bindata_file_info{name: "pub/styles/main.min.css", size: 7138, mode: os.FileMode(420), modTime: time.Unix(1422215929, 0)}
-	a := &asset{bytes: bytes, info: info}
-	return a, nil
-}
-
-// Asset loads and returns the asset for the given name.
-// It returns an error if the asset could not be found or
-// could not be loaded.
-func Asset(name string) ([]byte, error) {
-	cannonicalName := strings.Replace(name, "\\", "/", -1)
-	if f, ok := _bindata[cannonicalName]; ok {
-		a, err := f()
-		if err != nil {
-			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
-		}
-		return a.bytes, nil
-	}
-	return nil, fmt.Errorf("Asset %s not found", name)
-}
-
-// AssetInfo loads and returns the asset info for the given name.
-// It returns an error if the asset could not be found or
-// could not be loaded.
-func AssetInfo(name string) (os.FileInfo, error) {
-	cannonicalName := strings.Replace(name, "\\", "/", -1)
-	if f, ok := _bindata[cannonicalName]; ok {
-		a, err := f()
-		if err != nil {
-			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
-		}
-		return a.info, nil
-	}
-	return nil, fmt.Errorf("AssetInfo %s not found", name)
-}
-
-// AssetNames returns the names of the assets.
-func AssetNames() []string {
-	names := make([]string, 0, len(_bindata))
-	for name := range _bindata {
-		names = append(names, name)
-	}
-	return names
-}
-
-// _bindata is a table, holding each asset generator, mapped to its name.
-var _bindata = map[string]func() (*asset, error){
-	"pub/.DS_Store":           pub_ds_store,
-	"pub/images/.DS_Store":    pub_images_ds_store,
-	"pub/images/favicon.ico":  pub_images_favicon_ico,
-	"pub/images/gopher.png":   pub_images_gopher_png,
-	"pub/styles/main.css":     pub_styles_main_css,
-	"pub/styles/main.min.css": pub_styles_main_min_css,
-}
-
-// AssetDir returns the file names below a certain
-// directory embedded in the file by go-bindata.
-// For example if you run go-bindata on data/... and data contains the
-// following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
-// then AssetDir("data") would return []string{"foo.txt", "img"}
-// AssetDir("data/img") would return []string{"a.png", "b.png"}
-// AssetDir("foo.txt") and AssetDir("notexist") would return an error
-// AssetDir("") will return []string{"data"}.
-func AssetDir(name string) ([]string, error) {
-	node := _bintree
-	if len(name) != 0 {
-		cannonicalName := strings.Replace(name, "\\", "/", -1)
-		pathList := strings.Split(cannonicalName, "/")
-		for _, p := range pathList {
-			node = node.Children[p]
-			if node == nil {
-				return nil, fmt.Errorf("Asset %s not found", name)
-			}
-		}
-	}
-	if node.Func != nil {
-		return nil, fmt.Errorf("Asset %s not found", name)
-	}
-	rv := make([]string, 0, len(node.Children))
-	for name := range node.Children {
-		rv = append(rv, name)
-	}
-	return rv, nil
-}
-
-type _bintree_t struct {
-	Func     func() (*asset, error)
-	Children map[string]*_bintree_t
-}
-
-var _bintree = &_bintree_t{nil, map[string]*_bintree_t{
-	"pub": &_bintree_t{nil, map[string]*_bintree_t{
-		".DS_Store": &_bintree_t{pub_ds_store, map[string]*_bintree_t{}},
-		"images": &_bintree_t{nil, map[string]*_bintree_t{
-			".DS_Store":   &_bintree_t{pub_images_ds_store, map[string]*_bintree_t{}},
-			"favicon.ico": &_bintree_t{pub_images_favicon_ico, map[string]*_bintree_t{}},
-			"gopher.png":  &_bintree_t{pub_images_gopher_png, map[string]*_bintree_t{}},
-		}},
-		"styles": &_bintree_t{nil, map[string]*_bintree_t{
-			"main.css":     &_bintree_t{pub_styles_main_css, map[string]*_bintree_t{}},
-			"main.min.css": &_bintree_t{pub_styles_main_min_css, map[string]*_bintree_t{}},
-		}},
-	}},
-}}
-
-// Restore an asset under the given directory
-func RestoreAsset(dir, name string) error {
-	data, err := Asset(name)
-	if err != nil {
-		return err
-	}
-	info, err := AssetInfo(name)
-	if err != nil {
-		return err
-	}
-	err = os.MkdirAll(_filePath(dir, path.Dir(name)), os.FileMode(0755))
-	if err != nil {
-		return err
-	}
-	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
-	if err != nil {
-		return err
-	}
-	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-// Restore assets under the given directory recursively
-func RestoreAssets(dir, name string) error {
-	children, err := AssetDir(name)
-	if err != nil { // File
-		return RestoreAsset(dir, name)
-	} else { // Dir
-		for _, child := range children {
-			err = RestoreAssets(dir, path.Join(name, child))
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return nil
-}
-
-func _filePath(dir, name string) string {
-	cannonicalName := strings.Replace(name, "\\", "/", -1)
-	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
-}
-
-func AssetFS() *assetfs.AssetFS {
-	for k := range _bintree.Children {
-		return &assetfs.AssetFS{Asset: Asset, AssetDir: AssetDir, Prefix: k}
-	}
-	panic("unreachable")
-}
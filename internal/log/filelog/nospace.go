@@ -0,0 +1,12 @@
+//go:build !plan9
+// +build !plan9
+
+package filelog
+
+import "syscall"
+
+// Returns whether given system error is caused by lack of disk space
+// or exceeded disk quota.
+func isNoSpaceErr(e error) bool {
+	return e == syscall.ENOSPC || e == syscall.EDQUOT
+}
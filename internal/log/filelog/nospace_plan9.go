@@ -0,0 +1,10 @@
+//go:build plan9
+// +build plan9
+
+package filelog
+
+// Returns false, as errors of Plan 9 are strings, which are not
+// distinguished reliably.
+func isNoSpaceErr(error) bool {
+	return false
+}
@@ -0,0 +1,43 @@
+package filelog
+
+import "time"
+
+// Period represents calendar period on which boundaries log files are rotated.
+type Period int
+
+// Supported rotation periods.
+const (
+	None Period = iota
+	Hourly
+	Daily
+	Weekly
+	Monthly
+)
+
+// Helper function which truncates given time to the start of the period it
+// belongs to. Weeks are considered to start on Monday. Zero time is returned
+// for None period.
+func (p Period) truncate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	switch p {
+	case Hourly:
+		return time.Date(y, m, d, t.Hour(), 0, 0, 0, t.Location())
+	case Daily:
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	case Weekly:
+		return time.Date(
+			y, m, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location(),
+		)
+	case Monthly:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Time{}
+}
+
+// Helper function to check whether given times belong to different periods.
+func (p Period) crossed(from, to time.Time) bool {
+	if p == None {
+		return false
+	}
+	return !p.truncate(from).Equal(p.truncate(to))
+}
@@ -0,0 +1,39 @@
+package filelog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodCrossed(t *testing.T) {
+	at := func(value string) time.Time {
+		tm, err := time.Parse("2006-01-02 15:04", value)
+		if err != nil {
+			t.Fatalf("failed to parse time '%s', reason: %s", value, err.Error())
+		}
+		return tm
+	}
+	cases := []struct {
+		period   Period
+		from, to string
+		crossed  bool
+	}{
+		{None, "2015-01-01 10:00", "2016-01-01 10:00", false},
+		{Hourly, "2015-01-01 10:00", "2015-01-01 10:59", false},
+		{Hourly, "2015-01-01 10:59", "2015-01-01 11:00", true},
+		{Daily, "2015-01-01 00:00", "2015-01-01 23:59", false},
+		{Daily, "2015-01-01 23:59", "2015-01-02 00:00", true},
+		{Weekly, "2015-01-05 00:00", "2015-01-11 23:59", false},
+		{Weekly, "2015-01-11 23:59", "2015-01-12 00:00", true},
+		{Monthly, "2015-01-01 00:00", "2015-01-31 23:59", false},
+		{Monthly, "2015-01-31 23:59", "2015-02-01 00:00", true},
+	}
+	for _, c := range cases {
+		if crossed := c.period.crossed(at(c.from), at(c.to)); crossed != c.crossed {
+			t.Errorf(
+				"period %d crossed between '%s' and '%s' expected %v, got %v",
+				c.period, c.from, c.to, c.crossed, crossed,
+			)
+		}
+	}
+}
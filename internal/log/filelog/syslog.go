@@ -0,0 +1,175 @@
+package filelog
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	log "github.com/alecthomas/log4go"
+)
+
+var (
+	// Syslog is not dialed again for this long after dialing failed, so that
+	// unreachable syslog is not dialed for every record.
+	syslogRetryDelay = 5 * time.Second
+	// Dialing of syslog gives up after this long, as log/syslog package
+	// dials without timeout.
+	syslogDialTimeout = 5 * time.Second
+)
+
+// Connection to syslog, which sends records with priority of their level.
+type syslogConn interface {
+	send(lvl log.Level, msg string) error
+	Close() error
+}
+
+// Message queued to be sent to syslog.
+type syslogMsg struct {
+	lvl log.Level
+	msg string
+}
+
+// Sends log records to syslog in addition to file. Records are sent by
+// separate goroutine, so that dialing of syslog or sending to it never blocks
+// logging into file: records are dropped while syslog is unreachable or their
+// queue is full. Dropped connection is dialed again by log/syslog package.
+type syslogSink struct {
+	dial func() (syslogConn, error)
+	msgs chan syslogMsg
+	// Counter of dropped records
+	dropped *uint64
+	// Reports changes of syslog state
+	printErr func(error)
+	// Closed when goroutine sending records finishes
+	done chan sig
+}
+
+// Helper function which creates syslog sink dialing syslog with given
+// function, and starts its goroutine.
+func (w *Writer) newSyslogSink(dial func() (syslogConn, error)) *syslogSink {
+	s := &syslogSink{
+		dial:     dial,
+		msgs:     make(chan syslogMsg, log.LogBufferLength),
+		dropped:  &w.stats.SyslogDropped,
+		printErr: w.printErr,
+		done:     make(chan sig),
+	}
+	go s.run()
+	return s
+}
+
+// Helper function which queues given message to be sent to syslog, dropping
+// it if queue is full. It never blocks.
+func (s *syslogSink) send(lvl log.Level, msg string) {
+	select {
+	case s.msgs <- syslogMsg{lvl: lvl, msg: msg}:
+	default:
+		atomic.AddUint64(s.dropped, 1)
+	}
+}
+
+// Helper function which sends queued messages to syslog until sink is closed,
+// dialing syslog on the first message. Reports changes of syslog state only,
+// not every dropped record.
+func (s *syslogSink) run() {
+	defer close(s.done)
+	var conn syslogConn
+	var retryAt time.Time
+	failing := false
+	fail := func(err error) {
+		atomic.AddUint64(s.dropped, 1)
+		if !failing {
+			s.printErr(err)
+			failing = true
+		}
+	}
+	for m := range s.msgs {
+		if conn == nil {
+			if time.Now().Before(retryAt) {
+				atomic.AddUint64(s.dropped, 1)
+				continue
+			}
+			c, err := dialSyslog(s.dial)
+			if err != nil {
+				retryAt = time.Now().Add(syslogRetryDelay)
+				fail(fmt.Errorf("syslog is unreachable: %s", err))
+				continue
+			}
+			conn = c
+		}
+		if err := conn.send(m.lvl, m.msg); err != nil {
+			fail(fmt.Errorf("sending to syslog failed: %s", err))
+			continue
+		}
+		failing = false
+	}
+	if conn != nil {
+		conn.Close()
+	}
+}
+
+// Helper function which stops sink after records queued already are sent.
+func (s *syslogSink) close() {
+	close(s.msgs)
+}
+
+// Helper function which dials syslog with given function, giving up after
+// syslogDialTimeout. Connection dialed after timeout is closed.
+func dialSyslog(dial func() (syslogConn, error)) (syslogConn, error) {
+	type result struct {
+		conn syslogConn
+		err  error
+	}
+	dialed := make(chan result, 1)
+	go func() {
+		conn, err := dial()
+		dialed <- result{conn: conn, err: err}
+	}()
+	timer := time.NewTimer(syslogDialTimeout)
+	defer timer.Stop()
+	select {
+	case res := <-dialed:
+		return res.conn, res.err
+	case <-timer.C:
+		go func() {
+			if res := <-dialed; res.conn != nil {
+				res.conn.Close()
+			}
+		}()
+		return nil, fmt.Errorf("dialing timed out after %s", syslogDialTimeout)
+	}
+}
+
+// Helper function which stops sending records to syslog, if any.
+func (w *Writer) closeSyslog() {
+	if w.syslog != nil {
+		w.syslog.close()
+		w.syslog = nil
+	}
+}
+
+// SetSyslog makes records written into file to be sent to syslog too, with
+// priority mapped from their level and given facility (like "daemon" or
+// "local0"), or stops sending them if network is "none". Empty network and
+// address mean local syslog, otherwise records are sent to remote syslog
+// (e.g. "udp", "logs.example.com:514"). Records are sent as bare messages,
+// as syslog adds its own timestamp, and tagged with given tag, or name of
+// program if it is empty. Syslog is dialed on the first record, so
+// unreachable syslog is not an error here. Can be safely called while
+// logging. Syslog is not supported on Windows and Plan 9.
+func (w *Writer) SetSyslog(network, addr, facility, tag string) error {
+	var dial func() (syslogConn, error)
+	if network != "none" {
+		var err error
+		if dial, err = syslogDialer(network, addr, facility, tag); err != nil {
+			return err
+		}
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closeSyslog()
+	if dial != nil {
+		w.syslog = w.newSyslogSink(dial)
+	}
+	return nil
+}
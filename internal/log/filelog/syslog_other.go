@@ -0,0 +1,14 @@
+//go:build windows || plan9
+// +build windows plan9
+
+package filelog
+
+import (
+	"errors"
+	"runtime"
+)
+
+// Returns error, as there is no syslog on this system.
+func syslogDialer(network, addr, facility, tag string) (func() (syslogConn, error), error) {
+	return nil, errors.New("syslog is not supported on " + runtime.GOOS)
+}
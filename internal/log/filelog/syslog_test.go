@@ -0,0 +1,123 @@
+package filelog
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	l4g "github.com/alecthomas/log4go"
+)
+
+// Connection to syslog which records sent messages.
+type fakeSyslog struct {
+	sent []string
+}
+
+func (s *fakeSyslog) send(lvl l4g.Level, msg string) error {
+	s.sent = append(s.sent, lvl.String()+" "+msg)
+	return nil
+}
+
+func (s *fakeSyslog) Close() error {
+	return nil
+}
+
+// Helper function which creates writer of test file, sending records to
+// syslog dialed with given function.
+func newSyslogTestWriter(dir string, dial func() (syslogConn, error)) *Writer {
+	w := &Writer{
+		filename: filepath.Join(dir, "syslog-test.log"),
+		format:   "[%L] %M",
+		waiter:   &sync.WaitGroup{},
+	}
+	w.syslog = w.newSyslogSink(dial)
+	return w
+}
+
+// Helper function which writes given messages as writer loop does, and
+// waits for syslog sink of given writer to send them.
+func writeSyslog(t *testing.T, w *Writer, msgs ...string) {
+	for _, msg := range msgs {
+		if err := writeLocked(w, &l4g.LogRecord{Level: l4g.ERROR, Message: msg}); err != nil {
+			t.Fatalf("failed to write record, reason: %s", err.Error())
+		}
+	}
+	sink := w.syslog
+	closeLocked(w)
+	w.mu.Lock()
+	w.closeSyslog()
+	w.mu.Unlock()
+	select {
+	case <-sink.done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("syslog sink did not finish")
+	}
+}
+
+func TestSyslogSink(t *testing.T) {
+	dir := createTestFiles(nil)
+	defer removeTestFiles(dir)
+
+	// Bare messages are sent with their levels
+	syslog := &fakeSyslog{}
+	w := newSyslogTestWriter(dir, func() (syslogConn, error) { return syslog, nil })
+	writeSyslog(t, w, "first", "second")
+	if strings.Join(syslog.sent, "\n") != "EROR first\nEROR second" {
+		t.Errorf("syslog expected to receive bare messages, got %q", syslog.sent)
+	}
+	if stats := w.Stats(); stats.SyslogDropped != 0 || stats.BytesWritten != 27 {
+		t.Errorf("all records expected to be written and sent, got %+v", stats)
+	}
+
+	// Records are written into file, while syslog is down, and it is not
+	// dialed again before retry delay
+	dials := 0
+	w = newSyslogTestWriter(dir, func() (syslogConn, error) {
+		dials++
+		return nil, errors.New("connection refused")
+	})
+	writeSyslog(t, w, "lost", "lost again")
+	if stats := w.Stats(); dials != 1 || stats.SyslogDropped != 2 || stats.BytesWritten != 30 {
+		t.Errorf("expected 1 dial and 2 records dropped by syslog, got %d dials and %+v", dials, stats)
+	}
+}
+
+func TestSyslogDialTimeout(t *testing.T) {
+	dir := createTestFiles(nil)
+	defer removeTestFiles(dir)
+	defer func(timeout time.Duration) { syslogDialTimeout = timeout }(syslogDialTimeout)
+	syslogDialTimeout = 10 * time.Millisecond
+
+	// Hanging dial blocks neither writing into file nor closing
+	hang := make(chan sig)
+	defer close(hang)
+	w := newSyslogTestWriter(dir, func() (syslogConn, error) {
+		<-hang
+		return nil, errors.New("connection refused")
+	})
+	start := time.Now()
+	writeSyslog(t, w, "lost", "lost again")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("writing took %s while dialing of syslog hangs", elapsed)
+	}
+	if dropped := w.Stats().SyslogDropped; dropped != 2 {
+		t.Errorf("expected 2 records dropped by syslog, got %d", dropped)
+	}
+}
+
+func TestSetSyslog(t *testing.T) {
+	w := NewWriter(filepath.Join(t.TempDir(), "syslog-test.log"), false)
+	defer w.Close()
+	if err := w.SetSyslog("udp", "127.0.0.1:514", "nosuch", ""); err == nil {
+		t.Error("expected error for unknown facility")
+	}
+	if w.syslog != nil {
+		t.Error("syslog must not be set on error")
+	}
+	if err := w.SetSyslog("none", "", "", ""); err != nil || w.syslog != nil {
+		t.Errorf("syslog expected to be disabled, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package filelog
+
+import (
+	"fmt"
+	"log/syslog"
+
+	log "github.com/alecthomas/log4go"
+)
+
+// Syslog facilities by their names.
+var facilities = map[string]syslog.Priority{
+	"kern": syslog.LOG_KERN, "user": syslog.LOG_USER, "mail": syslog.LOG_MAIL,
+	"daemon": syslog.LOG_DAEMON, "auth": syslog.LOG_AUTH,
+	"syslog": syslog.LOG_SYSLOG, "lpr": syslog.LOG_LPR,
+	"news": syslog.LOG_NEWS, "uucp": syslog.LOG_UUCP, "cron": syslog.LOG_CRON,
+	"authpriv": syslog.LOG_AUTHPRIV, "ftp": syslog.LOG_FTP,
+	"local0": syslog.LOG_LOCAL0, "local1": syslog.LOG_LOCAL1,
+	"local2": syslog.LOG_LOCAL2, "local3": syslog.LOG_LOCAL3,
+	"local4": syslog.LOG_LOCAL4, "local5": syslog.LOG_LOCAL5,
+	"local6": syslog.LOG_LOCAL6, "local7": syslog.LOG_LOCAL7,
+}
+
+// Returns function which dials syslog with given network, address, facility
+// name and tag, or error if facility is unknown.
+func syslogDialer(network, addr, facility, tag string) (func() (syslogConn, error), error) {
+	if facility == "" {
+		facility = "user"
+	}
+	priority, exists := facilities[facility]
+	if !exists {
+		return nil, fmt.Errorf("unknown syslog facility '%s'", facility)
+	}
+	return func() (syslogConn, error) {
+		w, err := syslog.Dial(network, addr, priority, tag)
+		if err != nil {
+			return nil, err
+		}
+		return &syslogWriter{w}, nil
+	}, nil
+}
+
+// Connection to syslog of log/syslog package.
+type syslogWriter struct {
+	*syslog.Writer
+}
+
+// Sends given message with severity of given level.
+func (w *syslogWriter) send(lvl log.Level, msg string) error {
+	switch lvl {
+	case log.FINEST, log.FINE, log.DEBUG, log.TRACE:
+		return w.Debug(msg)
+	case log.INFO:
+		return w.Info(msg)
+	case log.WARNING:
+		return w.Warning(msg)
+	case log.ERROR:
+		return w.Err(msg)
+	}
+	return w.Crit(msg)
+}
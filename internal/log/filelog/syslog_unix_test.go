@@ -0,0 +1,43 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package filelog
+
+import (
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	l4g "github.com/alecthomas/log4go"
+)
+
+func TestSyslogRemote(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen, reason: %s", err.Error())
+	}
+	defer conn.Close()
+
+	w := NewWriter(filepath.Join(t.TempDir(), "syslog-test.log"), false)
+	w.SetFormat("%M").SetWaitOnClose(true)
+	if err = w.SetSyslog("udp", conn.LocalAddr().String(), "local0", "sizeof"); err != nil {
+		t.Fatalf("failed to set syslog, reason: %s", err.Error())
+	}
+	w.LogWrite(&l4g.LogRecord{Level: l4g.WARNING, Message: "disk is full"})
+	w.Close()
+
+	buf := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("failed to receive syslog message, reason: %s", err.Error())
+	}
+	// Priority is facility local0 (16) * 8 + severity warning (4)
+	msg := string(buf[:n])
+	if !strings.HasPrefix(msg, "<132>") || !strings.Contains(msg, "sizeof[") ||
+		!strings.HasSuffix(msg, "disk is full\n") {
+		t.Errorf("unexpected syslog message: %q", msg)
+	}
+}
@@ -4,38 +4,141 @@ package filelog
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/alecthomas/log4go"
 )
 
-// Day format for comparing files changed time during daily log rotation.
-const dayFormat = "2006-01-02"
+// Opens files for writing logs into. Can be replaced in tests.
+var openFile = os.OpenFile
+
+// Renames log files on rotation. Can be replaced in tests.
+var renameFile = os.Rename
+
+// Flushes log files to disk on rotation. Can be replaced in tests.
+var syncFile = (*os.File).Sync
+
+// Behavior of Windows file system, which can be enabled in tests on other
+// systems.
+var (
+	// Names of files which differ in case only refer to the same file
+	caseInsensitiveNames = runtime.GOOS == "windows"
+	// Opened file cannot be renamed, so it is closed before rotation
+	closeBeforeRename = runtime.GOOS == "windows"
+	// Names of devices (like "NUL" or "COM1") cannot be used as file names,
+	// even with extensions
+	reservedDeviceNames = runtime.GOOS == "windows"
+)
+
+// Failed rotation is retried this many times by default, waiting before
+// each retry for delay which doubles after each retry.
+const (
+	defaultRotationRetries = 3
+	rotationRetryDelay     = 10 * time.Millisecond
+)
+
+// Stats represents statistics of log writer.
+type Stats struct {
+	// Number of performed rotations
+	Rotations uint64
+	// Number of bytes written into log files
+	BytesWritten uint64
+	// Number of records dropped due to transient write errors
+	Dropped uint64
+	// Number of records dropped due to overflow of records buffer, or
+	// logged after writer is closed
+	Overflowed uint64
+	// Number of records not sent to syslog, as it is unreachable or slow
+	SyslogDropped uint64
+}
+
+// OverflowPolicy defines what happens with log record when records buffer of
+// writer is full.
+type OverflowPolicy int32
+
+const (
+	// Block makes .LogWrite() method to wait until there is room in buffer.
+	Block OverflowPolicy = iota
+	// DropNewest drops record which is being written.
+	DropNewest
+	// DropOldest drops the oldest queued record to make room for new one.
+	DropOldest
+)
+
+// Represents simple zero-cost message that can be used as signal between
+// goroutines.
+type sig struct{}
+
+// Default width of numeric suffix of rotated files.
+const defaultSuffixWidth = 3
 
 // Writer represents log writer which writes logs into files. It can rotate
 // files and delete previously rotated but expired now logs.
 type Writer struct {
+	// Statistics counters (must be first fields to be 64-bit aligned for
+	// atomic operations)
+	stats Stats
+	// Overflow policy, accessed atomically, as .LogWrite() must not wait
+	// for mutex held while file is written
+	overflow int32
+	// Closing settings, accessed atomically, as .Close() must not wait for
+	// mutex held by writer loop, which may hang on writing: closing is
+	// synchronized if waitOnClose is 1, and the longest time of waiting for
+	// writer loop to finish is closeTimeout nanoseconds (0 means forever)
+	waitOnClose  int32
+	closeTimeout int64
+	// Set to 1 by .Close() method, accessed atomically
+	closed int32
+
 	// Channels to receive commands
 	rec chan *log.LogRecord
 	rot chan bool
+	// Channel to request flush, which is kept out of records channel, so
+	// dropping of queued records cannot lose it
+	flush chan sig
+	// Channel to notify that records queued before flush request are written
+	flushed chan sig
+	// Closed when writer loop finishes, as it may stop on unrecoverable
+	// error before writer is closed
+	done chan sig
+	// Serializes concurrent flushes
+	flushMu sync.Mutex
+
+	// Guards settings below, so they can be changed while logging
+	mu sync.Mutex
 
 	// The opened file
 	filename string
 	file     *os.File
 	writer   io.Writer
+	// Custom destination of logs instead of file, set by NewWriterTo()
+	sink io.Writer
+	// Duplicate records written into file to standard output
+	echoStdout bool
+	// Send records written into file to syslog too, set by SetSyslog()
+	syslog *syslogSink
 
 	// The logging format
 	format string
+	// Write records as JSON objects instead of using format
+	json bool
 	// File header/trailer
 	header, trailer string
+	// Write header only into the first opened file and trailer only when
+	// writer is closed, instead of into each file
+	headFootOnce  bool
+	headerWritten bool
 
 	// How long keep already rotated files (0 value means always)
 	keepRotatedSeconds time.Duration
@@ -45,91 +148,381 @@ type Writer struct {
 	// Rotate at size
 	maxsize        uint64
 	maxsizeCursize uint64
-	// Rotate daily
-	daily         bool
-	dailyOpenDate string
+	// Rotate on calendar period boundaries
+	period   Period
+	openTime time.Time
 	// Keep old log files (.001, .002, etc)
 	rotate bool
+	// Width of zero-padded numeric suffix of rotated files
+	suffixWidth int
+	// The highest numeric suffix of rotated files, valid if directory is
+	// already scanned for them
+	lastRotatedNum int
+	rotatedScanned bool
+	// Number of retries of failed rotation before giving up
+	rotationRetries int
+	// Flush file to disk before it is rotated and when it is closed
+	syncOnRotate bool
+	// Rotate (or just close) current file if no record arrives for this
+	// duration (0 value means never)
+	idleRotate time.Duration
+	// Symlink which always points to the currently opened file (empty value
+	// means no symlink is maintained)
+	currentSymlink string
+
+	// Waits for writer loop to finish on synchronized closing
+	waiter *sync.WaitGroup
 
-	// Makes closing synchronized if true
-	waitOnClose bool
-	waiter      *sync.WaitGroup
+	// Writer loop is started by the first use of writer, so that records
+	// channel can be replaced before it
+	startOnce sync.Once
+	started   bool
+	// Makes .Close() method to be no-op when called again
+	closeOnce sync.Once
 }
 
 // NewWriter initializes new log writer.
 func NewWriter(fName string, rotate bool) *Writer {
-	w := &Writer{
-		rec:      make(chan *log.LogRecord, log.LogBufferLength),
-		rot:      make(chan bool),
-		filename: fName,
-		format:   "[%D %T] [%L] (%S) %M",
-		rotate:   rotate,
-		waiter:   &sync.WaitGroup{},
+	return &Writer{
+		rec:         make(chan *log.LogRecord, log.LogBufferLength),
+		rot:         make(chan bool),
+		flush:       make(chan sig),
+		flushed:     make(chan sig),
+		done:        make(chan sig),
+		filename:    fName,
+		format:      "[%D %T] [%L] (%S) %M",
+		rotate:      rotate,
+		suffixWidth: defaultSuffixWidth,
+		echoStdout:  true,
+		waiter:      &sync.WaitGroup{},
+
+		rotationRetries: defaultRotationRetries,
 	}
+}
 
-	w.waiter.Add(1)
-	go func() {
-		defer w.waiter.Done()
-		defer w.closeCurrentFile()
-		printErr := func(e error) {
-			fmt.Fprintf(os.Stderr,
-				"imaginator/filelog.NewWriter(%q): %s\n", w.filename, e,
-			)
-		}
-		for {
-			select {
-			case <-w.rot:
-				if err := w.doRotation(); err != nil {
-					printErr(err)
-					return
-				}
-			case rec, ok := <-w.rec:
-				if !ok {
-					return
-				}
-				if w.file == nil {
-					if err := w.openNewFile(); err != nil {
-						printErr(err)
-						return
-					}
-				}
-				if (w.maxlines > 0 && w.maxlinesCurlines >= w.maxlines) ||
-					(w.maxsize > 0 && w.maxsizeCursize >= w.maxsize) ||
-					(w.daily &&
-						(time.Now().Format(dayFormat) != w.dailyOpenDate)) {
-					if err := w.doRotation(); err != nil {
-						printErr(err)
+// NewWriterTo initializes new log writer which writes logs into given writer
+// (like buffer in tests, pipe or network socket) instead of file. Format,
+// header and trailer apply as usual, while files are neither opened nor
+// rotated. Given writer is not closed by .Close() method.
+func NewWriterTo(out io.Writer) *Writer {
+	w := NewWriter("", false)
+	w.sink = out
+	return w
+}
+
+// Helper function which starts log writer loop, if it is not started yet.
+func (w *Writer) start() {
+	w.startOnce.Do(func() {
+		w.mu.Lock()
+		w.started = true
+		w.mu.Unlock()
+		w.waiter.Add(1)
+		go w.run()
+	})
+}
+
+// Helper function which runs log writer loop. It must be run in a separate
+// goroutine and finishes when records channel is closed or unrecoverable
+// error occurs.
+func (w *Writer) run() {
+	defer w.waiter.Done()
+	defer close(w.done)
+	defer func() {
+		w.mu.Lock()
+		w.closeCurrentFile(true)
+		w.closeSyslog()
+		w.mu.Unlock()
+	}()
+	idle := time.NewTimer(time.Hour)
+	idle.Stop()
+	defer idle.Stop()
+	for {
+		select {
+		case <-w.rot:
+			w.mu.Lock()
+			w.rotateWithRetries()
+			w.mu.Unlock()
+		case <-idle.C:
+			w.mu.Lock()
+			w.closeIdleFile()
+			w.mu.Unlock()
+		case <-w.flush:
+			// Records queued before flush request are written first, while
+			// some of them may be dropped meanwhile by overflow policy
+		queued:
+			for n := len(w.rec); n > 0; n-- {
+				select {
+				case rec, ok := <-w.rec:
+					if !ok || !w.writeQueued(rec, idle) {
 						return
 					}
+				default:
+					break queued
 				}
-				if err := w.write(rec); err != nil {
-					printErr(err)
-					return
-				}
+			}
+			w.flushed <- sig{}
+		case rec, ok := <-w.rec:
+			if !ok || !w.writeQueued(rec, idle) {
+				return
 			}
 		}
-	}()
+	}
+}
 
-	return w
+// Helper function which writes given record received from records channel
+// and restarts given idle timer. Returns false if writer loop must be stopped
+// due to unrecoverable error.
+func (w *Writer) writeQueued(rec *log.LogRecord, idle *time.Timer) bool {
+	w.mu.Lock()
+	err := w.writeRecord(rec)
+	idleAfter := w.idleRotate
+	w.mu.Unlock()
+	if err != nil {
+		w.printErr(err)
+		return false
+	}
+	resetTimer(idle, idleAfter)
+	return true
+}
+
+// Helper function to write given log record, opening and rotating files if
+// required. Transient errors cause record to be dropped and failed rotation
+// makes record to be written into current file, while returned error means
+// that logging cannot be continued. Mutex of writer must be held.
+func (w *Writer) writeRecord(rec *log.LogRecord) error {
+	if w.writer == nil {
+		if err := w.openNewFile(); err != nil {
+			if isTransientErr(err) {
+				w.dropRecord(err)
+				return nil
+			}
+			return err
+		}
+	}
+	if (w.maxlines > 0 && w.maxlinesCurlines >= w.maxlines) ||
+		(w.maxsize > 0 && w.maxsizeCursize >= w.maxsize) ||
+		w.period.crossed(w.openTime, time.Now()) {
+		w.rotateWithRetries()
+		// File closed for rotation may fail to be opened again
+		if w.writer == nil {
+			if err := w.openNewFile(); err != nil {
+				w.dropRecord(err)
+				return nil
+			}
+		}
+	}
+	if err := w.write(rec); err != nil {
+		if isTransientErr(err) {
+			w.dropRecord(err)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// Helper function for dropping log record which failed to be written due to
+// transient error. Current file is closed, so the next record will try to
+// open it again.
+func (w *Writer) dropRecord(e error) {
+	atomic.AddUint64(&w.stats.Dropped, 1)
+	w.printErr(fmt.Errorf("log record dropped: %s", e))
+	w.closeCurrentFile(false)
+}
+
+// Helper function to check whether given error is transient and logging can
+// be continued after it (for example, when disk space is freed).
+func isTransientErr(e error) bool {
+	switch err := e.(type) {
+	case *os.PathError:
+		e = err.Err
+	case *os.SyscallError:
+		e = err.Err
+	}
+	return isNoSpaceErr(e)
+}
+
+// Helper function for reporting errors occurred in log writer goroutine.
+func (w *Writer) printErr(e error) {
+	fmt.Fprintf(os.Stderr,
+		"imaginator/filelog.NewWriter(%q): %s\n", w.filename, e,
+	)
+}
+
+// Helper function which restarts given timer to fire after given duration,
+// draining its channel if it has already fired. Zero duration stops timer.
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	if d > 0 {
+		t.Reset(d)
+	}
+}
+
+// Helper function which closes current file, as no records arrived for idle
+// duration. If rotation is enabled, closed file is rotated as well, so it
+// can be shipped, while the next record opens a fresh file.
+func (w *Writer) closeIdleFile() {
+	if w.file == nil || w.sink != nil {
+		return
+	}
+	w.closeCurrentFile(false)
+	if w.rotate {
+		w.rotateWithRetries()
+	}
+}
+
+// Helper function which rotates log files, retrying failed rotation with
+// growing delay up to configured number of times. If all attempts fail,
+// error is reported and logging continues into current file.
+func (w *Writer) rotateWithRetries() {
+	err := w.doRotation()
+	delay := rotationRetryDelay
+	for i := 0; err != nil && i < w.rotationRetries; i++ {
+		time.Sleep(delay)
+		delay *= 2
+		err = w.doRotation()
+	}
+	if err != nil {
+		w.printErr(fmt.Errorf("%s, gave up after %d attempts", err, w.rotationRetries+1))
+	}
 }
 
-// Helper function to rotate logs files.
-func (w *Writer) doRotation() (e error) {
-	w.closeCurrentFile()
+// Helper function to rotate logs files. New file is opened before current one
+// is closed, so if opening fails current file remains in use and no records
+// are lost. Writer into custom destination is never rotated.
+func (w *Writer) doRotation() error {
+	if w.sink != nil {
+		return nil
+	}
+	if w.syncOnRotate && w.file != nil {
+		if err := syncFile(w.file); err != nil {
+			return fmt.Errorf("rotation failed: %s", err)
+		}
+	}
+	rotated, num := "", w.lastRotatedNum
 	if w.rotate {
-		err := os.Rename(w.filename, w.processAlreadyRotatedFiles())
-		if err != nil && !os.IsNotExist(err) {
+		rotated, num = w.nextRotatedFile()
+		if reservedDeviceNames && isDeviceName(filepath.Base(rotated)) {
+			return fmt.Errorf("rotation failed: '%s' is reserved device name", rotated)
+		}
+		if closeBeforeRename && w.file != nil {
+			return w.rotateClosed(rotated, num)
+		}
+		err := renameFile(w.filename, rotated)
+		if os.IsNotExist(err) {
+			rotated, num = "", w.lastRotatedNum
+		} else if err != nil {
 			return fmt.Errorf("rotation failed: %s", err)
 		}
 	}
-	if w.file != nil {
-		e = w.openNewFile()
+	if w.file == nil {
+		w.lastRotatedNum = num
+		atomic.AddUint64(&w.stats.Rotations, 1)
+		return nil
 	}
-	return
+	fd, err := openFile(w.filename, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0660)
+	if err != nil {
+		if rotated != "" {
+			renameFile(rotated, w.filename)
+		}
+		return fmt.Errorf("rotation failed: %s", err)
+	}
+	w.closeCurrentFile(false)
+	// Not kept file is overwritten only after its trailer is written, so
+	// that new file starts empty
+	if !w.rotate {
+		if err = fd.Truncate(0); err != nil {
+			// Logging continues into the same file
+			w.useFile(fd)
+			return fmt.Errorf("rotation failed: %s", err)
+		}
+	}
+	w.lastRotatedNum = num
+	atomic.AddUint64(&w.stats.Rotations, 1)
+	return w.useFile(fd)
+}
+
+// Helper function which rotates current file into given one of given number on
+// systems where opened file cannot be renamed. Current file is closed before
+// renaming, and is opened again if renaming fails, so that logging continues
+// into it.
+func (w *Writer) rotateClosed(rotated string, num int) error {
+	w.closeCurrentFile(false)
+	err := renameFile(w.filename, rotated)
+	if err != nil && !os.IsNotExist(err) {
+		if e := w.openNewFile(); e != nil {
+			return fmt.Errorf("rotation failed: %s, reopening failed: %s", err, e)
+		}
+		return fmt.Errorf("rotation failed: %s", err)
+	}
+	if err == nil {
+		w.lastRotatedNum = num
+	}
+	if err = w.openNewFile(); err != nil {
+		return fmt.Errorf("rotation failed: %s", err)
+	}
+	atomic.AddUint64(&w.stats.Rotations, 1)
+	return nil
+}
+
+// Windows device names, which cannot be used as names of files.
+var deviceNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// Returns whether given file name is Windows device name, which is checked
+// for part of name before the first dot, ignoring case and trailing spaces.
+func isDeviceName(name string) bool {
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[:i]
+	}
+	return deviceNames[strings.ToUpper(strings.TrimRight(name, " "))]
+}
+
+// Returns given file name without given prefix, and whether name has it.
+// Case is ignored on systems where names differ in case refer to the same
+// file.
+func trimNamePrefix(name, prefix string) (string, bool) {
+	if len(name) < len(prefix) {
+		return "", false
+	}
+	if caseInsensitiveNames {
+		if !strings.EqualFold(name[:len(prefix)], prefix) {
+			return "", false
+		}
+	} else if name[:len(prefix)] != prefix {
+		return "", false
+	}
+	return name[len(prefix):], true
+}
+
+// Helper function which returns name and number of next file to rotate into.
+// Directory is scanned for already rotated files only for the first rotation
+// or if expired files are removed, while otherwise number of the last rotated
+// file is remembered. Directory is rescanned if next file exists anyway (e.g.
+// it is created by external tool), so that it is never overwritten, while
+// files deleted externally just leave gaps in numbering.
+func (w *Writer) nextRotatedFile() (string, int) {
+	if !w.rotatedScanned || w.keepRotatedSeconds > 0 {
+		w.processAlreadyRotatedFiles()
+	} else if _, err := os.Lstat(w.rotatedFileName(w.lastRotatedNum + 1)); err == nil {
+		w.processAlreadyRotatedFiles()
+	}
+	return w.rotatedFileName(w.lastRotatedNum + 1), w.lastRotatedNum + 1
 }
 
 // Helper function to process already rotated files. It removes expired log
-// files if any and returns name of next file to rotate into.
+// files if any, remembers number of the last rotated file and returns name of
+// next file to rotate into.
 func (w *Writer) processAlreadyRotatedFiles() (fileNameForRotation string) {
 	dir := filepath.Dir(w.filename)
 	lastNum := 0
@@ -138,11 +531,8 @@ func (w *Writer) processAlreadyRotatedFiles() (fileNameForRotation string) {
 		now := time.Now()
 		for _, file := range files {
 			fileName := file.Name()
-			if file.IsDir() || !strings.HasPrefix(fileName, base) {
-				continue
-			}
-			suffix := strings.TrimPrefix(fileName, base)
-			if suffix == "" {
+			suffix, ok := trimNamePrefix(fileName, base)
+			if file.IsDir() || !ok || suffix == "" {
 				continue
 			}
 			num, _ := strconv.Atoi(strings.TrimPrefix(suffix, "."))
@@ -163,22 +553,49 @@ func (w *Writer) processAlreadyRotatedFiles() (fileNameForRotation string) {
 	if lastNum < 1 {
 		lastNum = 0
 	}
-	return w.filename + fmt.Sprintf(".%03d", lastNum+1)
+	w.lastRotatedNum, w.rotatedScanned = lastNum, true
+	return w.rotatedFileName(lastNum + 1)
 }
 
-// Helper function for opening new file to write logs into.
-func (w *Writer) openNewFile() (e error) {
-	fd, e := os.OpenFile(w.filename, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0660)
-	if e != nil {
-		return
+// Helper function which returns name of rotated file with given number.
+func (w *Writer) rotatedFileName(num int) string {
+	width := w.suffixWidth
+	if width < 1 {
+		width = defaultSuffixWidth
 	}
+	return w.filename + fmt.Sprintf(".%0*d", width, num)
+}
+
+// Helper function for opening new file to write logs into, or starting to
+// write into custom destination.
+func (w *Writer) openNewFile() error {
+	if w.sink != nil {
+		w.writer = w.sink
+		w.writeHeader()
+		return nil
+	}
+	fd, err := openFile(w.filename, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0660)
+	if err != nil {
+		return err
+	}
+	return w.useFile(fd)
+}
+
+// Helper function which makes given opened file current one to write logs
+// into.
+func (w *Writer) useFile(fd *os.File) (e error) {
 	w.file = fd
-	w.writer = io.MultiWriter(fd, os.Stdout)
+	w.useEcho()
+	if w.currentSymlink != "" {
+		if err := w.updateCurrentSymlink(); err != nil {
+			w.printErr(err)
+		}
+	}
 	fi, e := fd.Stat()
 	if e != nil {
 		return
 	}
-	w.dailyOpenDate = fi.ModTime().Format(dayFormat)
+	w.openTime = fi.ModTime()
 	w.maxsizeCursize = uint64(fi.Size())
 	if w.maxlinesCurlines, e = func() (num uint64, _ error) {
 		scanner := bufio.NewScanner(w.file)
@@ -189,118 +606,584 @@ func (w *Writer) openNewFile() (e error) {
 	}(); e != nil {
 		return
 	}
-	fmt.Fprint(w.writer,
-		log.FormatLogRecord(w.header, &log.LogRecord{Created: time.Now()}),
-	)
+	w.writeHeader()
 	return
 }
 
-// Helper function for closing current opened file if any.
-func (w *Writer) closeCurrentFile() {
-	if w.file == nil {
+// Helper function which makes records to be written into current file and
+// duplicated to standard output if echo is enabled.
+func (w *Writer) useEcho() {
+	if !w.echoStdout {
+		w.writer = w.file
 		return
 	}
-	fmt.Fprint(w.writer,
-		log.FormatLogRecord(w.trailer, &log.LogRecord{Created: time.Now()}),
-	)
-	if err := w.file.Close(); err != nil {
-		log.Stderrf("Failed to close file: %v", err)
+	w.writer = io.MultiWriter(w.file, os.Stdout)
+}
+
+// Helper function which writes header into current file, unless it is
+// already written once and should not be repeated. Header is counted in size
+// and lines of file, so that rotated files do not exceed their limits.
+func (w *Writer) writeHeader() {
+	if !w.json && !(w.headFootOnce && w.headerWritten) {
+		header := log.FormatLogRecord(w.header, &log.LogRecord{Created: time.Now()})
+		n, _ := w.print(w.writer, header)
+		w.maxlinesCurlines += uint64(strings.Count(header, "\n"))
+		w.maxsizeCursize += uint64(n)
+		w.headerWritten = true
+	}
+}
+
+// Helper function for pointing current symlink to the opened log file.
+// Symlink is replaced atomically: new one is created under temporary name and
+// then renamed over the old one, so tailers never see missing link.
+func (w *Writer) updateCurrentSymlink() error {
+	target, err := filepath.Abs(w.filename)
+	if err != nil {
+		return fmt.Errorf("updating current symlink failed: %s", err)
+	}
+	tmp := w.currentSymlink + ".tmp"
+	if err = os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("updating current symlink failed: %s", err)
 	}
+	if err = os.Symlink(target, tmp); err != nil {
+		return fmt.Errorf("updating current symlink failed: %s", err)
+	}
+	if err = os.Rename(tmp, w.currentSymlink); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("updating current symlink failed: %s", err)
+	}
+	return nil
+}
+
+// Helper function for closing current opened file if any. Trailer is written
+// into closed file exactly once, as file is forgotten after closing, so
+// neither rotation nor closing of writer can write it again. If header and
+// trailer are written once, trailer is written only when file is closed
+// finally, as writer is closed.
+func (w *Writer) closeCurrentFile(final bool) {
+	if w.writer == nil {
+		return
+	}
+	if !w.json && (final || !w.headFootOnce) {
+		w.print(w.writer,
+			log.FormatLogRecord(w.trailer, &log.LogRecord{Created: time.Now()}),
+		)
+	}
+	if w.file != nil {
+		// Trailer written after rotated file is synced must be durable too
+		if w.syncOnRotate {
+			if err := syncFile(w.file); err != nil {
+				log.Stderrf("Failed to sync file: %v", err)
+			}
+		}
+		if err := w.file.Close(); err != nil {
+			log.Stderrf("Failed to close file: %v", err)
+		}
+	}
+	w.file, w.writer = nil, nil
+}
+
+// Helper function which prints given text into given destination. Mutex of
+// writer must be held, and it is released while text is written, so that
+// hanging destination does not block setters and closing of writer. Current
+// file is changed by writer loop only, so it stays the same meanwhile.
+func (w *Writer) print(out io.Writer, text string) (int, error) {
+	w.mu.Unlock()
+	defer w.mu.Lock()
+	return fmt.Fprint(out, text)
 }
 
 // Helper function to write given log record into current opened file.
 //
-// Attention: File must be opened to avoid nil pointer failure!
+// Attention: File must be opened and mutex must be held to avoid failure!
 func (w *Writer) write(rec *log.LogRecord) (e error) {
-	n, e := fmt.Fprint(w.writer, log.FormatLogRecord(w.format, rec))
+	msg, e := w.formatRecord(rec)
+	if e != nil {
+		return
+	}
+	n, e := w.print(w.writer, msg)
 	if e != nil {
 		return
 	}
-	w.maxlinesCurlines++
+	w.maxlinesCurlines += uint64(strings.Count(msg, "\n"))
 	w.maxsizeCursize += uint64(n)
+	atomic.AddUint64(&w.stats.BytesWritten, uint64(n))
+	if w.syslog != nil {
+		w.syslog.send(rec.Level, rec.Message)
+	}
 	return
 }
 
-// LogWrite writes given log record into file. Implementation of
-// log4go.LogWriter interface.
+// Helper function to format given log record accordingly to current settings.
+func (w *Writer) formatRecord(rec *log.LogRecord) (string, error) {
+	if !w.json {
+		return log.FormatLogRecord(w.format, rec), nil
+	}
+	data, err := json.Marshal(&jsonRecord{
+		Level:   rec.Level.String(),
+		Created: rec.Created,
+		Source:  rec.Source,
+		Message: rec.Message,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// Represents log record written in JSON mode.
+type jsonRecord struct {
+	Level   string    `json:"level"`
+	Created time.Time `json:"created"`
+	Source  string    `json:"source,omitempty"`
+	Message string    `json:"message"`
+}
+
+// LogWrite writes given log record into file. Nil records are ignored.
+// Implementation of log4go.LogWriter interface.
 func (w *Writer) LogWrite(rec *log.LogRecord) {
-	w.rec <- rec
+	if rec == nil {
+		return
+	}
+	w.enqueue(rec)
+}
+
+// Helper function which queues given record to be written, accordingly to
+// overflow policy when records buffer is full. Records queued after writer
+// is closed are dropped and counted as overflowed.
+func (w *Writer) enqueue(rec *log.LogRecord) {
+	w.start()
+	if w.isClosed() {
+		atomic.AddUint64(&w.stats.Overflowed, 1)
+		return
+	}
+	// Writer may be closed concurrently, so send on closed channel is
+	// recovered
+	defer func() {
+		if recover() != nil {
+			atomic.AddUint64(&w.stats.Overflowed, 1)
+		}
+	}()
+	policy := OverflowPolicy(atomic.LoadInt32(&w.overflow))
+	if policy == Block {
+		w.rec <- rec
+		return
+	}
+	for {
+		select {
+		case w.rec <- rec:
+			return
+		default:
+		}
+		if policy != DropOldest {
+			atomic.AddUint64(&w.stats.Overflowed, 1)
+			return
+		}
+		w.dropOldest()
+	}
+}
+
+// Helper function which drops the oldest queued record to make room in
+// records buffer.
+func (w *Writer) dropOldest() {
+	select {
+	case <-w.rec:
+		atomic.AddUint64(&w.stats.Overflowed, 1)
+	default:
+		// Buffer was drained meanwhile
+	}
+}
+
+// Flush blocks until all log records queued before its call are written into
+// file. Unlike .Close() method, log writer remains usable after it.
+// Does nothing after writer is closed or its loop is stopped by error.
+func (w *Writer) Flush() {
+	w.flushMu.Lock()
+	defer w.flushMu.Unlock()
+	w.start()
+	if w.isClosed() {
+		return
+	}
+	// Writer loop finishes when writer is closed concurrently
+	select {
+	case w.flush <- sig{}:
+	case <-w.done:
+		return
+	}
+	select {
+	case <-w.flushed:
+	case <-w.done:
+	}
+}
+
+// Write writes given bytes into file as a message of INFO log record.
+// Implementation of io.Writer interface, so Writer can be used as output of
+// standard library log.Logger.
+//
+// Given bytes are not written raw, but are formatted accordingly to the
+// format set by .SetFormat() method (with single trailing newline trimmed), so
+// use "%M" format to write bytes as is. Written data is counted for rotation
+// in the same way as other log records.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.enqueue(&log.LogRecord{
+		Level:   log.INFO,
+		Created: time.Now(),
+		Message: strings.TrimSuffix(string(p), "\n"),
+	})
+	return len(p), nil
 }
 
 // Close closes current log writer and resources connected with it. By default
 // acts asynchronous, which means that method doesn't wait log writer to be
-// closed. To change this behaviour you must use .SetWaitOnClose() method.
+// closed. To change this behaviour you must use .SetWaitOnClose() method, and
+// .SetCloseTimeout() method to limit waiting. Calling it again does nothing,
+// and records logged after it are dropped.
 // Implementation of log4go.LogWriter interface.
 func (w *Writer) Close() {
-	close(w.rec)
-	if w.waitOnClose {
+	w.start()
+	w.closeOnce.Do(func() {
+		atomic.StoreInt32(&w.closed, 1)
+		close(w.rec)
+	})
+	wait := atomic.LoadInt32(&w.waitOnClose) == 1
+	timeout := time.Duration(atomic.LoadInt64(&w.closeTimeout))
+	if !wait {
+		return
+	}
+	if timeout == 0 {
 		w.waiter.Wait()
+		return
+	}
+	done := make(chan sig)
+	go func() {
+		w.waiter.Wait()
+		close(done)
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		w.printErr(fmt.Errorf(
+			"writer is not closed cleanly, it did not finish within %s", timeout,
+		))
+	}
+}
+
+// Returns whether writer is closed with .Close() method.
+func (w *Writer) isClosed() bool {
+	return atomic.LoadInt32(&w.closed) == 1
+}
+
+// Dropped returns number of log records which were dropped due to transient
+// write errors (like ENOSPC or EDQUOT).
+func (w *Writer) Dropped() uint64 {
+	return atomic.LoadUint64(&w.stats.Dropped)
+}
+
+// Stats returns current statistics of log writer.
+func (w *Writer) Stats() Stats {
+	return Stats{
+		Rotations:     atomic.LoadUint64(&w.stats.Rotations),
+		BytesWritten:  atomic.LoadUint64(&w.stats.BytesWritten),
+		Dropped:       atomic.LoadUint64(&w.stats.Dropped),
+		Overflowed:    atomic.LoadUint64(&w.stats.Overflowed),
+		SyslogDropped: atomic.LoadUint64(&w.stats.SyslogDropped),
 	}
 }
 
 // Rotate requests current log rotation.
 func (w *Writer) Rotate() {
+	w.start()
 	w.rot <- true
 }
 
-// SetFormat sets the logging format (chainable). Must be called before the
-// first log message is written.
+// Verbs of format which are understood by log4go.FormatLogRecord.
+const formatVerbs = "TtDdLSsM"
+
+// ValidateFormat checks that given format of log records, header or footer
+// contains only verbs understood by log4go.FormatLogRecord: %T, %t, %D, %d,
+// %L, %S, %s and %M. Percent sign cannot be escaped, so "%%" and dangling
+// "%" are invalid as well.
+func ValidateFormat(format string) error {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		if i+1 == len(format) {
+			return fmt.Errorf("invalid format %q: dangling %% at the end", format)
+		}
+		i++
+		if !strings.ContainsRune(formatVerbs, rune(format[i])) {
+			return fmt.Errorf(
+				"invalid format %q: unknown verb %%%c at position %d",
+				format, format[i], i-1,
+			)
+		}
+	}
+	return nil
+}
+
+// SetBufferLength sets number of log records which can be queued to be written
+// into file (chainable). By default is log4go.LogBufferLength. Longer buffer
+// absorbs bursts of logging without blocking callers of .LogWrite() method
+// while file is written or rotated, at the cost of memory held by queued
+// records (and records lost if process crashes). Shorter buffer applies
+// backpressure to callers earlier. Buffer length can be set only before the
+// first use of writer, later calls are reported and ignored.
+func (w *Writer) SetBufferLength(n int) *Writer {
+	if n < 0 {
+		w.printErr(fmt.Errorf("invalid buffer length %d", n))
+		return w
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.started {
+		w.printErr(fmt.Errorf("buffer length cannot be changed after writer is used"))
+		return w
+	}
+	w.rec = make(chan *log.LogRecord, n)
+	return w
+}
+
+// SetOverflowPolicy sets what happens with log records when records buffer is
+// full (chainable). By default writing of record blocks until there is room in
+// buffer, so slow disk slows down the whole application. Drop policies keep
+// callers responsive instead, counting dropped records in Overflowed stats.
+// Can be safely called while logging.
+func (w *Writer) SetOverflowPolicy(policy OverflowPolicy) *Writer {
+	atomic.StoreInt32(&w.overflow, int32(policy))
+	return w
+}
+
+// SetFormat sets the logging format (chainable). Can be safely called while
+// logging. Invalid format is reported and ignored, use .SetFormatErr() method
+// to handle it.
 func (w *Writer) SetFormat(format string) *Writer {
+	if err := w.SetFormatErr(format); err != nil {
+		w.printErr(err)
+	}
+	return w
+}
+
+// SetFormatErr sets the logging format, or returns error if format is
+// invalid (see ValidateFormat). Can be safely called while logging.
+func (w *Writer) SetFormatErr(format string) error {
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	w.format = format
+	return nil
+}
+
+// SetJSON makes log records to be written as JSON objects, one per line,
+// instead of using format set by .SetFormat() method (chainable). Header and
+// footer are not written in this mode. Can be safely called while logging.
+func (w *Writer) SetJSON(yes bool) *Writer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.json = yes
 	return w
 }
 
-// SetHeadFoot sets the log file header and footer (chainable). Must be called
-// before the first log message is written. These are formatted similar to the
-// log4go.FormatLogRecord (e.g. you can use %D and %T in your header/footer for
-// date and time).
+// SetHeadFoot sets the log file header and footer (chainable). Can be safely
+// called while logging, and takes effect on the next opened file. These are
+// formatted similar to the log4go.FormatLogRecord (e.g. you can use %D and %T
+// in your header/footer for date and time). Invalid header or footer is
+// reported and ignored, use .SetHeadFootErr() method to handle it.
 func (w *Writer) SetHeadFoot(head, foot string) *Writer {
+	if err := w.SetHeadFootErr(head, foot); err != nil {
+		w.printErr(err)
+	}
+	return w
+}
+
+// SetHeadFootErr sets the log file header and footer, or returns error if any
+// of them is invalid (see ValidateFormat). Can be safely called while logging.
+func (w *Writer) SetHeadFootErr(head, foot string) error {
+	for _, format := range []string{head, foot} {
+		if err := ValidateFormat(format); err != nil {
+			return err
+		}
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	w.header, w.trailer = head, foot
+	return nil
+}
+
+// SetHeadFootOnce makes the header to be written only into the very first
+// opened file and the trailer only when writer is closed, instead of into
+// each rotated file (chainable). If is not set, by default is false. Can be
+// safely called while logging.
+func (w *Writer) SetHeadFootOnce(yes bool) *Writer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.headFootOnce = yes
+	return w
+}
+
+// SetRotationRetries sets how many times failed rotation is retried before
+// giving up (chainable). Retries are made with delay of 10ms, which doubles
+// after each retry. If is not set, by default failed rotation is retried 3
+// times. If all attempts fail, logging continues into current file. Can be
+// safely called while logging.
+func (w *Writer) SetRotationRetries(n int) *Writer {
+	if n < 0 {
+		w.printErr(fmt.Errorf("invalid number of rotation retries %d", n))
+		return w
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rotationRetries = n
+	return w
+}
+
+// SetIdleRotate makes current file to be rotated, or just closed if rotation
+// is disabled, when no record arrives for given duration (chainable). The next
+// record opens a new file. If is not set, by default is 0, which means file is
+// kept opened regardless of idle time. Change applies after the next record.
+// Can be safely called while logging.
+func (w *Writer) SetIdleRotate(d time.Duration) *Writer {
+	if d < 0 {
+		w.printErr(fmt.Errorf("invalid idle rotation duration %s", d))
+		return w
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.idleRotate = d
+	return w
+}
+
+// SetSyncOnRotate makes file to be flushed to disk before it is rotated, so
+// that rotated file is durable before the next one is started (chainable).
+// Failed flush fails rotation, which is retried as usual. File is flushed
+// again when it is closed, so trailer is durable too. If is not set, by
+// default is false. Can be safely called while logging.
+func (w *Writer) SetSyncOnRotate(yes bool) *Writer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.syncOnRotate = yes
 	return w
 }
 
-// SetRotateLines sets rotate at linecount (chainable). Must be called before
-// the first log message is written.
+// SetEchoStdout sets whether records written into file are duplicated to
+// standard output (chainable). If is not set, by default is true. Writer into
+// custom destination never duplicates records. Can be safely called while
+// logging.
+func (w *Writer) SetEchoStdout(yes bool) *Writer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.echoStdout = yes
+	if w.file != nil {
+		w.useEcho()
+	}
+	return w
+}
+
+// SetRotateLines sets rotate at linecount (chainable). Can be safely called
+// while logging.
 func (w *Writer) SetRotateLines(maxlines int) *Writer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	w.maxlines = uint64(maxlines)
 	return w
 }
 
-// SetRotateSize sets rotate at size (chainable). Must be called before the
-// first log message is written.
+// SetRotateSize sets rotate at size (chainable). Can be safely called while
+// logging.
 func (w *Writer) SetRotateSize(maxsize int) *Writer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	w.maxsize = uint64(maxsize)
 	return w
 }
 
-// SetRotateDaily sets rotate daily (chainable). Must be called before the first
-// log message is written.
+// SetRotateDaily sets rotate daily (chainable). Can be safely called while
+// logging. It is a shortcut for .SetRotatePeriod(Daily).
 func (w *Writer) SetRotateDaily(daily bool) *Writer {
-	w.daily = daily
+	if !daily {
+		return w.SetRotatePeriod(None)
+	}
+	return w.SetRotatePeriod(Daily)
+}
+
+// SetRotatePeriod sets rotate on boundaries of given calendar period
+// (chainable). Can be safely called while logging.
+func (w *Writer) SetRotatePeriod(period Period) *Writer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.period = period
 	return w
 }
 
-// SetRotate changes whether or not the old logs are kept (chainable). Must be
-// called before the first log message is written. If rotate is false, the files
-// are overwritten; otherwise, they are rotated to another file before the new
-// log is opened.
+// SetRotate changes whether or not the old logs are kept (chainable). Can be
+// safely called while logging. If rotate is false, the files are overwritten;
+// otherwise, they are rotated to another file before the new log is opened.
 func (w *Writer) SetRotate(rotate bool) *Writer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	w.rotate = rotate
 	return w
 }
 
+// SetSuffixWidth sets width of zero-padded numeric suffix of rotated files
+// (chainable). By default is 3 (.001, .002, etc). Already rotated files are
+// recognized regardless of their suffix width. Can be safely called while
+// logging.
+func (w *Writer) SetSuffixWidth(n int) *Writer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.suffixWidth = n
+	return w
+}
+
 // SetRotatedFilesExpiration sets duration (in seconds) of how long already
 // rotated files must be kept (chainable). If is not set, then files will be
 // kept always.
 func (w *Writer) SetRotatedFilesExpiration(seconds uint64) *Writer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	w.keepRotatedSeconds = time.Duration(seconds) * time.Second
 	return w
 }
 
+// SetCurrentSymlink sets path of symlink which is updated to point to the
+// currently opened log file each time new file is opened (chainable). Can be
+// safely called while logging, and takes effect on the next opened file. If
+// symlinks are not supported by platform, error is reported and logging
+// continues without it.
+func (w *Writer) SetCurrentSymlink(path string) *Writer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.currentSymlink = path
+	return w
+}
+
 // SetWaitOnClose makes .Close() method to wait until Writer will be totally
 // closed. If is not set, by default is false, which means .Close() method to
 // act asynchronous.
 func (w *Writer) SetWaitOnClose(yes bool) *Writer {
-	w.waitOnClose = yes
+	wait := int32(0)
+	if yes {
+		wait = 1
+	}
+	atomic.StoreInt32(&w.waitOnClose, wait)
+	return w
+}
+
+// SetCloseTimeout limits time which .Close() method waits for Writer to be
+// closed, when it is set to wait (chainable). When time is out, .Close()
+// returns and reports that writer is not closed cleanly, while writer keeps
+// finishing in background. If is not set, by default is 0, which means
+// .Close() method waits forever. Can be safely called while logging.
+func (w *Writer) SetCloseTimeout(d time.Duration) *Writer {
+	if d < 0 {
+		w.printErr(fmt.Errorf("invalid close timeout %s", d))
+		return w
+	}
+	atomic.StoreInt64(&w.closeTimeout, int64(d))
 	return w
 }
@@ -1,13 +1,20 @@
 package filelog
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"syscall"
 	"testing"
 	"time"
+
+	l4g "github.com/alecthomas/log4go"
 )
 
 var (
@@ -51,6 +58,38 @@ func removeTestFiles(dirName string) {
 	}
 }
 
+// Helper function which writes given record as writer loop does, holding
+// mutex of writer.
+func writeLocked(w *Writer, rec *l4g.LogRecord) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.writeRecord(rec)
+}
+
+// Helper function which opens new file as writer loop does, holding mutex of
+// writer.
+func openLocked(w *Writer) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.openNewFile()
+}
+
+// Helper function which rotates file as writer loop does, holding mutex of
+// writer.
+func rotateLocked(w *Writer) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.doRotation()
+}
+
+// Helper function which finally closes current file as writer loop does,
+// holding mutex of writer.
+func closeLocked(w *Writer) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closeCurrentFile(true)
+}
+
 func TestProcessAlreadyRotatedFiles(t *testing.T) {
 	test := func(bunch map[string]uint32, filename string, keepSeconds uint64, filesKept map[string]bool, nextFilename string) {
 		dir := createTestFiles(bunch)
@@ -93,8 +132,27 @@ func TestProcessAlreadyRotatedFiles(t *testing.T) {
 	}, "super-test.log.001")
 }
 
+func TestSetSuffixWidth(t *testing.T) {
+	dir := createTestFiles(map[string]uint32{
+		"testing.log":     100,
+		"testing.log.002": 100,
+		"testing.log.7":   100,
+	})
+	defer removeTestFiles(dir)
+
+	w := &Writer{
+		filename: filepath.Join(dir, "testing.log"),
+	}
+	w.SetSuffixWidth(5)
+
+	fName := w.processAlreadyRotatedFiles()
+	if needName := filepath.Join(dir, "testing.log.00008"); fName != needName {
+		t.Errorf("fileNameForRotation expected '%s', got '%s'", needName, fName)
+	}
+}
+
 func TestOpenNewFile(t *testing.T) {
-	test := func(bunch map[string]uint32, filename, dailyOpenDate string, lines, size uint64) {
+	test := func(bunch map[string]uint32, filename string, openTime time.Time, lines, size uint64) {
 		dir := createTestFiles(bunch)
 		defer removeTestFiles(dir)
 
@@ -103,10 +161,10 @@ func TestOpenNewFile(t *testing.T) {
 			waiter:   &sync.WaitGroup{},
 		}
 
-		if err := w.openNewFile(); err != nil {
+		if err := openLocked(w); err != nil {
 			t.Error("failed to open file")
 		}
-		defer w.closeCurrentFile()
+		defer closeLocked(w)
 
 		if w.maxlinesCurlines != lines {
 			t.Errorf("maxlinesCurlines expected %d, got %d", lines, w.maxlinesCurlines)
@@ -114,13 +172,13 @@ func TestOpenNewFile(t *testing.T) {
 		if w.maxsizeCursize != size {
 			t.Errorf("maxsizeCursize expected %d, got %d", size, w.maxsizeCursize)
 		}
-		if w.dailyOpenDate != dailyOpenDate {
-			t.Errorf("dailyOpenDate expected '%s', got '%s'", dailyOpenDate, w.dailyOpenDate)
+		if Daily.crossed(w.openTime, openTime) {
+			t.Errorf("openTime day expected '%s', got '%s'", openTime, w.openTime)
 		}
 	}
 
-	test(bunch1, "testing.log", time.Now().Add(-86400*time.Second).Format(dayFormat), 1, 9)
-	test(bunch2, "test.log", time.Now().Format(dayFormat), 0, 0)
+	test(bunch1, "testing.log", time.Now().Add(-86400*time.Second), 1, 9)
+	test(bunch2, "test.log", time.Now(), 0, 0)
 }
 
 func TestSetWaitOnClose(t *testing.T) {
@@ -133,12 +191,945 @@ func TestSetWaitOnClose(t *testing.T) {
 	}
 	w.SetWaitOnClose(true)
 
-	if err := w.openNewFile(); err != nil {
+	if err := openLocked(w); err != nil {
 		t.Error("failed to open file")
 	}
-	w.closeCurrentFile()
+	fd := w.file
+	closeLocked(w)
 
-	if int(w.file.Fd()) != -1 {
+	if int(fd.Fd()) != -1 {
 		t.Errorf("waiting failed, file is still not closed")
 	}
 }
+
+// Writer which blocks until its channel is closed, like file wedged in sync
+// syscall.
+type blockedWriter chan sig
+
+func (w blockedWriter) Write(p []byte) (int, error) {
+	<-w
+	return len(p), nil
+}
+
+func TestSetCloseTimeout(t *testing.T) {
+	blocked := make(blockedWriter)
+	w := NewWriterTo(blocked)
+	w.SetFormat("%M").SetWaitOnClose(true).SetCloseTimeout(50 * time.Millisecond)
+	w.LogWrite(&l4g.LogRecord{Level: l4g.INFO, Message: "stuck"})
+
+	closed := make(chan sig)
+	go func() {
+		w.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Close() did not return within timeout of blocked writer")
+	}
+
+	// Settings can be changed while writer is blocked
+	set := make(chan sig)
+	go func() {
+		w.SetFormat("%L %M")
+		close(set)
+	}()
+	select {
+	case <-set:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("SetFormat() is blocked by blocked writer")
+	}
+
+	// Writer finishes in background when it is unblocked
+	close(blocked)
+	w.waiter.Wait()
+}
+
+func TestSetCurrentSymlink(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+
+	link := filepath.Join(dir, "current.log")
+	w := &Writer{
+		filename: filepath.Join(dir, "super-test.log"),
+		waiter:   &sync.WaitGroup{},
+	}
+	w.SetCurrentSymlink(link)
+
+	for i := 0; i < 2; i++ {
+		if err := openLocked(w); err != nil {
+			t.Fatal("failed to open file")
+		}
+		closeLocked(w)
+
+		target, err := os.Readlink(link)
+		if err != nil {
+			t.Fatalf("failed to read symlink '%s', reason: %s", link, err.Error())
+		}
+		if needTarget, _ := filepath.Abs(w.filename); target != needTarget {
+			t.Errorf("symlink target expected '%s', got '%s'", needTarget, target)
+		}
+	}
+	if _, err := os.Lstat(link + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary symlink '%s.tmp' should not be left", link)
+	}
+}
+
+type diskFullWriter struct{}
+
+func (diskFullWriter) Write([]byte) (int, error) {
+	return 0, &os.PathError{Op: "write", Path: "disk", Err: syscall.ENOSPC}
+}
+
+func TestWriteTransientError(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+
+	w := &Writer{
+		rec:      make(chan *l4g.LogRecord, 2),
+		rot:      make(chan bool),
+		done:     make(chan sig),
+		filename: filepath.Join(dir, "super-test.log"),
+		format:   "%M",
+		waiter:   &sync.WaitGroup{},
+	}
+	w.SetWaitOnClose(true)
+
+	if err := openLocked(w); err != nil {
+		t.Fatal("failed to open file")
+	}
+	w.writer = diskFullWriter{}
+
+	w.LogWrite(&l4g.LogRecord{Message: "dropped"})
+	w.LogWrite(&l4g.LogRecord{Message: "recovered"})
+	w.Close()
+
+	if w.Dropped() != 1 {
+		t.Errorf("dropped records expected %d, got %d", 1, w.Dropped())
+	}
+	data, err := ioutil.ReadFile(w.filename)
+	if err != nil {
+		t.Fatalf("failed to read log file '%s', reason: %s", w.filename, err.Error())
+	}
+	if !strings.Contains(string(data), "recovered") {
+		t.Errorf("log record was not written after recovery")
+	}
+	if strings.Contains(string(data), "dropped") {
+		t.Errorf("dropped log record was written")
+	}
+}
+
+type brokenWriter struct{}
+
+func (brokenWriter) Write([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestFlushAfterError(t *testing.T) {
+	w := NewWriterTo(brokenWriter{})
+	w.LogWrite(&l4g.LogRecord{Message: "first"})
+	w.waiter.Wait()
+
+	// Writer loop is stopped by unrecoverable error, so flush must not wait
+	// for it
+	flushed := make(chan sig)
+	go func() {
+		w.Flush()
+		close(flushed)
+	}()
+	select {
+	case <-flushed:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Flush() hangs after writer loop is stopped by error")
+	}
+	w.Close()
+}
+
+func TestSettersWhileLogging(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+
+	w := NewWriter(filepath.Join(dir, "super-test.log"), true)
+	w.SetWaitOnClose(true)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			w.SetRotateSize(100 + i).SetRotateLines(10 + i).SetFormat("%M")
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		w.LogWrite(&l4g.LogRecord{Message: "test", Created: time.Now()})
+	}
+	<-done
+	w.Close()
+}
+
+func TestWrite(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+
+	w := NewWriter(filepath.Join(dir, "write-test.log"), true)
+	w.SetFormat("%M").SetRotateLines(2).SetWaitOnClose(true)
+
+	lgr := log.New(w, "", 0)
+	lgr.Print("first")
+	lgr.Print("second\nthird")
+	lgr.Print("fourth")
+	w.Close()
+
+	rotated, err := ioutil.ReadFile(w.filename + ".001")
+	if err != nil {
+		t.Fatalf("failed to read rotated log file, reason: %s", err.Error())
+	}
+	if string(rotated) != "first\nsecond\nthird\n" {
+		t.Errorf("rotated log file content is unexpected: %q", rotated)
+	}
+	data, err := ioutil.ReadFile(w.filename)
+	if err != nil {
+		t.Fatalf("failed to read log file '%s', reason: %s", w.filename, err.Error())
+	}
+	if string(data) != "fourth\n" {
+		t.Errorf("log file content is unexpected: %q", data)
+	}
+	if stats := w.Stats(); stats.Rotations != 1 || stats.BytesWritten != 26 {
+		t.Errorf("stats expected 1 rotation and 26 bytes, got %+v", stats)
+	}
+}
+
+func TestRotationRetries(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+	defer func(f func(string, string) error) {
+		renameFile = f
+	}(renameFile)
+
+	w := &Writer{
+		filename:        filepath.Join(dir, "retry-test.log"),
+		format:          "%M",
+		rotate:          true,
+		maxlines:        1,
+		rotationRetries: 2,
+		waiter:          &sync.WaitGroup{},
+	}
+	defer closeLocked(w)
+	if err := writeLocked(w, &l4g.LogRecord{Message: "first"}); err != nil {
+		t.Fatalf("failed to write record, reason: %s", err.Error())
+	}
+
+	// Rename fails given number of times before it succeeds
+	failRenames := func(n int) {
+		renameFile = func(from, to string) error {
+			if n > 0 {
+				n--
+				return &os.LinkError{Op: "rename", Old: from, New: to, Err: syscall.EBUSY}
+			}
+			return os.Rename(from, to)
+		}
+	}
+	failRenames(2)
+	if err := writeLocked(w, &l4g.LogRecord{Message: "second"}); err != nil {
+		t.Fatalf("failed to write record, reason: %s", err.Error())
+	}
+	if w.Stats().Rotations != 1 {
+		t.Errorf("rotation expected to succeed on the last retry")
+	}
+
+	failRenames(3)
+	if err := writeLocked(w, &l4g.LogRecord{Message: "third"}); err != nil {
+		t.Fatalf("failed rotation must not stop logging, got: %s", err.Error())
+	}
+	if w.Stats().Rotations != 1 {
+		t.Errorf("rotation expected to give up after all retries")
+	}
+	data, err := ioutil.ReadFile(w.filename)
+	if err != nil {
+		t.Fatalf("failed to read log file '%s', reason: %s", w.filename, err.Error())
+	}
+	if string(data) != "second\nthird\n" {
+		t.Errorf("log file content is unexpected: %q", data)
+	}
+}
+
+// Enables behavior of Windows file system and returns function restoring it.
+func emulateWindows() (restore func()) {
+	names, closing, devices := caseInsensitiveNames, closeBeforeRename, reservedDeviceNames
+	caseInsensitiveNames, closeBeforeRename, reservedDeviceNames = true, true, true
+	return func() {
+		caseInsensitiveNames, closeBeforeRename, reservedDeviceNames = names, closing, devices
+	}
+}
+
+func TestWindowsRotation(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+	defer emulateWindows()()
+	defer func(f func(string, string) error) {
+		renameFile = f
+	}(renameFile)
+
+	w := &Writer{
+		filename:        filepath.Join(dir, "win-test.log"),
+		format:          "%M",
+		rotate:          true,
+		maxlines:        1,
+		rotationRetries: 1,
+		waiter:          &sync.WaitGroup{},
+	}
+	defer closeLocked(w)
+	// Rotated file named in other case is found
+	if err := ioutil.WriteFile(filepath.Join(dir, "WIN-TEST.LOG.004"), nil, 0660); err != nil {
+		t.Fatalf("failed to create rotated file, reason: %s", err.Error())
+	}
+	// Opened file is locked, and rename fails given number of times anyway
+	failRenames := func(n int) {
+		renameFile = func(from, to string) error {
+			if w.file != nil || n > 0 {
+				n--
+				return &os.LinkError{Op: "rename", Old: from, New: to, Err: syscall.EACCES}
+			}
+			return os.Rename(from, to)
+		}
+	}
+	failRenames(0)
+	for _, msg := range []string{"first", "second"} {
+		if err := writeLocked(w, &l4g.LogRecord{Message: msg}); err != nil {
+			t.Fatalf("failed to write record, reason: %s", err.Error())
+		}
+	}
+	if data, _ := ioutil.ReadFile(w.filename + ".005"); string(data) != "first\n" {
+		t.Errorf("rotated log file content is unexpected: %q", data)
+	}
+
+	failRenames(2)
+	if err := writeLocked(w, &l4g.LogRecord{Message: "third"}); err != nil {
+		t.Fatalf("failed rotation must not stop logging, got: %s", err.Error())
+	}
+	closeLocked(w)
+	if data, _ := ioutil.ReadFile(w.filename); string(data) != "second\nthird\n" {
+		t.Errorf("log file content is unexpected: %q", data)
+	}
+	if w.Stats().Rotations != 1 {
+		t.Errorf("expected 1 rotation, got %d", w.Stats().Rotations)
+	}
+
+	// Rotated file would have reserved name
+	w = &Writer{filename: filepath.Join(dir, "com1"), rotate: true}
+	if err := rotateLocked(w); err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Errorf("expected error of reserved name, got: %v", err)
+	}
+}
+
+func TestIsDeviceName(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"NUL":          true,
+		"com1.log.001": true,
+		"Con .txt":     true,
+		"console.log":  false,
+		"COM10":        false,
+		"app.log.001":  false,
+	} {
+		if isDeviceName(name) != expected {
+			t.Errorf("'%s' expected to be device name: %t", name, expected)
+		}
+	}
+}
+
+func TestRotationOpenFailure(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+	defer func(f func(string, int, os.FileMode) (*os.File, error)) {
+		openFile = f
+	}(openFile)
+
+	w := &Writer{
+		filename: filepath.Join(dir, "rotation-test.log"),
+		format:   "%M",
+		rotate:   true,
+		maxlines: 1,
+		waiter:   &sync.WaitGroup{},
+	}
+	if err := writeLocked(w, &l4g.LogRecord{Message: "first"}); err != nil {
+		t.Fatalf("failed to write record, reason: %s", err.Error())
+	}
+
+	openFile = func(string, int, os.FileMode) (*os.File, error) {
+		return nil, &os.PathError{Op: "open", Path: w.filename, Err: syscall.EMFILE}
+	}
+	if err := writeLocked(w, &l4g.LogRecord{Message: "second"}); err != nil {
+		t.Fatalf("failed rotation must not stop logging, got: %s", err.Error())
+	}
+	if _, err := os.Stat(w.filename + ".001"); !os.IsNotExist(err) {
+		t.Errorf("failed rotation must not leave rotated file")
+	}
+
+	openFile = os.OpenFile
+	if err := writeLocked(w, &l4g.LogRecord{Message: "third"}); err != nil {
+		t.Fatalf("failed to write record, reason: %s", err.Error())
+	}
+	closeLocked(w)
+
+	rotated, err := ioutil.ReadFile(w.filename + ".001")
+	if err != nil {
+		t.Fatalf("failed to read rotated log file, reason: %s", err.Error())
+	}
+	if string(rotated) != "first\nsecond\n" {
+		t.Errorf("rotated log file content is unexpected: %q", rotated)
+	}
+	data, err := ioutil.ReadFile(w.filename)
+	if err != nil {
+		t.Fatalf("failed to read log file '%s', reason: %s", w.filename, err.Error())
+	}
+	if string(data) != "third\n" {
+		t.Errorf("log file content is unexpected: %q", data)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+
+	w := NewWriter(filepath.Join(dir, "flush-test.log"), true)
+	w.SetFormat("%M").SetWaitOnClose(true)
+	defer w.Close()
+
+	for i := 0; i < 10; i++ {
+		w.LogWrite(&l4g.LogRecord{Message: "test"})
+	}
+	w.Flush()
+
+	data, err := ioutil.ReadFile(w.filename)
+	if err != nil {
+		t.Fatalf("failed to read log file '%s', reason: %s", w.filename, err.Error())
+	}
+	if lines := strings.Count(string(data), "test\n"); lines != 10 {
+		t.Errorf("flushed records expected %d, got %d", 10, lines)
+	}
+}
+
+func TestSetJSON(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+
+	w := &Writer{
+		filename: filepath.Join(dir, "json-test.log"),
+		waiter:   &sync.WaitGroup{},
+	}
+	w.SetJSON(true).SetHeadFoot("header", "footer")
+
+	created := time.Date(2015, 1, 25, 10, 0, 0, 0, time.UTC)
+	if err := writeLocked(w, &l4g.LogRecord{
+		Level: l4g.ERROR, Created: created, Message: "test \"json\"",
+	}); err != nil {
+		t.Fatalf("failed to write record, reason: %s", err.Error())
+	}
+	closeLocked(w)
+
+	data, err := ioutil.ReadFile(w.filename)
+	if err != nil {
+		t.Fatalf("failed to read log file '%s', reason: %s", w.filename, err.Error())
+	}
+	expected := `{"level":"EROR","created":"2015-01-25T10:00:00Z","message":"test \"json\""}` + "\n"
+	if string(data) != expected {
+		t.Errorf("log file content expected %q, got %q", expected, data)
+	}
+	if w.maxlinesCurlines != 1 || w.maxsizeCursize != uint64(len(expected)) {
+		t.Errorf("counters expected %d lines and %d bytes, got %d and %d",
+			1, len(expected), w.maxlinesCurlines, w.maxsizeCursize)
+	}
+}
+
+func TestRotationTrailer(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+
+	w := NewWriter(filepath.Join(dir, "trailer-test.log"), true)
+	w.SetFormat("%M").SetHeadFoot("header", "footer").SetWaitOnClose(true)
+
+	w.LogWrite(&l4g.LogRecord{Message: "first"})
+	w.Flush()
+	w.Rotate()
+	w.Close()
+
+	for _, fName := range []string{w.filename + ".001", w.filename} {
+		data, err := ioutil.ReadFile(fName)
+		if err != nil {
+			t.Fatalf("failed to read log file '%s', reason: %s", fName, err.Error())
+		}
+		content := strings.TrimSpace(string(data))
+		if strings.Count(content, "header") != 1 || strings.Count(content, "footer") != 1 ||
+			!strings.HasPrefix(content, "header") || !strings.HasSuffix(content, "footer") {
+			t.Errorf("log file '%s' must have one header and one footer, got %q", fName, data)
+		}
+	}
+}
+
+func TestSetHeadFootOnce(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+
+	w := NewWriter(filepath.Join(dir, "once-test.log"), true)
+	w.SetFormat("%M").SetHeadFoot("header", "footer").SetHeadFootOnce(true).SetWaitOnClose(true)
+
+	w.LogWrite(&l4g.LogRecord{Message: "first"})
+	w.Flush()
+	w.Rotate()
+	w.LogWrite(&l4g.LogRecord{Message: "second"})
+	w.Close()
+
+	for fName, expected := range map[string]string{
+		w.filename + ".001": "header\nfirst",
+		w.filename:          "second\nfooter",
+	} {
+		data, err := ioutil.ReadFile(fName)
+		if err != nil {
+			t.Fatalf("failed to read log file '%s', reason: %s", fName, err.Error())
+		}
+		if content := strings.TrimSpace(string(data)); content != expected {
+			t.Errorf("log file '%s' expected %q, got %q", fName, expected, content)
+		}
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	for format, valid := range map[string]bool{
+		"":                      true,
+		"[%D %T] [%L] (%S) %M":  true,
+		"%d %t %s: %M":          true,
+		"100% sure":             false,
+		"[%D %T] %M %":          false,
+		"%M %%":                 false,
+		"[%D %T] [%l] %M":       false,
+		"plain text, no verbs!": true,
+	} {
+		if err := ValidateFormat(format); (err == nil) != valid {
+			t.Errorf("format %q expected valid: %t, got error: %v", format, valid, err)
+		}
+	}
+
+	w := &Writer{format: "%M"}
+	if err := w.SetFormatErr("%M %x"); err == nil || w.format != "%M" {
+		t.Errorf("invalid format must be rejected, got error %v and format %q", err, w.format)
+	}
+	if w.SetFormat("[%L] %Q"); w.format != "%M" {
+		t.Errorf("invalid format must be ignored, got format %q", w.format)
+	}
+	if err := w.SetHeadFootErr("start %D", "end %"); err == nil || w.header != "" {
+		t.Errorf("invalid footer must be rejected, got error %v and header %q", err, w.header)
+	}
+	if err := w.SetHeadFootErr("start %D", "end %T"); err != nil || w.trailer != "end %T" {
+		t.Errorf("valid header and footer must be set, got error %v", err)
+	}
+}
+
+func TestSetBufferLength(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+
+	w := NewWriter(filepath.Join(dir, "buffer-test.log"), true)
+	w.SetFormat("%M").SetBufferLength(100).SetWaitOnClose(true)
+	if cap(w.rec) != 100 {
+		t.Errorf("buffer length expected %d, got %d", 100, cap(w.rec))
+	}
+
+	w.LogWrite(&l4g.LogRecord{Message: "test"})
+	if w.SetBufferLength(10); cap(w.rec) != 100 {
+		t.Errorf("buffer length must not change after writer is used, got %d", cap(w.rec))
+	}
+	w.Close()
+
+	data, err := ioutil.ReadFile(w.filename)
+	if err != nil {
+		t.Fatalf("failed to read log file '%s', reason: %s", w.filename, err.Error())
+	}
+	if string(data) != "test\n" {
+		t.Errorf("log file content is unexpected: %q", data)
+	}
+}
+
+func TestSetOverflowPolicy(t *testing.T) {
+	test := func(policy OverflowPolicy, expected string) {
+		dir := createTestFiles(bunch2)
+		defer removeTestFiles(dir)
+
+		// Writer loop is not running, so records stay in buffer
+		w := &Writer{
+			rec:      make(chan *l4g.LogRecord, 2),
+			filename: filepath.Join(dir, "overflow-test.log"),
+			waiter:   &sync.WaitGroup{},
+		}
+		w.startOnce.Do(func() {})
+		w.SetOverflowPolicy(policy)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for _, msg := range []string{"first", "second", "third", "fourth"} {
+				w.LogWrite(&l4g.LogRecord{Message: msg})
+			}
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("LogWrite() with policy %d blocks on full buffer", policy)
+		}
+
+		close(w.rec)
+		var queued []string
+		for rec := range w.rec {
+			queued = append(queued, rec.Message)
+		}
+		if strings.Join(queued, " ") != expected || w.Stats().Overflowed != 2 {
+			t.Errorf("policy %d expected queued records %q and 2 overflowed, got %q and %d",
+				policy, expected, queued, w.Stats().Overflowed)
+		}
+	}
+	test(DropNewest, "first second")
+	test(DropOldest, "third fourth")
+}
+
+func TestFlushWithDropOldest(t *testing.T) {
+	blocked := make(blockedWriter)
+	w := NewWriterTo(blocked).SetBufferLength(2).SetOverflowPolicy(DropOldest)
+	w.SetFormat("%M")
+	w.LogWrite(&l4g.LogRecord{Message: "first"})
+
+	flushed := make(chan sig)
+	go func() {
+		w.Flush()
+		close(flushed)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	// Records overflowing buffer while flush is pending do not block
+	written := make(chan sig)
+	go func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					w.LogWrite(&l4g.LogRecord{Message: "next"})
+				}
+			}()
+		}
+		wg.Wait()
+		close(written)
+	}()
+	select {
+	case <-written:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("LogWrite() blocks while flush is pending")
+	}
+
+	close(blocked)
+	select {
+	case <-flushed:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Flush() is lost while records are dropped")
+	}
+	w.Close()
+}
+
+func TestCloseTwice(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+
+	w := NewWriter(filepath.Join(dir, "close-test.log"), true)
+	w.SetFormat("%M").SetWaitOnClose(true)
+
+	w.LogWrite(&l4g.LogRecord{Message: "before"})
+	w.Close()
+	w.Close()
+	w.LogWrite(&l4g.LogRecord{Message: "after"})
+	if _, err := w.Write([]byte("after")); err != nil {
+		t.Errorf("Write() after Close() failed, reason: %s", err.Error())
+	}
+	w.Flush()
+
+	data, err := ioutil.ReadFile(w.filename)
+	if err != nil {
+		t.Fatalf("failed to read log file '%s', reason: %s", w.filename, err.Error())
+	}
+	if strings.TrimSpace(string(data)) != "before" || w.Stats().Overflowed != 2 {
+		t.Errorf("expected only record logged before close and 2 overflowed, got %q and %d",
+			data, w.Stats().Overflowed)
+	}
+}
+
+func TestNewWriterTo(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriterTo(&buf)
+	w.SetFormat("%L %M").SetHeadFoot("header", "footer").SetRotateLines(1).SetWaitOnClose(true)
+
+	w.LogWrite(&l4g.LogRecord{Level: l4g.INFO, Message: "first"})
+	w.LogWrite(&l4g.LogRecord{Level: l4g.ERROR, Message: "second"})
+	w.Rotate()
+	w.Close()
+
+	if buf.String() != "header\nINFO first\nEROR second\nfooter\n" {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+	if stats := w.Stats(); stats.Rotations != 0 || stats.BytesWritten != 23 {
+		t.Errorf("stats expected no rotations and 23 bytes, got %+v", stats)
+	}
+}
+
+func TestSetEchoStdout(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+	stdout := os.Stdout
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	for _, echo := range []bool{true, false} {
+		r, pw, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("failed to create pipe, reason: %s", err.Error())
+		}
+		os.Stdout = pw
+
+		w := NewWriter(filepath.Join(dir, "echo-test.log"), false)
+		w.SetFormat("%M").SetEchoStdout(echo).SetWaitOnClose(true)
+		w.LogWrite(&l4g.LogRecord{Message: "echo"})
+		w.Close()
+		pw.Close()
+		echoed, _ := ioutil.ReadAll(r)
+		r.Close()
+
+		if (string(echoed) == "echo\n") != echo {
+			t.Errorf("echo to stdout expected %t, got %q", echo, echoed)
+		}
+	}
+}
+
+func TestSetIdleRotate(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+
+	for _, rotate := range []bool{true, false} {
+		fName := filepath.Join(dir, fmt.Sprintf("idle-test-%t.log", rotate))
+		w := NewWriter(fName, rotate)
+		w.SetFormat("%M").SetHeadFoot("head", "foot").SetEchoStdout(false).
+			SetIdleRotate(20 * time.Millisecond).SetWaitOnClose(true)
+		w.LogWrite(&l4g.LogRecord{Message: "first"})
+		w.Flush()
+		time.Sleep(100 * time.Millisecond)
+
+		w.mu.Lock()
+		opened := w.file != nil
+		w.mu.Unlock()
+		if opened {
+			t.Errorf("file expected to be closed after idle period (rotate %t)", rotate)
+		}
+		if rotations := w.Stats().Rotations; rotations != map[bool]uint64{true: 1}[rotate] {
+			t.Errorf("unexpected number of rotations %d (rotate %t)", rotations, rotate)
+		}
+		w.LogWrite(&l4g.LogRecord{Message: "second"})
+		w.Close()
+
+		expected := "head\nfirst\nfoot\nhead\nsecond\nfoot\n"
+		if rotate {
+			data, err := ioutil.ReadFile(fName + ".001")
+			if err != nil {
+				t.Fatalf("failed to read rotated file, reason: %s", err.Error())
+			}
+			if string(data) != "head\nfirst\nfoot\n" {
+				t.Errorf("rotated file content is unexpected: %q", data)
+			}
+			expected = "head\nsecond\nfoot\n"
+		}
+		data, err := ioutil.ReadFile(fName)
+		if err != nil {
+			t.Fatalf("failed to read log file, reason: %s", err.Error())
+		}
+		if string(data) != expected {
+			t.Errorf("log file content is unexpected (rotate %t): %q", rotate, data)
+		}
+	}
+}
+
+func TestSetSyncOnRotate(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+	defer func(sync func(*os.File) error, rename func(string, string) error) {
+		syncFile, renameFile = sync, rename
+	}(syncFile, renameFile)
+
+	var events []string
+	syncFile = func(f *os.File) error {
+		events = append(events, "sync")
+		return f.Sync()
+	}
+	renameFile = func(from, to string) error {
+		events = append(events, "rename")
+		return os.Rename(from, to)
+	}
+
+	for _, synced := range []bool{true, false} {
+		events = nil
+		w := &Writer{
+			filename:     filepath.Join(dir, fmt.Sprintf("sync-test-%t.log", synced)),
+			format:       "%M",
+			rotate:       true,
+			maxlines:     1,
+			syncOnRotate: synced,
+			waiter:       &sync.WaitGroup{},
+		}
+		for _, msg := range []string{"first", "second"} {
+			if err := writeLocked(w, &l4g.LogRecord{Message: msg}); err != nil {
+				t.Fatalf("failed to write record, reason: %s", err.Error())
+			}
+		}
+		closeLocked(w)
+
+		expected := []string{"rename"}
+		if synced {
+			// Rotated file is synced before rename and after trailer
+			// is written, and current file is synced on close
+			expected = []string{"sync", "rename", "sync", "sync"}
+		}
+		if strings.Join(events, ",") != strings.Join(expected, ",") {
+			t.Errorf("sync %t: expected events %v, got %v", synced, expected, events)
+		}
+	}
+}
+
+func TestRotatedNumberCache(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+
+	w := &Writer{
+		filename: filepath.Join(dir, "cache-test.log"),
+		format:   "%M",
+		rotate:   true,
+		maxlines: 1,
+		waiter:   &sync.WaitGroup{},
+	}
+	defer closeLocked(w)
+	write := func(msg string) {
+		if err := writeLocked(w, &l4g.LogRecord{Message: msg}); err != nil {
+			t.Fatalf("failed to write record, reason: %s", err.Error())
+		}
+	}
+	expectRotated := func(num int, content string) {
+		data, err := ioutil.ReadFile(fmt.Sprintf("%s.%03d", w.filename, num))
+		if err != nil || string(data) != content {
+			t.Errorf("rotated file #%d expected to contain %q, got %q (%v)", num, content, data, err)
+		}
+	}
+	write("first")
+	write("second")
+	write("third")
+	expectRotated(2, "second\n")
+
+	// Files deleted by external tool leave gap in numbering
+	os.Remove(w.filename + ".001")
+	os.Remove(w.filename + ".002")
+	write("fourth")
+	expectRotated(3, "third\n")
+
+	// File created by external tool is never overwritten
+	if err := ioutil.WriteFile(w.filename+".004", []byte("external"), 0660); err != nil {
+		t.Fatalf("failed to create file, reason: %s", err.Error())
+	}
+	write("fifth")
+	expectRotated(4, "external")
+	expectRotated(5, "fourth\n")
+}
+
+func TestCombinedRotation(t *testing.T) {
+	dir := createTestFiles(bunch2)
+	defer removeTestFiles(dir)
+
+	for _, rotate := range []bool{true, false} {
+		w := &Writer{
+			filename: filepath.Join(dir, fmt.Sprintf("combined-%t.log", rotate)),
+			format:   "%M",
+			header:   "head",
+			rotate:   rotate,
+			maxsize:  16,
+			maxlines: 4,
+			period:   Daily,
+			waiter:   &sync.WaitGroup{},
+		}
+		write := func(msg string) {
+			if err := writeLocked(w, &l4g.LogRecord{Message: msg}); err != nil {
+				t.Fatalf("failed to write record, reason: %s", err.Error())
+			}
+		}
+		expect := func(rotations uint64, content string) {
+			data, err := ioutil.ReadFile(w.filename)
+			if err != nil || string(data) != content {
+				t.Errorf("rotate=%t: log file expected to contain %q, got %q (%v)", rotate, content, data, err)
+			}
+			if r := w.Stats().Rotations; r != rotations {
+				t.Errorf("rotate=%t: expected %d rotations, got %d", rotate, rotations, r)
+			}
+			// Counters must match fresh file, header included
+			if w.maxsizeCursize != uint64(len(data)) ||
+				w.maxlinesCurlines != uint64(strings.Count(string(data), "\n")) {
+				t.Errorf("rotate=%t: stale counters of %d bytes and %d lines for %q",
+					rotate, w.maxsizeCursize, w.maxlinesCurlines, data)
+			}
+			if w.period.crossed(w.openTime, time.Now()) {
+				t.Errorf("rotate=%t: stale open time %s", rotate, w.openTime)
+			}
+		}
+		yesterday := time.Now().AddDate(0, 0, -1)
+
+		write("first")
+		expect(0, "head\nfirst\n")
+
+		// Day boundary alone
+		w.openTime = yesterday
+		write("second")
+		expect(1, "head\nsecond\n")
+
+		// Size limit hit at the same time as day boundary rotates once
+		write("third")
+		w.openTime = yesterday
+		write("fourth")
+		expect(2, "head\nfourth\n")
+		write("fifth")
+		expect(2, "head\nfourth\nfifth\n")
+
+		// Size limit alone
+		write("sixth")
+		expect(3, "head\nsixth\n")
+		closeLocked(w)
+
+		if rotate {
+			data, err := ioutil.ReadFile(w.filename + ".002")
+			if err != nil || string(data) != "head\nsecond\nthird\n" {
+				t.Errorf("rotated file expected to contain both records, got %q (%v)", data, err)
+			}
+		}
+	}
+}
+
+func BenchmarkRotationManyBackups(b *testing.B) {
+	dir := createTestFiles(nil)
+	defer removeTestFiles(dir)
+	fName := filepath.Join(dir, "bench.log")
+	for i := 1; i <= 10000; i++ {
+		if err := ioutil.WriteFile(fmt.Sprintf("%s.%05d", fName, i), nil, 0660); err != nil {
+			b.Fatalf("failed to create rotated file, reason: %s", err.Error())
+		}
+	}
+
+	w := &Writer{filename: fName, rotate: true, suffixWidth: 5, waiter: &sync.WaitGroup{}}
+	if err := openLocked(w); err != nil {
+		b.Fatalf("failed to open log file, reason: %s", err.Error())
+	}
+	defer closeLocked(w)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := rotateLocked(w); err != nil {
+			b.Fatalf("failed to rotate, reason: %s", err.Error())
+		}
+	}
+}
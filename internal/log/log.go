@@ -2,7 +2,13 @@ package log
 
 import (
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/chappjc/go-sizeof-webapp/internal/log/filelog"
 
@@ -13,9 +19,73 @@ import (
 // application log is stored.
 const ApplicationLogFile = "logs/application.log"
 
+// ErrorLogFile is the relative path (from application root) to file where
+// errors of application log are additionally stored.
+const ErrorLogFile = "logs/error.log"
+
+// AccessLogFile is the relative path (from application root) to file where
+// HTTP access log is stored.
+const AccessLogFile = "logs/access.log"
+
+// FileEnv is the name of environment variable which overrides path to file
+// where application log is stored. Error and access logs are stored in the
+// same directory.
+const FileEnv = "GOLOGFILE"
+
+// RootEnv is the name of environment variable which sets directory against
+// which relative paths to log files are resolved. Application root given to
+// logger constructors is used if it is not set.
+const RootEnv = "GOLOGROOT"
+
+// LevelEnv is the name of environment variable which sets minimal level of
+// records written into application log. Its value may be one of DEBUG, TRACE,
+// INFO, WARN, ERROR and CRITICAL, otherwise INFO level is used.
+const LevelEnv = "GOLOGLEVEL"
+
+// StdoutEnv is the name of environment variable which sets whether log
+// records are duplicated to standard output. Set it to 0 to disable
+// duplication (for example, in containers where standard output is
+// captured separately). Records are duplicated by default.
+const StdoutEnv = "GOLOGSTDOUT"
+
+// FallbackEnv is the name of environment variable which, when set to "1",
+// makes loggers write to standard error if their log files cannot be opened
+// (like in read-only logs directory), instead of failing. It is disabled by
+// default, so that misconfiguration is not masked.
+const FallbackEnv = "GOLOGFALLBACK"
+
+// SyslogEnv is the name of environment variable which makes application log
+// records to be sent to syslog too: "local" for local syslog, or URL of remote
+// one like "udp://logs.example.com:514". Empty value disables it.
+const SyslogEnv = "GOLOGSYSLOG"
+
+// SyslogFacilityEnv is the name of environment variable which sets facility
+// of records sent to syslog, like "local0". It is "daemon" if not set.
+const SyslogFacilityEnv = "GOLOGSYSLOGFACILITY"
+
+// Levels which can be set via LevelEnv environment variable.
+var levels = map[string]l4g.Level{
+	"DEBUG":    l4g.DEBUG,
+	"TRACE":    l4g.TRACE,
+	"INFO":     l4g.INFO,
+	"WARN":     l4g.WARNING,
+	"ERROR":    l4g.ERROR,
+	"CRITICAL": l4g.CRITICAL,
+}
+
 // Description of filelog.Writer creation error.
 const errCreateLogFile = "failed to create '%s' log file"
 
+// Format of log records, other than access log ones.
+const recordFormat = "[%D %T][%L] %M"
+
+// Destination of fallback loggers. Can be replaced in tests.
+var fallbackOut io.Writer = os.Stderr
+
+// The longest time closing of logger waits for its log files to be written
+// and closed, so that wedged file does not hang application shutdown.
+const closeTimeout = 5 * time.Second
+
 // Logger represents a logger with different levels of logs.
 type Logger interface {
 	Debug(interface{}, ...interface{})
@@ -24,21 +94,200 @@ type Logger interface {
 	Warn(interface{}, ...interface{}) error
 	Error(interface{}, ...interface{}) error
 	Critical(interface{}, ...interface{}) error
+	Fatal(interface{}, ...interface{})
+	SetLevel(string) error
+	SetCallDepth(int)
+	With(map[string]interface{}) Logger
+	Stats() filelog.Stats
 	Close()
 }
 
 // NewApplicationLogger creates and returns new application logger, ready for
-// use.
-func NewApplicationLogger() (Logger, error) {
+// use. Relative path of log file is resolved against given application root
+// (working directory if it is empty).
+func NewApplicationLogger(root string) (Logger, error) {
+	lgr := make(l4g.Logger)
+	lvl := parseLevel(os.Getenv(LevelEnv))
+	flw, err := addFileFilter(lgr, "s", lvl, FilePath(ApplicationLogFile, root))
+	if err != nil {
+		return newFallbackLogger(lvl, recordFormat, err)
+	}
+	if err = setSyslog(flw); err != nil {
+		lgr.Close()
+		return nil, err
+	}
+	return &logger{filters: &filters{l4g: lgr}}, nil
+}
+
+// NewApplicationLoggerWithErrorLog creates and returns new application logger
+// which additionally writes errors (and more severe records) into separate
+// ErrorLogFile. Both log files are closed together on logger's Close().
+// Relative paths of log files are resolved against given application root.
+func NewApplicationLoggerWithErrorLog(root string) (Logger, error) {
+	lgr := make(l4g.Logger)
+	lvl := parseLevel(os.Getenv(LevelEnv))
+	flw, err := addFileFilter(lgr, "s", lvl, FilePath(ApplicationLogFile, root))
+	if err != nil {
+		return newFallbackLogger(lvl, recordFormat, err)
+	}
+	if err = setSyslog(flw); err != nil {
+		lgr.Close()
+		return nil, err
+	}
+	errLvl := l4g.ERROR
+	if lvl > errLvl {
+		errLvl = lvl
+	}
+	if _, err := addFileFilter(lgr, "e", errLvl, FilePath(ErrorLogFile, root)); err != nil {
+		lgr.Close()
+		return newFallbackLogger(lvl, recordFormat, err)
+	}
+	return &logger{filters: &filters{
+		l4g:    lgr,
+		floors: map[string]l4g.Level{"e": l4g.ERROR},
+	}}, nil
+}
+
+// NewAccessLogger creates and returns new HTTP access logger, ready for use.
+// Access log records are written as is, without any additional formatting,
+// and regardless of LevelEnv environment variable. Relative path of log file
+// is resolved against given application root.
+func NewAccessLogger(root string) (Logger, error) {
+	lgr := make(l4g.Logger)
+	flw, err := addFileFilter(lgr, "a", l4g.INFO, FilePath(AccessLogFile, root))
+	if err != nil {
+		return newFallbackLogger(l4g.INFO, "%M", err)
+	}
+	flw.SetFormat("%M")
+	return &logger{filters: &filters{l4g: lgr}}, nil
+}
+
+// UsesFallback returns whether given logger writes to standard error, as its
+// log files cannot be opened.
+func UsesFallback(lgr Logger) bool {
+	l, ok := lgr.(*logger)
+	return ok && l.fallback
+}
+
+// Helper function which returns logger writing records of given minimal level
+// and format to standard error instead of log file, which cannot be created
+// because of given error. Given error is returned as is, if fallback is not
+// enabled with FallbackEnv environment variable.
+func newFallbackLogger(lvl l4g.Level, format string, err error) (Logger, error) {
+	if os.Getenv(FallbackEnv) != "1" {
+		return nil, err
+	}
+	StdErr("%s, logging to standard error instead\n", err.Error())
 	lgr := make(l4g.Logger)
-	if flw := filelog.NewWriter(ApplicationLogFile, false); flw == nil {
-		return nil, fmt.Errorf(errCreateLogFile, ApplicationLogFile)
-	} else {
-		flw.SetFormat("[%D %T][%L] %M")
-		flw.SetWaitOnClose(true)
-		lgr.AddFilter("s", l4g.INFO, flw)
-	}
-	return lgr, nil
+	flw := filelog.NewWriterTo(fallbackOut)
+	flw.SetFormat(format).SetWaitOnClose(true).SetCloseTimeout(closeTimeout)
+	lgr.AddFilter("s", lvl, flw)
+	return &logger{filters: &filters{l4g: lgr, fallback: true}}, nil
+}
+
+// FilePath returns path to log file with given default path, accordingly with
+// FileEnv and RootEnv environment variables. Relative path is resolved against
+// RootEnv directory, or given application root if it is not set.
+func FilePath(file, root string) string {
+	if appFile := os.Getenv(FileEnv); appFile != "" {
+		if file == ApplicationLogFile {
+			file = appFile
+		} else {
+			file = filepath.Join(filepath.Dir(appFile), filepath.Base(file))
+		}
+	}
+	if logRoot := os.Getenv(RootEnv); logRoot != "" {
+		root = logRoot
+	}
+	if root != "" && !filepath.IsAbs(file) {
+		file = filepath.Join(root, file)
+	}
+	return file
+}
+
+// Helper function which adds filter with given name and level to given logger,
+// writing log records into given file, which directory is created if missing.
+// Returns writer of added filter.
+func addFileFilter(
+	lgr l4g.Logger, name string, lvl l4g.Level, file string,
+) (*filelog.Writer, error) {
+	if err := os.MkdirAll(filepath.Dir(file), 0770); err != nil {
+		return nil, fmt.Errorf(errCreateLogFile+", reason -> %s", file, err.Error())
+	}
+	flw := filelog.NewWriter(file, false)
+	if flw == nil {
+		return nil, fmt.Errorf(errCreateLogFile, file)
+	}
+	// Writer opens file on the first record, so it is checked here to
+	// report file which cannot be written before logging
+	f, err := os.OpenFile(file, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0660)
+	if err != nil {
+		return nil, fmt.Errorf(errCreateLogFile+", reason -> %s", file, err.Error())
+	}
+	f.Close()
+	flw.SetFormat(recordFormat)
+	flw.SetWaitOnClose(true).SetCloseTimeout(closeTimeout)
+	flw.SetEchoStdout(echoStdout())
+	lgr.AddFilter(name, lvl, flw)
+	return flw, nil
+}
+
+// Helper function which makes given writer to send records to syslog too,
+// accordingly with SyslogEnv and SyslogFacilityEnv environment variables.
+func setSyslog(flw *filelog.Writer) error {
+	value := strings.TrimSpace(os.Getenv(SyslogEnv))
+	if value == "" {
+		return nil
+	}
+	network, addr := "", ""
+	if value != "local" {
+		u, err := url.Parse(value)
+		if err != nil || u.Host == "" || u.Scheme != "udp" && u.Scheme != "tcp" {
+			return fmt.Errorf(
+				"invalid %s '%s', \"local\" or URL like udp://host:514 expected",
+				SyslogEnv, value,
+			)
+		}
+		network, addr = u.Scheme, u.Host
+	}
+	facility := os.Getenv(SyslogFacilityEnv)
+	if facility == "" {
+		facility = "daemon"
+	}
+	return flw.SetSyslog(network, addr, facility, "")
+}
+
+// Helper function which returns whether log records are duplicated to
+// standard output, accordingly with StdoutEnv environment variable.
+// Unrecognized or empty values enable duplication.
+func echoStdout() bool {
+	echo, err := strconv.ParseBool(os.Getenv(StdoutEnv))
+	return echo || err != nil
+}
+
+// LevelName returns name of minimal level of application log set via LevelEnv
+// environment variable, or INFO if it is not set or is unrecognized.
+func LevelName() string {
+	name := strings.ToUpper(strings.TrimSpace(os.Getenv(LevelEnv)))
+	if _, ok := levels[name]; ok {
+		return name
+	}
+	return "INFO"
+}
+
+// Helper function which converts given level name to log4go level.
+// Unrecognized or empty names are treated as INFO level.
+func parseLevel(name string) l4g.Level {
+	if lvl, ok := lookupLevel(name); ok {
+		return lvl
+	}
+	return l4g.INFO
+}
+
+// Helper function which looks up log4go level by given level name.
+func lookupLevel(name string) (l4g.Level, bool) {
+	lvl, ok := levels[strings.ToUpper(strings.TrimSpace(name))]
+	return lvl, ok
 }
 
 // StdErr performs printf() of given pattern with given arguments to OS standard
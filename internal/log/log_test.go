@@ -0,0 +1,339 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/log/filelog"
+
+	l4g "github.com/alecthomas/log4go"
+)
+
+func TestParseLevel(t *testing.T) {
+	cases := map[string]l4g.Level{
+		"":         l4g.INFO,
+		"unknown":  l4g.INFO,
+		"DEBUG":    l4g.DEBUG,
+		"trace":    l4g.TRACE,
+		" WARN ":   l4g.WARNING,
+		"ERROR":    l4g.ERROR,
+		"CRITICAL": l4g.CRITICAL,
+	}
+	for name, lvl := range cases {
+		if actual := parseLevel(name); actual != lvl {
+			t.Errorf("level of '%s' expected %v, got %v", name, lvl, actual)
+		}
+	}
+}
+
+func TestLevelName(t *testing.T) {
+	defer os.Setenv(LevelEnv, os.Getenv(LevelEnv))
+	for value, name := range map[string]string{
+		"":        "INFO",
+		"unknown": "INFO",
+		" debug ": "DEBUG",
+		"WARN":    "WARN",
+	} {
+		os.Setenv(LevelEnv, value)
+		if actual := LevelName(); actual != name {
+			t.Errorf("level name of '%s' expected %s, got %s", value, name, actual)
+		}
+	}
+}
+
+type recordsCapture []*l4g.LogRecord
+
+func (c *recordsCapture) LogWrite(rec *l4g.LogRecord) {
+	*c = append(*c, rec)
+}
+
+func (c *recordsCapture) Close() {}
+
+func TestLoggerSource(t *testing.T) {
+	capture := &recordsCapture{}
+	lgr := &logger{filters: &filters{
+		l4g: l4g.Logger{"t": &l4g.Filter{Level: l4g.DEBUG, LogWriter: capture}},
+	}}
+
+	_, file, line, _ := runtime.Caller(0)
+	lgr.Info("info %d", 1)
+	lgr.Error("error")
+
+	if len(*capture) != 2 {
+		t.Fatalf("records expected %d, got %d", 2, len(*capture))
+	}
+	for i, rec := range *capture {
+		source := fmt.Sprintf("%s:%d", filepath.Base(file), line+1+i)
+		if !strings.HasSuffix(rec.Source, source) {
+			t.Errorf("record source expected '%s', got '%s'", source, rec.Source)
+		}
+	}
+	if msg := (*capture)[0].Message; msg != "info 1" {
+		t.Errorf("record message expected '%s', got '%s'", "info 1", msg)
+	}
+}
+
+// Helper function wrapping logger, like ones of application packages.
+func logWrapped(lgr Logger, msg string) {
+	lgr.Info(msg)
+}
+
+func TestLoggerCallDepth(t *testing.T) {
+	capture := &recordsCapture{}
+	lgr := &logger{filters: &filters{
+		l4g: l4g.Logger{"t": &l4g.Filter{Level: l4g.DEBUG, LogWriter: capture}},
+	}}
+
+	_, file, line, _ := runtime.Caller(0)
+	logWrapped(lgr, "wrapper")
+	lgr.SetCallDepth(1)
+	logWrapped(lgr, "caller")
+	logWrapped(lgr.With(map[string]interface{}{"k": "v"}), "derived")
+
+	if len(*capture) != 3 {
+		t.Fatalf("records expected %d, got %d", 3, len(*capture))
+	}
+	for i, l := range []int{line - 9, line + 3, line + 4} { // the first one is in logWrapped()
+		source := fmt.Sprintf("%s:%d", filepath.Base(file), l)
+		if rec := (*capture)[i]; !strings.HasSuffix(rec.Source, source) {
+			t.Errorf("record '%s' source expected '%s', got '%s'", rec.Message, source, rec.Source)
+		}
+	}
+}
+
+func TestLoggerSetLevel(t *testing.T) {
+	capture := &recordsCapture{}
+	lgr := &logger{filters: &filters{
+		l4g: l4g.Logger{
+			"s": &l4g.Filter{Level: l4g.INFO, LogWriter: capture},
+			"e": &l4g.Filter{Level: l4g.ERROR, LogWriter: capture},
+		},
+		floors: map[string]l4g.Level{"e": l4g.ERROR},
+	}}
+
+	lgr.Debug("skipped")
+	if err := lgr.SetLevel("DEBUG"); err != nil {
+		t.Fatalf("failed to set level, reason: %s", err.Error())
+	}
+	lgr.Debug("written")
+	if err := lgr.SetLevel("unknown"); err == nil {
+		t.Errorf("setting unknown level must fail")
+	}
+
+	if len(*capture) != 1 || (*capture)[0].Message != "written" {
+		t.Errorf("only record written after level change expected, got %d records", len(*capture))
+	}
+	if lvl := lgr.l4g["e"].Level; lvl != l4g.ERROR {
+		t.Errorf("errors filter level expected %v, got %v", l4g.ERROR, lvl)
+	}
+}
+
+type closeCounter struct {
+	recordsCapture
+	closed int
+}
+
+func (c *closeCounter) Close() {
+	c.closed++
+}
+
+func TestLoggerWith(t *testing.T) {
+	capture := &closeCounter{}
+	lgr := &logger{filters: &filters{
+		l4g: l4g.Logger{"t": &l4g.Filter{Level: l4g.INFO, LogWriter: capture}},
+	}}
+
+	reqLog := lgr.With(map[string]interface{}{"request_id": "abc"})
+	reqLog.With(map[string]interface{}{"a": 1}).Info("test")
+	reqLog.Close()
+
+	if len(capture.recordsCapture) != 1 {
+		t.Fatalf("records expected %d, got %d", 1, len(capture.recordsCapture))
+	}
+	if msg := capture.recordsCapture[0].Message; msg != "[a=1 request_id=abc] test" {
+		t.Errorf("record message expected '%s', got '%s'", "[a=1 request_id=abc] test", msg)
+	}
+	if capture.closed != 0 {
+		t.Errorf("closing derived logger must not close log writers")
+	}
+	lgr.Close()
+	if capture.closed != 1 {
+		t.Errorf("closing logger must close log writers")
+	}
+}
+
+type flushCounter struct {
+	recordsCapture
+	flushed int
+}
+
+func (c *flushCounter) Flush() {
+	c.flushed++
+}
+
+func TestLoggerFatal(t *testing.T) {
+	defer func(f func(int)) {
+		exit = f
+	}(exit)
+	code := 0
+	exit = func(c int) {
+		code = c
+	}
+
+	capture := &flushCounter{}
+	lgr := &logger{filters: &filters{
+		l4g: l4g.Logger{"t": &l4g.Filter{Level: l4g.INFO, LogWriter: capture}},
+	}}
+	lgr.Fatal("fatal")
+
+	if len(capture.recordsCapture) != 1 || capture.recordsCapture[0].Level != l4g.CRITICAL {
+		t.Errorf("single critical record expected")
+	}
+	if capture.flushed != 1 {
+		t.Errorf("log writer must be flushed before exit")
+	}
+	if code != 1 {
+		t.Errorf("exit code expected %d, got %d", 1, code)
+	}
+}
+
+func TestEchoStdout(t *testing.T) {
+	defer os.Unsetenv(StdoutEnv)
+	for value, expected := range map[string]bool{
+		"":      true,
+		"1":     true,
+		"true":  true,
+		"0":     false,
+		"false": false,
+		"maybe": true,
+	} {
+		os.Setenv(StdoutEnv, value)
+		if echo := echoStdout(); echo != expected {
+			t.Errorf("GOLOGSTDOUT=%s expected echo %t, got %t", value, expected, echo)
+		}
+	}
+}
+
+func TestSetSyslog(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" {
+		t.Skip("syslog is not supported on " + runtime.GOOS)
+	}
+	defer os.Unsetenv(SyslogEnv)
+	defer os.Unsetenv(SyslogFacilityEnv)
+	for _, c := range []struct {
+		value, facility string
+		valid           bool
+	}{
+		{"", "nosuch", true},
+		{"local", "", true},
+		{"udp://127.0.0.1:514", "local3", true},
+		{"tcp://logs.example.com:601", "", true},
+		{"udp://127.0.0.1:514", "nosuch", false},
+		{"http://logs.example.com", "", false},
+		{"udp://", "", false},
+		{"logs.example.com:514", "", false},
+	} {
+		os.Setenv(SyslogEnv, c.value)
+		os.Setenv(SyslogFacilityEnv, c.facility)
+		flw := filelog.NewWriter(filepath.Join(t.TempDir(), "test.log"), false)
+		if err := setSyslog(flw); (err == nil) != c.valid {
+			t.Errorf("GOLOGSYSLOG=%s GOLOGSYSLOGFACILITY=%s: expected valid %t, got error %v",
+				c.value, c.facility, c.valid, err)
+		}
+		flw.Close()
+	}
+}
+
+func TestLogPath(t *testing.T) {
+	defer os.Unsetenv(FileEnv)
+	defer os.Unsetenv(RootEnv)
+
+	cases := []struct {
+		file, root, appRoot, path string
+		expected                  string
+	}{
+		{"", "", "", ApplicationLogFile, ApplicationLogFile},
+		{"", "/srv/sizeof", "", ErrorLogFile, "/srv/sizeof/logs/error.log"},
+		{"/var/log/sizeof/app.log", "/srv/sizeof", "", ApplicationLogFile, "/var/log/sizeof/app.log"},
+		{"/var/log/sizeof/app.log", "", "", AccessLogFile, "/var/log/sizeof/access.log"},
+		{"var/app.log", "/srv/sizeof", "", ErrorLogFile, "/srv/sizeof/var/error.log"},
+		{"", "", "/opt/sizeof", ApplicationLogFile, "/opt/sizeof/logs/application.log"},
+		{"", "/srv/sizeof", "/opt/sizeof", AccessLogFile, "/srv/sizeof/logs/access.log"},
+		{"/var/log/sizeof/app.log", "", "/opt/sizeof", ErrorLogFile, "/var/log/sizeof/error.log"},
+	}
+	for _, c := range cases {
+		os.Setenv(FileEnv, c.file)
+		os.Setenv(RootEnv, c.root)
+		if path := FilePath(c.path, c.appRoot); path != filepath.FromSlash(c.expected) {
+			t.Errorf("invalid path of '%s' (GOLOGFILE=%s, GOLOGROOT=%s, root %s)\n\texpected: %s\n\tactual: %s",
+				c.path, c.file, c.root, c.appRoot, c.expected, path)
+		}
+	}
+}
+
+func TestNewApplicationLoggerCreatesDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sizeof-log")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory, reason -> %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	lgr, err := NewApplicationLogger(dir)
+	if err != nil {
+		t.Fatalf("failed to create logger, reason -> %s", err.Error())
+	}
+	lgr.Info("test")
+	lgr.Close()
+	if _, err := os.Stat(filepath.Join(dir, ApplicationLogFile)); err != nil {
+		t.Errorf("log file is not created, reason -> %s", err.Error())
+	}
+}
+
+func TestNewLoggerFallback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sizeof-log")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory, reason -> %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+	// Directory in place of log file cannot be opened for writing, even
+	// when tests are run by root
+	if err = os.MkdirAll(filepath.Join(dir, ApplicationLogFile), 0770); err != nil {
+		t.Fatalf("failed to create directory, reason -> %s", err.Error())
+	}
+	defer os.Setenv(FallbackEnv, os.Getenv(FallbackEnv))
+	defer func() { fallbackOut = os.Stderr }()
+
+	os.Setenv(FallbackEnv, "")
+	if _, err = NewApplicationLoggerWithErrorLog(dir); err == nil {
+		t.Errorf("expected error of unwritable log file without fallback")
+	}
+
+	os.Setenv(FallbackEnv, "1")
+	var buf bytes.Buffer
+	fallbackOut = &buf
+	lgr, err := NewApplicationLoggerWithErrorLog(dir)
+	if err != nil {
+		t.Fatalf("expected fallback logger, got error -> %s", err.Error())
+	}
+	lgr.Info("served")
+	lgr.Close()
+	if !UsesFallback(lgr) || !strings.Contains(buf.String(), "[INFO] served\n") {
+		t.Errorf("expected record in standard error, got: %q", buf.String())
+	}
+
+	// Loggers with writable files do not fall back
+	lgr, err = NewAccessLogger(dir)
+	if err != nil {
+		t.Fatalf("failed to create logger, reason -> %s", err.Error())
+	}
+	defer lgr.Close()
+	if UsesFallback(lgr) {
+		t.Errorf("logger with writable file uses fallback")
+	}
+}
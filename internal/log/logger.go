@@ -0,0 +1,236 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/chappjc/go-sizeof-webapp/internal/log/filelog"
+
+	l4g "github.com/alecthomas/log4go"
+)
+
+// Terminates application. Can be replaced in tests.
+var exit = os.Exit
+
+// Implementation of Logger on top of log4go logger, which records actual call
+// site of logging methods as source of log records.
+type logger struct {
+	*filters
+	// Contextual fields and prefix of messages built from them
+	fields map[string]interface{}
+	prefix string
+	// Derived loggers share filters, so must not close them
+	derived bool
+	// Number of additional stack frames skipped to find call site
+	depth int32
+}
+
+// Log4go filters shared between logger and loggers derived from it.
+type filters struct {
+	l4g l4g.Logger
+	// Minimal levels of filters, which cannot be lowered by SetLevel()
+	floors map[string]l4g.Level
+	// Filters write to standard error, as log files cannot be opened
+	fallback bool
+	// Guards levels of filters
+	mu sync.RWMutex
+}
+
+// Debug logs a message at debug level.
+func (l *logger) Debug(arg0 interface{}, args ...interface{}) {
+	l.log(l4g.DEBUG, arg0, args...)
+}
+
+// Trace logs a message at trace level.
+func (l *logger) Trace(arg0 interface{}, args ...interface{}) {
+	l.log(l4g.TRACE, arg0, args...)
+}
+
+// Info logs a message at info level.
+func (l *logger) Info(arg0 interface{}, args ...interface{}) {
+	l.log(l4g.INFO, arg0, args...)
+}
+
+// Warn logs a message at warning level and returns it as error.
+func (l *logger) Warn(arg0 interface{}, args ...interface{}) error {
+	return errors.New(l.log(l4g.WARNING, arg0, args...))
+}
+
+// Error logs a message at error level and returns it as error.
+func (l *logger) Error(arg0 interface{}, args ...interface{}) error {
+	return errors.New(l.log(l4g.ERROR, arg0, args...))
+}
+
+// Critical logs a message at critical level and returns it as error.
+func (l *logger) Critical(arg0 interface{}, args ...interface{}) error {
+	return errors.New(l.log(l4g.CRITICAL, arg0, args...))
+}
+
+// Fatal logs a message at critical level, waits until it is written by all
+// log writers supporting flushing, and exits application with code 1.
+func (l *logger) Fatal(arg0 interface{}, args ...interface{}) {
+	l.log(l4g.CRITICAL, arg0, args...)
+	l.flush()
+	exit(1)
+}
+
+// SetLevel changes minimal level of records written by logger. Filters with
+// own minimal level (like errors log) are not lowered below it.
+func (l *logger) SetLevel(name string) error {
+	lvl, ok := lookupLevel(name)
+	if !ok {
+		return fmt.Errorf("unknown log level '%s'", name)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for fName, filt := range l.l4g {
+		filt.Level = lvl
+		if floor, ok := l.floors[fName]; ok && floor > lvl {
+			filt.Level = floor
+		}
+	}
+	return nil
+}
+
+// With returns derived logger which prefixes every message with given fields
+// (in addition to fields of current logger). Derived logger shares log writers
+// with current one, and its Close() does nothing.
+func (l *logger) With(fields map[string]interface{}) Logger {
+	merged := make(map[string]interface{}, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf("%s=%v", k, merged[k])
+	}
+	return &logger{
+		filters: l.filters,
+		fields:  merged,
+		prefix:  "[" + strings.Join(pairs, " ") + "] ",
+		derived: true,
+		depth:   atomic.LoadInt32(&l.depth),
+	}
+}
+
+// SetCallDepth sets number of additional stack frames which are skipped to
+// find source of log records, so that helper functions wrapping logger record
+// call sites of their callers instead of their own. Loggers derived from
+// logger afterwards inherit it. Negative depth is treated as 0.
+func (l *logger) SetCallDepth(depth int) {
+	if depth < 0 {
+		depth = 0
+	}
+	atomic.StoreInt32(&l.depth, int32(depth))
+}
+
+// Stats returns summary statistics of all file log writers of logger.
+func (l *logger) Stats() (stats filelog.Stats) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, filt := range l.l4g {
+		if s, ok := filt.LogWriter.(interface {
+			Stats() filelog.Stats
+		}); ok {
+			st := s.Stats()
+			stats.Rotations += st.Rotations
+			stats.BytesWritten += st.BytesWritten
+			stats.Dropped += st.Dropped
+			stats.Overflowed += st.Overflowed
+			stats.SyslogDropped += st.SyslogDropped
+		}
+	}
+	return
+}
+
+// Close closes all log writers of logger. Does nothing for derived loggers.
+func (l *logger) Close() {
+	if l.derived {
+		return
+	}
+	l.l4g.Close()
+}
+
+// Helper function which builds message from given arguments and dispatches it
+// with given level to log writers. Message is built only if it will be written
+// or is required to be returned (warning level and higher).
+//
+// Attention: Must be called directly from Logger methods to record correct
+// call site!
+func (l *logger) log(lvl l4g.Level, arg0 interface{}, args ...interface{}) string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if lvl < l4g.WARNING && !l.enabled(lvl) {
+		return ""
+	}
+	msg := message(arg0, args...)
+	l.l4g.Log(lvl, caller(3+int(atomic.LoadInt32(&l.depth))), l.prefix+msg)
+	return msg
+}
+
+// Helper function which waits until all queued records are written by log
+// writers supporting flushing (like filelog.Writer).
+func (l *logger) flush() {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, filt := range l.l4g {
+		if f, ok := filt.LogWriter.(interface {
+			Flush()
+		}); ok {
+			f.Flush()
+		}
+	}
+}
+
+// Helper function to check whether any log writer accepts given level.
+func (l *logger) enabled(lvl l4g.Level) bool {
+	for _, filt := range l.l4g {
+		if lvl >= filt.Level {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper function which builds log message in the same way as log4go does:
+// string is used as format, closure is called and anything else is printed
+// similar to fmt.Sprint().
+func message(arg0 interface{}, args ...interface{}) string {
+	switch first := arg0.(type) {
+	case string:
+		if len(args) == 0 {
+			return first
+		}
+		return fmt.Sprintf(first, args...)
+	case func() string:
+		return first()
+	default:
+		return fmt.Sprintf(
+			fmt.Sprint(first)+strings.Repeat(" %v", len(args)), args...,
+		)
+	}
+}
+
+// Helper function which returns "file:line" of the caller, skipping given
+// number of stack frames (0 identifies caller() function itself).
+func caller(skip int) string {
+	_, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
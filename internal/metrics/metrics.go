@@ -0,0 +1,389 @@
+// Package metrics implements minimal set of metrics exposed in Prometheus
+// text exposition format, or in OpenMetrics format with exemplars. It is used
+// instead of Prometheus client library, so that application builds without
+// fetching its dependencies.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are default upper bounds of histogram buckets, suitable for
+// measuring durations of HTTP requests in seconds.
+var DefaultBuckets = []float64{
+	.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10,
+}
+
+// Represents metric which can be written in text exposition format, or in
+// OpenMetrics format if openMetrics is true.
+type metric interface {
+	write(w io.Writer, openMetrics bool)
+}
+
+// Registry holds set of metrics and exposes them.
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+}
+
+// NewRegistry creates new empty registry of metrics.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Helper function which registers given metric.
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, m)
+}
+
+// WriteText writes all registered metrics into given writer in text
+// exposition format.
+func (r *Registry) WriteText(w io.Writer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range r.metrics {
+		m.write(w, false)
+	}
+}
+
+// WriteOpenMetrics writes all registered metrics into given writer in
+// OpenMetrics format, which has exemplars of histograms.
+func (r *Registry) WriteOpenMetrics(w io.Writer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range r.metrics {
+		m.write(w, true)
+	}
+	fmt.Fprint(w, "# EOF\n")
+}
+
+// ServeHTTP serves all registered metrics in OpenMetrics format if client
+// accepts it, or in text exposition format otherwise.
+// Implementation of http.Handler interface.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if strings.Contains(req.Header.Get("Accept"), "application/openmetrics-text") {
+		w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+		r.WriteOpenMetrics(w)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteText(w)
+}
+
+// CounterVec represents counters partitioned by value of single label.
+type CounterVec struct {
+	name, help, label string
+
+	mu     sync.Mutex
+	values map[string]uint64
+}
+
+// NewCounterVec creates and registers new counters partitioned by given
+// label.
+func (r *Registry) NewCounterVec(name, help, label string) *CounterVec {
+	c := &CounterVec{
+		name: name, help: help, label: label,
+		values: make(map[string]uint64),
+	}
+	r.register(c)
+	return c
+}
+
+// Inc increments counter with given label value.
+func (c *CounterVec) Inc(labelValue string) {
+	c.mu.Lock()
+	c.values[labelValue]++
+	c.mu.Unlock()
+}
+
+// Count returns value of counter with given label value.
+func (c *CounterVec) Count(labelValue string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[labelValue]
+}
+
+func (c *CounterVec) write(w io.Writer, openMetrics bool) {
+	c.mu.Lock()
+	values := make(map[string]float64, len(c.values))
+	for k, v := range c.values {
+		values[k] = float64(v)
+	}
+	c.mu.Unlock()
+	writeHeader(w, c.name, c.help, "counter", openMetrics)
+	writeLabeled(w, c.name, c.label, values)
+}
+
+// CounterFunc represents counters partitioned by value of single label, which
+// values are provided by function on each exposition.
+type CounterFunc struct {
+	name, help, label string
+	fn                func() map[string]float64
+}
+
+// NewCounterFunc creates and registers new counters which values are
+// returned by given function, mapped by values of given label.
+func (r *Registry) NewCounterFunc(
+	name, help, label string, fn func() map[string]float64,
+) *CounterFunc {
+	c := &CounterFunc{name: name, help: help, label: label, fn: fn}
+	r.register(c)
+	return c
+}
+
+func (c *CounterFunc) write(w io.Writer, openMetrics bool) {
+	writeHeader(w, c.name, c.help, "counter", openMetrics)
+	writeLabeled(w, c.name, c.label, c.fn())
+}
+
+// Histogram counts observed values in configurable buckets.
+type Histogram struct {
+	name, help string
+	buckets    []float64
+
+	mu     sync.Mutex
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewHistogram creates and registers new histogram with given upper bounds of
+// buckets, which must be sorted in increasing order.
+func (r *Registry) NewHistogram(name, help string, buckets []float64) *Histogram {
+	h := &Histogram{
+		name: name, help: help, buckets: buckets,
+		counts: make([]uint64, len(buckets)),
+	}
+	r.register(h)
+	return h
+}
+
+// Observe adds given value to histogram.
+func (h *Histogram) Observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		h.counts[i]++
+	}
+	h.sum += v
+	h.count++
+}
+
+func (h *Histogram) write(w io.Writer, openMetrics bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	writeHeader(w, h.name, h.help, "histogram", openMetrics)
+	cumulative := uint64(0)
+	for i, bound := range h.buckets {
+		cumulative += h.counts[i]
+		fmt.Fprintf(w, "%s_bucket{%s} %d\n",
+			h.name, formatLabel("le", formatFloat(bound)), cumulative,
+		)
+	}
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", h.name, h.count)
+	fmt.Fprintf(w, "%s_sum %s\n", h.name, formatFloat(h.sum))
+	fmt.Fprintf(w, "%s_count %d\n", h.name, h.count)
+}
+
+// Exemplar is observed value with labels which tell where it comes from, like
+// ID of request. Exemplars are exposed in OpenMetrics format only.
+type Exemplar struct {
+	Labels map[string]string
+	Value  float64
+	Time   time.Time
+}
+
+// HistogramVec represents histograms partitioned by values of labels, which
+// keep the latest exemplar of each bucket.
+type HistogramVec struct {
+	name, help string
+	labels     []string
+
+	mu      sync.Mutex
+	buckets []float64
+	series  map[string]*histogramSeries
+}
+
+// Observations of single combination of label values.
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64
+	exemplars   []*Exemplar // the last one is of +Inf bucket
+	sum         float64
+	count       uint64
+}
+
+// NewHistogramVec creates and registers new histograms partitioned by given
+// labels, with given upper bounds of buckets, which must be sorted in
+// increasing order.
+func (r *Registry) NewHistogramVec(
+	name, help string, buckets []float64, labels ...string,
+) *HistogramVec {
+	h := &HistogramVec{
+		name: name, help: help, labels: labels, buckets: buckets,
+		series: make(map[string]*histogramSeries),
+	}
+	r.register(h)
+	return h
+}
+
+// SetBuckets replaces upper bounds of buckets, which must be sorted in
+// increasing order. Already observed values are discarded.
+func (h *HistogramVec) SetBuckets(buckets []float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.buckets = buckets
+	h.series = make(map[string]*histogramSeries)
+}
+
+// Observe adds given value to histogram with given label values, which must
+// be given in order of labels. Value is kept as exemplar of its bucket with
+// given exemplar labels, unless they are empty.
+func (h *HistogramVec) Observe(
+	v float64, exemplar map[string]string, labelValues ...string,
+) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := strings.Join(labelValues, "\xff")
+	s, exists := h.series[key]
+	if !exists {
+		s = &histogramSeries{
+			labelValues: labelValues,
+			counts:      make([]uint64, len(h.buckets)),
+			exemplars:   make([]*Exemplar, len(h.buckets)+1),
+		}
+		h.series[key] = s
+	}
+	i := sort.SearchFloat64s(h.buckets, v)
+	if i < len(h.buckets) {
+		s.counts[i]++
+	}
+	if len(exemplar) > 0 {
+		s.exemplars[i] = &Exemplar{Labels: exemplar, Value: v, Time: time.Now()}
+	}
+	s.sum += v
+	s.count++
+}
+
+// Count returns number of values observed with given label values.
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if s, exists := h.series[strings.Join(labelValues, "\xff")]; exists {
+		return s.count
+	}
+	return 0
+}
+
+func (h *HistogramVec) write(w io.Writer, openMetrics bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	writeHeader(w, h.name, h.help, "histogram", openMetrics)
+	keys := make([]string, 0, len(h.series))
+	for k := range h.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s := h.series[k]
+		labels := make([]string, len(h.labels))
+		for i, label := range h.labels {
+			labels[i] = formatLabel(label, s.labelValues[i])
+		}
+		series := ""
+		if len(labels) > 0 {
+			series = "{" + strings.Join(labels, ",") + "}"
+		}
+		labels = append(labels, "")
+		cumulative := uint64(0)
+		for i, bound := range h.buckets {
+			cumulative += s.counts[i]
+			labels[len(labels)-1] = formatLabel("le", formatFloat(bound))
+			fmt.Fprintf(w, "%s_bucket{%s} %d%s\n",
+				h.name, strings.Join(labels, ","), cumulative,
+				formatExemplar(s.exemplars[i], openMetrics),
+			)
+		}
+		labels[len(labels)-1] = `le="+Inf"`
+		fmt.Fprintf(w, "%s_bucket{%s} %d%s\n",
+			h.name, strings.Join(labels, ","), s.count,
+			formatExemplar(s.exemplars[len(h.buckets)], openMetrics),
+		)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, series, formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, series, s.count)
+	}
+}
+
+// Helper function which formats given exemplar as suffix of bucket sample,
+// or returns empty string if there is no exemplar or format has no exemplars.
+func formatExemplar(e *Exemplar, openMetrics bool) string {
+	if e == nil || !openMetrics {
+		return ""
+	}
+	names := make([]string, 0, len(e.Labels))
+	for name := range e.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	labels := make([]string, len(names))
+	for i, name := range names {
+		labels[i] = formatLabel(name, e.Labels[name])
+	}
+	return fmt.Sprintf(" # {%s} %s %s",
+		strings.Join(labels, ","), formatFloat(e.Value),
+		strconv.FormatFloat(float64(e.Time.UnixNano())/1e9, 'f', 3, 64),
+	)
+}
+
+// Helper function which writes HELP and TYPE lines of metric. OpenMetrics
+// names counter families without "_total" suffix of their samples.
+func writeHeader(w io.Writer, name, help, typ string, openMetrics bool) {
+	if openMetrics && typ == "counter" {
+		name = strings.TrimSuffix(name, "_total")
+	}
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// Helper function which writes values of metric sorted by label values.
+func writeLabeled(w io.Writer, name, label string, values map[string]float64) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s{%s} %s\n", name, formatLabel(label, k), formatFloat(values[k]))
+	}
+}
+
+// Escaper of label values, as text exposition format escapes only backslash,
+// double quote and line feed, unlike Go quoting.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Helper function which formats label pair accordingly to text exposition
+// format.
+func formatLabel(name, value string) string {
+	return name + `="` + labelEscaper.Replace(value) + `"`
+}
+
+// Helper function which formats float value accordingly to text exposition
+// format.
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
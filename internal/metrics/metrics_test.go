@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRegistryWriteText(t *testing.T) {
+	r := NewRegistry()
+	requests := r.NewCounterVec("test_requests_total", "Requests.", "status")
+	duration := r.NewHistogram("test_duration_seconds", "Duration.", []float64{.1, 1})
+	r.NewCounterFunc("test_bytes_total", "Bytes.", "log",
+		func() map[string]float64 {
+			return map[string]float64{"b": 2, "a": 1}
+		},
+	)
+
+	requests.Inc("404")
+	requests.Inc("200")
+	requests.Inc("200")
+	duration.Observe(.05)
+	duration.Observe(.5)
+	duration.Observe(5)
+
+	buf := &bytes.Buffer{}
+	r.WriteText(buf)
+
+	expected := `# HELP test_requests_total Requests.
+# TYPE test_requests_total counter
+test_requests_total{status="200"} 2
+test_requests_total{status="404"} 1
+# HELP test_duration_seconds Duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{le="0.1"} 1
+test_duration_seconds_bucket{le="1"} 2
+test_duration_seconds_bucket{le="+Inf"} 3
+test_duration_seconds_sum 5.55
+test_duration_seconds_count 3
+# HELP test_bytes_total Bytes.
+# TYPE test_bytes_total counter
+test_bytes_total{log="a"} 1
+test_bytes_total{log="b"} 2
+`
+	if buf.String() != expected {
+		t.Errorf("metrics text expected:\n%s\nactual:\n%s", expected, buf.String())
+	}
+}
+
+func TestHistogramVecExemplars(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounterVec("test_requests_total", "Requests.", "status").Inc("200")
+	duration := r.NewHistogramVec("test_parse_seconds", "Parse.", []float64{1}, "result")
+	duration.SetBuckets([]float64{.1, 1})
+
+	duration.Observe(.05, map[string]string{"request_id": "a"}, "ok")
+	duration.Observe(.5, nil, "ok")
+	duration.Observe(5, map[string]string{"request_id": "b"}, "error")
+	if count := duration.Count("ok"); count != 2 {
+		t.Errorf("expected 2 observed values, got %d", count)
+	}
+
+	// Exemplars are written in OpenMetrics format only, and timestamps of
+	// them vary
+	buf := &bytes.Buffer{}
+	r.WriteOpenMetrics(buf)
+	actual := regexp.MustCompile(`\} (\S+) \d+\.\d{3}\n`).ReplaceAllString(buf.String(), "} $1 T\n")
+	expected := `# HELP test_requests Requests.
+# TYPE test_requests counter
+test_requests_total{status="200"} 1
+# HELP test_parse_seconds Parse.
+# TYPE test_parse_seconds histogram
+test_parse_seconds_bucket{result="error",le="0.1"} 0
+test_parse_seconds_bucket{result="error",le="1"} 0
+test_parse_seconds_bucket{result="error",le="+Inf"} 1 # {request_id="b"} 5 T
+test_parse_seconds_sum{result="error"} 5
+test_parse_seconds_count{result="error"} 1
+test_parse_seconds_bucket{result="ok",le="0.1"} 1 # {request_id="a"} 0.05 T
+test_parse_seconds_bucket{result="ok",le="1"} 2
+test_parse_seconds_bucket{result="ok",le="+Inf"} 2
+test_parse_seconds_sum{result="ok"} 0.55
+test_parse_seconds_count{result="ok"} 2
+# EOF
+`
+	if actual != expected {
+		t.Errorf("OpenMetrics text expected:\n%s\nactual:\n%s", expected, actual)
+	}
+
+	buf.Reset()
+	r.WriteText(buf)
+	if strings.Contains(buf.String(), "request_id") {
+		t.Errorf("exemplars must not be written in text exposition format:\n%s", buf.String())
+	}
+}
+
+func TestFormatLabel(t *testing.T) {
+	for value, expected := range map[string]string{
+		`GET /`:         `path="GET /"`,
+		`C:\logs`:       `path="C:\\logs"`,
+		`say "hi"`:      `path="say \"hi\""`,
+		"a\nb":          `path="a\nb"`,
+		"tab\there":     "path=\"tab\there\"",
+		"héllo \u2028!": "path=\"héllo \u2028!\"",
+	} {
+		if actual := formatLabel("path", value); actual != expected {
+			t.Errorf("invalid label of '%s'\n\texpected: %s\n\tactual: %s", value, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package parser
+
+// BitfieldRun is run of consecutive bool fields, which C would pack into
+// bits of single integer if they were declared as bitfields.
+type BitfieldRun struct {
+	Fields []*TypeInfo
+	// Bytes occupied by fields, and by integer with one bit per field
+	Size       uint64
+	PackedSize uint64
+}
+
+// BitfieldNote estimates how much smaller struct would be if its runs of bool
+// fields were packed into bits, as C bitfields do. It is educational only:
+// Go has no bitfields, so flags take a byte each unless they are packed into
+// integer manually, and real layout is not changed.
+type BitfieldNote struct {
+	Runs []*BitfieldRun
+	// Size of struct with runs replaced by integers
+	PackedSize uint64
+	Saved      uint64
+}
+
+// AnalyzeBitfields returns bitfield note for given struct laid out for given
+// architecture, or nil if it has no runs of two or more bool fields.
+func AnalyzeBitfields(strct *TypeInfo, arch *Arch) *BitfieldNote {
+	if !strct.IsStruct {
+		return nil
+	}
+	isFlag := func(typ *TypeInfo) bool {
+		return typ.TypeName == "bool" && !typ.IsArray && !typ.IsStruct
+	}
+
+	note := &BitfieldNote{}
+	packed := *strct
+	packed.Fields = make([]*TypeInfo, 0, len(strct.Fields))
+	for i := 0; i < len(strct.Fields); i++ {
+		j := i
+		for j < len(strct.Fields) && isFlag(strct.Fields[j]) {
+			j++
+		}
+		if j-i < 2 {
+			f := *strct.Fields[i]
+			packed.Fields = append(packed.Fields, &f)
+			continue
+		}
+		flags := packedFlags(uint64(j-i), arch)
+		last := strct.Fields[j-1]
+		note.Runs = append(note.Runs, &BitfieldRun{
+			Fields:     strct.Fields[i:j],
+			Size:       last.Offset + last.Sizeof - strct.Fields[i].Offset,
+			PackedSize: flags.Sizeof,
+		})
+		packed.Fields = append(packed.Fields, flags)
+		i = j - 1
+	}
+	if len(note.Runs) < 1 {
+		return nil
+	}
+	layoutStruct(&packed)
+	note.PackedSize = packed.Sizeof
+	if packed.Sizeof < strct.Sizeof {
+		note.Saved = strct.Sizeof - packed.Sizeof
+	}
+	return note
+}
+
+// Returns the smallest unsigned integer which holds given number of flags,
+// or array of uint64 if there are more than 64 of them.
+func packedFlags(n uint64, arch *Arch) *TypeInfo {
+	size := uint64(1)
+	for size*8 < n && size < 8 {
+		size *= 2
+	}
+	if size*8 < n {
+		size = (n + 63) / 64 * 8
+	}
+	return &TypeInfo{
+		Sizeof:  size,
+		Alignof: arch.basicAlign("uint64", min(size, 8)),
+		Name:    "flags",
+		IsFixed: true,
+	}
+}
@@ -0,0 +1,65 @@
+package parser
+
+import "testing"
+
+func TestAnalyzeBitfields(t *testing.T) {
+	arch := Archs["amd64"]
+	typ, err := ParseCode(`struct{a, b, c, d, e, f, g, h, i bool; x int32; y bool; z, w bool}`)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	note := AnalyzeBitfields(typ, arch)
+	if note == nil {
+		t.Fatalf("expected bitfield note")
+	}
+	// 9 flags fit uint16 before x, and the last 3 flags fit uint8 after it
+	if typ.Sizeof != 20 || note.PackedSize != 12 || note.Saved != 8 {
+		t.Errorf("invalid packed size: %d of %d, saved %d", note.PackedSize, typ.Sizeof, note.Saved)
+	}
+	expected := []struct {
+		fields           int
+		size, packedSize uint64
+	}{
+		{9, 9, 2},
+		{3, 3, 1},
+	}
+	if len(note.Runs) != len(expected) {
+		t.Fatalf("invalid number of runs, expected: %d, actual: %d",
+			len(expected), len(note.Runs))
+	}
+	for i, e := range expected {
+		run := note.Runs[i]
+		if len(run.Fields) != e.fields || run.Size != e.size || run.PackedSize != e.packedSize {
+			t.Errorf(
+				"invalid run #%d\n\texpected: %d fields, size %d, packed %d\n\tactual: %d fields, size %d, packed %d",
+				i, e.fields, e.size, e.packedSize, len(run.Fields), run.Size, run.PackedSize,
+			)
+		}
+	}
+	if typ.Fields[9].Offset != 12 {
+		t.Errorf("real layout must not change, offset of x is %d", typ.Fields[9].Offset)
+	}
+
+	// Flags beyond 64 are packed into array of uint64
+	if flags := packedFlags(70, arch); flags.Sizeof != 16 || flags.Alignof != 8 {
+		t.Errorf("invalid integer for 70 flags: size %d, align %d", flags.Sizeof, flags.Alignof)
+	}
+	if flags := packedFlags(33, Archs["386"]); flags.Sizeof != 8 || flags.Alignof != 4 {
+		t.Errorf("invalid integer for 33 flags on 386: size %d, align %d", flags.Sizeof, flags.Alignof)
+	}
+
+	for _, code := range []string{
+		`struct{a bool; x int64; b bool}`,
+		`struct{a [70]bool}`,
+		`struct{}`,
+		`bool`,
+	} {
+		typ, err := ParseCode(code)
+		if err != nil {
+			t.Fatalf("failed to parse code '%s', reason -> %s", code, err.Error())
+		}
+		if note := AnalyzeBitfields(typ, arch); note != nil {
+			t.Errorf("unexpected bitfield note for '%s': %+v", code, note)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package parser
+
+import "fmt"
+
+// Cache line size used when none is given.
+const DefaultCacheLineSize = 64
+
+// AnnotateCacheLines sets cache lines occupied by given type and all of its
+// fields (including fields of nested structs), assuming that type starts at
+// the beginning of cache line of given size.
+func AnnotateCacheLines(typ *TypeInfo, lineSize uint64) error {
+	if lineSize == 0 {
+		return fmt.Errorf("invalid cache line size %d", lineSize)
+	}
+	annotateCacheLines(typ, 0, lineSize)
+	return nil
+}
+
+func annotateCacheLines(typ *TypeInfo, offset, lineSize uint64) {
+	typ.FirstCacheLine = offset / lineSize
+	typ.LastCacheLine = typ.FirstCacheLine
+	if typ.Sizeof > 0 {
+		typ.LastCacheLine = (offset + typ.Sizeof - 1) / lineSize
+	}
+	typ.CrossesCacheLine = typ.LastCacheLine != typ.FirstCacheLine
+	for _, field := range typ.Fields {
+		annotateCacheLines(field, offset+field.Offset, lineSize)
+	}
+}
@@ -0,0 +1,49 @@
+package parser
+
+import "testing"
+
+func TestAnnotateCacheLines(t *testing.T) {
+	typ, err := ParseCode(`struct{a [60]byte; b int32; c int64; n struct{d [50]byte; e int64}}`)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	if err = AnnotateCacheLines(typ, DefaultCacheLineSize); err != nil {
+		t.Fatalf("failed to annotate cache lines, reason -> %s", err.Error())
+	}
+	n := typ.Fields[3]
+	cases := []struct {
+		name          string
+		typ           *TypeInfo
+		first, last   uint64
+		crossesBorder bool
+	}{
+		{"struct", typ, 0, 2, true},
+		{"a", typ.Fields[0], 0, 0, false},
+		{"b", typ.Fields[1], 0, 0, false},
+		{"c", typ.Fields[2], 1, 1, false},
+		{"n", n, 1, 2, true},
+		{"n.d", n.Fields[0], 1, 1, false},
+		{"n.e", n.Fields[1], 2, 2, false},
+	}
+	for _, c := range cases {
+		if c.typ.FirstCacheLine != c.first ||
+			c.typ.LastCacheLine != c.last ||
+			c.typ.CrossesCacheLine != c.crossesBorder {
+			t.Errorf(
+				"invalid cache lines of '%s'\n\texpected: %d-%d %v\n\tactual: %d-%d %v",
+				c.name, c.first, c.last, c.crossesBorder,
+				c.typ.FirstCacheLine, c.typ.LastCacheLine, c.typ.CrossesCacheLine,
+			)
+		}
+	}
+
+	if err = AnnotateCacheLines(typ, 32); err != nil {
+		t.Fatalf("failed to annotate cache lines, reason -> %s", err.Error())
+	}
+	if !typ.Fields[0].CrossesCacheLine || typ.Fields[0].LastCacheLine != 1 {
+		t.Errorf("field 'a' must cross 32 bytes cache line")
+	}
+	if AnnotateCacheLines(typ, 0) == nil {
+		t.Errorf("expected error for zero cache line size")
+	}
+}
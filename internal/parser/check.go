@@ -0,0 +1,83 @@
+package parser
+
+import "fmt"
+
+// NaturalAlign returns natural alignment of type: maximum alignment of
+// struct fields, or 1 for struct without fields. Natural alignment of
+// other types is their own alignment.
+func (typ *TypeInfo) NaturalAlign() uint64 {
+	if !typ.IsStruct {
+		return typ.Alignof
+	}
+	align := uint64(1)
+	for _, field := range typ.Fields {
+		if field.Alignof > align {
+			align = field.Alignof
+		}
+	}
+	return align
+}
+
+// PaddedSize returns end of last struct field rounded up to natural
+// alignment of struct. Like gc compiler, struct which ends with zero sized
+// field is padded by at least one byte. Padded size of other types is
+// their own size.
+func (typ *TypeInfo) PaddedSize() uint64 {
+	if !typ.IsStruct || len(typ.Fields) < 1 {
+		return typ.Sizeof
+	}
+	last := typ.Fields[len(typ.Fields)-1]
+	end := last.Offset + last.Sizeof
+	if last.Sizeof == 0 && end > 0 {
+		end++
+	}
+	return alignUp(end, typ.NaturalAlign())
+}
+
+// CheckLayout verifies internal consistency of computed layout of type and
+// its nested structs: struct is aligned to its natural alignment and its
+// size is padded to it, and each field is aligned and placed after the
+// previous one.
+func (typ *TypeInfo) CheckLayout() error {
+	if typ.Alignof == 0 || typ.Sizeof%typ.Alignof != 0 {
+		return fmt.Errorf(
+			"size %d of type '%s' is not multiple of its alignment %d",
+			typ.Sizeof, typ.Name, typ.Alignof,
+		)
+	}
+	if !typ.IsStruct {
+		return nil
+	}
+	if align := typ.NaturalAlign(); typ.Alignof != align {
+		return fmt.Errorf(
+			"alignment %d of struct '%s' differs from natural alignment %d",
+			typ.Alignof, typ.Name, align,
+		)
+	}
+	if size := typ.PaddedSize(); typ.Sizeof != size {
+		return fmt.Errorf(
+			"size %d of struct '%s' differs from padded size %d",
+			typ.Sizeof, typ.Name, size,
+		)
+	}
+	end := uint64(0)
+	for _, field := range typ.Fields {
+		switch {
+		case field.Offset%field.Alignof != 0:
+			return fmt.Errorf(
+				"offset %d of field '%s' is not multiple of its alignment %d",
+				field.Offset, field.FieldName, field.Alignof,
+			)
+		case field.Offset < end:
+			return fmt.Errorf(
+				"field '%s' at offset %d overlaps previous field",
+				field.FieldName, field.Offset,
+			)
+		}
+		if err := field.CheckLayout(); err != nil {
+			return err
+		}
+		end = field.Offset + field.Sizeof
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"testing"
+	"unsafe"
+)
+
+type checkMixed struct {
+	a bool
+	b int64
+	c int16
+}
+
+type checkSmall struct {
+	a, b byte
+	c    int16
+}
+
+type checkZeroTail struct {
+	a int32
+	b struct{}
+}
+
+type checkNested struct {
+	a bool
+	n checkSmall
+	p *int
+}
+
+func TestCheckLayout(t *testing.T) {
+	cases := []struct {
+		code        string
+		size, align uintptr
+	}{
+		{`struct{a bool; b int64; c int16}`, unsafe.Sizeof(checkMixed{}), unsafe.Alignof(checkMixed{})},
+		{`struct{a, b byte; c int16}`, unsafe.Sizeof(checkSmall{}), unsafe.Alignof(checkSmall{})},
+		{`struct{a int32; b struct{}}`, unsafe.Sizeof(checkZeroTail{}), unsafe.Alignof(checkZeroTail{})},
+		{`struct{a bool; n struct{a, b byte; c int16}; p *int}`,
+			unsafe.Sizeof(checkNested{}), unsafe.Alignof(checkNested{})},
+		{`struct{}`, unsafe.Sizeof(struct{}{}), unsafe.Alignof(struct{}{})},
+	}
+	for _, c := range cases {
+		typ, err := ParseCodeArch(c.code, hostArch(t))
+		if err != nil {
+			t.Fatalf("failed to parse code '%s', reason -> %s", c.code, err.Error())
+		}
+		if err = typ.CheckLayout(); err != nil {
+			t.Errorf("inconsistent layout of '%s': %s", c.code, err.Error())
+		}
+		if typ.NaturalAlign() != uint64(c.align) || typ.Alignof != uint64(c.align) ||
+			typ.PaddedSize() != uint64(c.size) || typ.Sizeof != uint64(c.size) {
+			t.Errorf(
+				"invalid layout of '%s'\n\texpected: size %d, align %d"+
+					"\n\tactual: size %d, padded size %d, align %d, natural align %d",
+				c.code, c.size, c.align,
+				typ.Sizeof, typ.PaddedSize(), typ.Alignof, typ.NaturalAlign(),
+			)
+		}
+	}
+
+	typ, _ := ParseCode(`struct{a bool; b int64}`)
+	typ.Fields[1].Offset = 4
+	if err := typ.CheckLayout(); err == nil {
+		t.Errorf("misaligned field is not detected")
+	}
+	typ.Fields[1].Offset = 8
+	typ.Sizeof = 12
+	if err := typ.CheckLayout(); err == nil {
+		t.Errorf("unpadded struct size is not detected")
+	}
+}
@@ -0,0 +1,66 @@
+package parser
+
+// FieldDelta is change of single struct field between two versions
+// of struct, matched by field name.
+type FieldDelta struct {
+	Name string
+	// Field in each version, nil if field was added or removed
+	Before *TypeInfo
+	After  *TypeInfo
+	// Changes of offset and size of field present in both versions
+	Shift  int64
+	Resize int64
+}
+
+// Added returns whether field exists only in new version of struct.
+func (d *FieldDelta) Added() bool {
+	return d.Before == nil
+}
+
+// Removed returns whether field exists only in old version of struct.
+func (d *FieldDelta) Removed() bool {
+	return d.After == nil
+}
+
+// LayoutDelta is difference between layouts of two versions of type.
+// Positive values mean that new version is larger.
+type LayoutDelta struct {
+	Size    int64
+	Align   int64
+	Padding int64
+	// Fields of new version in their order, followed by removed fields
+	Fields []*FieldDelta
+}
+
+// CompareLayouts returns difference between layouts of given
+// old and new versions of type.
+func CompareLayouts(before, after *TypeInfo) *LayoutDelta {
+	delta := &LayoutDelta{
+		Size:    int64(after.Sizeof) - int64(before.Sizeof),
+		Align:   int64(after.Alignof) - int64(before.Alignof),
+		Padding: int64(after.TotalPadding()) - int64(before.TotalPadding()),
+	}
+	old := make(map[string]*TypeInfo, len(before.Fields))
+	for _, field := range before.Fields {
+		old[field.FieldName] = field
+	}
+	for _, field := range after.Fields {
+		d := &FieldDelta{Name: field.FieldName, After: field}
+		if prev, exists := old[field.FieldName]; exists {
+			d.Before = prev
+			d.Shift = int64(field.Offset) - int64(prev.Offset)
+			d.Resize = int64(field.Sizeof) - int64(prev.Sizeof)
+			delete(old, field.FieldName)
+		}
+		delta.Fields = append(delta.Fields, d)
+	}
+	for _, field := range before.Fields {
+		if _, removed := old[field.FieldName]; removed {
+			delta.Fields = append(delta.Fields, &FieldDelta{
+				Name:   field.FieldName,
+				Before: field,
+			})
+		}
+	}
+	return delta
+}
@@ -0,0 +1,46 @@
+package parser
+
+import "testing"
+
+func TestCompareLayouts(t *testing.T) {
+	arch := Archs[DefaultArch]
+	before, err := ParseCodeArch(`struct{a bool; b int64; c bool; d int16}`, arch)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	after, err := ParseCodeArch(`struct{b int64; a bool; c bool; e int32}`, arch)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	delta := CompareLayouts(before, after)
+	if delta.Size != -8 || delta.Align != 0 || delta.Padding != -10 {
+		t.Errorf("invalid delta: size %d, align %d, padding %d",
+			delta.Size, delta.Align, delta.Padding)
+	}
+	if len(delta.Fields) != 5 {
+		t.Fatalf("invalid number of field deltas, expected: 5, actual: %d", len(delta.Fields))
+	}
+	expected := []struct {
+		name           string
+		shift          int64
+		added, removed bool
+	}{
+		{"b", -8, false, false},
+		{"a", 8, false, false},
+		{"c", -7, false, false},
+		{"e", 0, true, false},
+		{"d", 0, false, true},
+	}
+	for i, e := range expected {
+		d := delta.Fields[i]
+		if d.Name != e.name || d.Shift != e.shift ||
+			d.Added() != e.added || d.Removed() != e.removed {
+			t.Errorf(
+				"invalid delta of field #%d\n\texpected: %s (shift %d, added %t, removed %t)"+
+					"\n\tactual: %s (shift %d, added %t, removed %t)",
+				i, e.name, e.shift, e.added, e.removed,
+				d.Name, d.Shift, d.Added(), d.Removed(),
+			)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	. "go/ast"
+	. "go/parser"
+	"go/token"
+	"strings"
+)
+
+// NamedType is type declared in parsed source. Layout of generic type is
+// not known without type arguments, so it has only names of type parameters.
+type NamedType struct {
+	Name       string
+	Type       *TypeInfo
+	TypeParams []string
+}
+
+var errNoDecls = errors.New("no type declarations found")
+
+// ParseDecls parses given source, which is either single type expression or
+// list of type declarations, and computes layout of each declared type on
+// given architecture. Declared types may refer to each other. Single type
+// expression results in one declaration with empty name.
+func ParseDecls(code string, arch *Arch) ([]*NamedType, error) {
+	return ParseDeclsContext(context.Background(), code, arch)
+}
+
+// ParseDeclsContext is like ParseDecls, but stops computing layouts
+// when given context is done. Layouts of given instantiations of generic
+// types (like "Box[int64]") are appended to declared types.
+func ParseDeclsContext(
+	ctx context.Context,
+	code string,
+	arch *Arch,
+	insts ...string,
+) ([]*NamedType, error) {
+	_, exprErr := ParseExpr(code)
+	if exprErr == nil && len(insts) == 0 {
+		typ, err := parseCode(ctx, code, arch)
+		if err != nil {
+			return nil, err
+		}
+		return []*NamedType{{Type: typ}}, nil
+	}
+
+	src, specs, err := newSource(ctx, code, arch, exprErr)
+	if err != nil {
+		return nil, err
+	}
+	if len(specs) < 1 {
+		return nil, errNoDecls
+	}
+
+	decls := make([]*NamedType, 0, len(specs)+len(insts))
+	for _, spec := range specs {
+		if params := typeParamNames(spec); len(params) > 0 {
+			decls = append(decls, &NamedType{
+				Name:       spec.Name.Name,
+				TypeParams: params,
+			})
+			continue
+		}
+		typ, _, err := src.resolve(spec.Name.Name, nil)
+		if err != nil {
+			return nil, typeError(spec.Name.Name, err)
+		}
+		decls = append(decls, &NamedType{Name: spec.Name.Name, Type: typ})
+	}
+	for _, inst := range insts {
+		typ, err := src.instantiate(inst)
+		if err != nil {
+			return nil, typeError(inst, err)
+		}
+		decls = append(decls, &NamedType{Name: inst, Type: typ})
+	}
+	return decls, nil
+}
+
+// ParseTypeContext computes layout of given type expression (like "[]byte"
+// or "[4]Point"), which may refer to types declared in given source.
+// Source may be empty, when expression refers only to predeclared types.
+func ParseTypeContext(ctx context.Context, code, expr string, arch *Arch) (*TypeInfo, error) {
+	if strings.TrimSpace(code) == "" {
+		return parseCode(ctx, expr, arch)
+	}
+	_, exprErr := ParseExpr(code)
+	src, _, err := newSource(ctx, code, arch, exprErr)
+	if err != nil {
+		return nil, err
+	}
+	typ, err := src.instantiate(expr)
+	if err != nil {
+		return nil, typeError(expr, err)
+	}
+	return typ, nil
+}
+
+// Parses given list of type declarations, and returns source
+// which resolves declared types, along with their declarations.
+// Given error of parsing code as type expression is reported
+// when code does not look like declarations.
+func newSource(
+	ctx context.Context,
+	code string,
+	arch *Arch,
+	exprErr error,
+) (*source, []*TypeSpec, error) {
+	header := ""
+	if !strings.HasPrefix(strings.TrimSpace(code), "package") {
+		header = "package p\n"
+	}
+	fset := token.NewFileSet()
+	file, err := ParseFile(fset, "", header+code, 0)
+	src := &source{
+		ctx:       ctx,
+		fset:      fset,
+		code:      header + code,
+		arch:      arch,
+		decls:     make(map[string]*TypeSpec),
+		consts:    make(map[string]Expr),
+		resolving: make(map[string]bool),
+		resolved:  make(map[string]*resolvedType),
+		header:    strings.Count(header, "\n"),
+	}
+	var specs []*TypeSpec
+	if file != nil {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*GenDecl)
+			if ok && gen.Tok == token.CONST {
+				src.addConsts(gen)
+			}
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*TypeSpec)
+				specs = append(specs, spec)
+				src.decls[spec.Name.Name] = spec
+			}
+		}
+	}
+	if err != nil {
+		// Code which does not look like declarations is reported
+		// as invalid type expression.
+		if exprErr != nil && len(specs) < 1 && !strings.Contains(code, "type") {
+			return nil, nil, syntaxError(exprErr, nil, 0)
+		}
+		return nil, nil, syntaxError(err, specs, src.header)
+	}
+	return src, specs, nil
+}
+
+// Adds constants of given declaration to source. Constants without explicit
+// value (implicitly repeated expressions with iota) are not supported.
+func (src *source) addConsts(gen *GenDecl) {
+	for _, spec := range gen.Specs {
+		spec := spec.(*ValueSpec)
+		for i, name := range spec.Names {
+			var value Expr
+			if i < len(spec.Values) {
+				value = spec.Values[i]
+			}
+			src.consts[name.Name] = value
+		}
+	}
+}
+
+// Memoized layout of declared type.
+type resolvedType struct {
+	typ *TypeInfo
+	// Number of type infos in its tree
+	nodes int
+}
+
+// Returns key of declared type with given name instantiated with given type
+// arguments, under which its layout is memoized, or false if it cannot be
+// memoized, as some of type arguments are structs, which layouts are not
+// described by their names.
+func resolvedKey(name string, args []*TypeInfo) (string, bool) {
+	key := name
+	for _, arg := range args {
+		if len(arg.Fields) > 0 {
+			return "", false
+		}
+		key += fmt.Sprintf("\x00%s %d %d %t %t %t %t", arg.Name, arg.Sizeof, arg.Alignof,
+			arg.IsFixed, arg.IsArray, arg.IsStruct, arg.External)
+	}
+	return key, true
+}
+
+// Returns info of named type declared in source, instantiated with given
+// type arguments if it is generic. Layout is computed once and memoized,
+// while each call returns new copy of it, as struct fields are annotated
+// with their offsets.
+func (src *source) resolve(
+	name string,
+	args []*TypeInfo,
+) (typ *TypeInfo, declared bool, err error) {
+	spec, declared := src.decls[name]
+	if !declared {
+		return nil, false, nil
+	}
+	params := typeParamNames(spec)
+	switch {
+	case len(params) > 0 && len(args) == 0:
+		return nil, true, fmt.Errorf("generic type '%s' requires type arguments", name)
+	case len(params) != len(args):
+		return nil, true, fmt.Errorf(
+			"type '%s' expects %d type arguments, got %d",
+			name, len(params), len(args),
+		)
+	}
+	key, memoized := resolvedKey(name, args)
+	if resolved, exists := src.resolved[key]; memoized && exists {
+		if err = src.count(resolved.nodes); err != nil {
+			return nil, true, err
+		}
+		return resolved.typ.clone(), true, nil
+	}
+	if src.resolving[name] {
+		return nil, true, fmt.Errorf("invalid recursive type '%s'", name)
+	}
+	src.resolving[name] = true
+	defer delete(src.resolving, name)
+
+	// Declaration sees only its own type parameters
+	scope := src.scope
+	src.scope = make(map[string]*TypeInfo, len(params))
+	for i, param := range params {
+		src.scope[param] = args[i]
+	}
+	defer func() { src.scope = scope }()
+
+	if typ, err = parseType(spec.Type, src); err != nil {
+		return nil, true, err
+	}
+	if typ.Name == "struct" {
+		typ.Name = instanceName(name, args)
+	}
+	if memoized {
+		src.resolved[key] = &resolvedType{typ: typ.clone(), nodes: typ.nodeCount()}
+	}
+	return typ, true, nil
+}
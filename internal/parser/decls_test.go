@@ -0,0 +1,306 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestParseDecls(t *testing.T) {
+	code := `// Sample code
+type Point struct {
+	X, Y int32
+}
+
+type ID uint16
+
+type Shape struct {
+	ID
+	Visible bool
+	Point
+	Center  Point
+	Next    *Shape
+}
+`
+	types, err := ParseDecls(code, Archs[DefaultArch])
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	expected := []struct {
+		name string
+		size uint64
+	}{
+		{"Point", 8},
+		{"ID", 2},
+		{"Shape", 32},
+	}
+	if len(types) != len(expected) {
+		t.Fatalf("invalid number of types, expected: %d, actual: %d",
+			len(expected), len(types))
+	}
+	for i, e := range expected {
+		if types[i].Name != e.name || types[i].Type.Sizeof != e.size {
+			t.Errorf(
+				"invalid type #%d\n\texpected: %s (%d)\n\tactual: %s (%d)",
+				i, e.name, e.size, types[i].Name, types[i].Type.Sizeof,
+			)
+		}
+	}
+	if center := types[2].Type.Fields[3]; center.TypeName != "Point" || !center.IsStruct {
+		t.Errorf("invalid field 'Center': %s", center.Name)
+	}
+	if y, next := types[0].Type.Fields[1], types[2].Type.Fields[4]; y.Line != 3 || next.Line != 13 {
+		t.Errorf("invalid lines of fields 'Y' and 'Next': %d, %d", y.Line, next.Line)
+	}
+
+	types, err = ParseDecls(`struct{a bool}`, Archs[DefaultArch])
+	if err != nil || len(types) != 1 || types[0].Name != "" {
+		t.Errorf("failed to parse single type expression: %v", err)
+	}
+
+	for code, msg := range map[string]string{
+		"type A struct{a bool}\ntype B struct{b A; c C}": "type B: type error: unknown type 'C'",
+		"type A struct{b B}\ntype B struct{a A}":         "type A: type error: invalid recursive type",
+		"type A struct{a bool}\ntype B struct{b bool":    "type B: syntax error: 2:",
+		"func main() {}": errNoDecls.Error(),
+	} {
+		_, err := ParseDecls(code, Archs[DefaultArch])
+		if err == nil || !strings.HasPrefix(err.Error(), msg) {
+			t.Errorf("invalid error for code '%s'\n\texpected: %s\n\tactual: %v",
+				code, msg, err)
+		}
+	}
+}
+
+func TestParseType(t *testing.T) {
+	code := `type Point struct {
+	X, Y int32
+}
+
+type ID uint16`
+	cases := []struct {
+		code, expr  string
+		size, align uint64
+	}{
+		{"", "[]byte", 24, 8},
+		{"", "map[string]int", 8, 8},
+		{"", "[3]uint16", 6, 2},
+		{code, "[4]Point", 32, 4},
+		{code, "*Point", 8, 8},
+		{code, "ID", 2, 2},
+		{code, "struct{id ID; ok bool}", 4, 2},
+	}
+	for _, c := range cases {
+		typ, err := ParseTypeContext(context.Background(), c.code, c.expr, Archs[DefaultArch])
+		if err != nil {
+			t.Errorf("failed to parse type '%s', reason -> %s", c.expr, err.Error())
+			continue
+		}
+		if typ.Sizeof != c.size || typ.Alignof != c.align {
+			t.Errorf(
+				"invalid layout of '%s'\n\texpected: size %d, align %d\n\tactual: size %d, align %d",
+				c.expr, c.size, c.align, typ.Sizeof, typ.Alignof,
+			)
+		}
+	}
+
+	_, err := ParseTypeContext(context.Background(), code, "[2]Shape", Archs[DefaultArch])
+	if err == nil || err.Error() != "type [2]Shape: type error: unknown type 'Shape'" {
+		t.Errorf("invalid error of unknown type: %v", err)
+	}
+}
+
+type embeddedInner struct {
+	a bool
+	b int64
+	c int16
+}
+
+type embeddedPtr struct {
+	p int
+}
+
+type embeddedOuter struct {
+	x byte
+	embeddedInner
+	y bool
+	n struct {
+		i  embeddedInner
+		ok bool
+	}
+	*embeddedPtr
+	z int32
+}
+
+func TestEmbeddedDecls(t *testing.T) {
+	code := `
+type embeddedOuter struct {
+	x byte
+	embeddedInner
+	y bool
+	n struct {
+		i  embeddedInner
+		ok bool
+	}
+	*embeddedPtr
+	z int32
+}
+
+type embeddedInner struct {
+	a bool
+	b int64
+	c int16
+}
+
+type embeddedPtr struct {
+	p int
+}
+`
+	types, err := ParseDecls(code, hostArch(t))
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	outer, inner := types[0].Type, types[1].Type
+	var v embeddedOuter
+	if outer.Sizeof != uint64(unsafe.Sizeof(v)) ||
+		outer.Alignof != uint64(unsafe.Alignof(v)) ||
+		inner.Sizeof != uint64(unsafe.Sizeof(v.embeddedInner)) {
+		t.Errorf(
+			"invalid sizes of embedding types: %d (align %d), %d",
+			outer.Sizeof, outer.Alignof, inner.Sizeof,
+		)
+	}
+	offsets := []uint64{
+		uint64(unsafe.Offsetof(v.x)),
+		uint64(unsafe.Offsetof(v.embeddedInner)),
+		uint64(unsafe.Offsetof(v.y)),
+		uint64(unsafe.Offsetof(v.n)),
+		uint64(unsafe.Offsetof(v.embeddedPtr)),
+		uint64(unsafe.Offsetof(v.z)),
+	}
+	for i, field := range outer.Fields {
+		if field.Offset != offsets[i] {
+			t.Errorf(
+				"invalid offset of field '%s'\n\texpected: %d\n\tactual: %d",
+				field.Name, offsets[i], field.Offset,
+			)
+		}
+	}
+	n := outer.Fields[3]
+	if n.Fields[1].Offset != uint64(unsafe.Offsetof(v.n.ok)) {
+		t.Errorf("invalid offset of nested field 'n.ok': %d", n.Fields[1].Offset)
+	}
+	if e := outer.Fields[1]; e.FieldName != "embeddedInner" || e.Name != "embeddedInner" {
+		t.Errorf("invalid name of embedded field: '%s', '%s'", e.FieldName, e.Name)
+	}
+	if e := outer.Fields[4]; e.FieldName != "embeddedPtr" || e.Name != "embeddedPtr pointer" {
+		t.Errorf("invalid name of embedded pointer: '%s', '%s'", e.FieldName, e.Name)
+	}
+}
+
+type listNode struct {
+	next  *listNode
+	value int32
+}
+
+type treeNode struct {
+	left, right *treeNode
+	parent      *treeNode
+	children    []treeNode
+	index       map[string]*treeNode
+	leaf        bool
+}
+
+func TestSelfReferentialDecls(t *testing.T) {
+	code := `
+type listNode struct {
+	next  *listNode
+	value int32
+}
+
+type treeNode struct {
+	left, right *treeNode
+	parent      *treeNode
+	children    []treeNode
+	index       map[string]*treeNode
+	leaf        bool
+}
+
+type ping struct {
+	p *pong
+}
+
+type pong struct {
+	p [2]*ping
+	n *struct{ l listNode }
+}
+`
+	types, err := ParseDecls(code, hostArch(t))
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	ptrSize := uint64(unsafe.Sizeof(uintptr(0)))
+	for i, size := range []uint64{
+		uint64(unsafe.Sizeof(listNode{})),
+		uint64(unsafe.Sizeof(treeNode{})),
+		ptrSize,
+		3 * ptrSize,
+	} {
+		if types[i].Type.Sizeof != size {
+			t.Errorf("invalid size of type '%s'\n\texpected: %d\n\tactual: %d",
+				types[i].Name, size, types[i].Type.Sizeof)
+		}
+	}
+	if next := types[0].Type.Fields[0]; next.IsStruct || next.Sizeof != ptrSize {
+		t.Errorf("pointer to struct must be sized as pointer: %s (%d)", next.Name, next.Sizeof)
+	}
+}
+
+func TestParseDeclsContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, code := range []string{`struct{a bool}`, `type A struct{a bool}`} {
+		_, err := ParseDeclsContext(ctx, code, Archs[DefaultArch])
+		if err == nil || !strings.Contains(err.Error(), context.Canceled.Error()) {
+			t.Errorf("expected cancellation error for '%s', actual: %v", code, err)
+		}
+	}
+}
+
+func TestNestedDecls(t *testing.T) {
+	// Helper function which returns declarations of types, each of which
+	// has 8 fields of the previous one
+	nested := func(n int) string {
+		var code strings.Builder
+		code.WriteString("type T0 struct{a, b, c, d, e, f, g, h int8}\n")
+		for i := 1; i < n; i++ {
+			fmt.Fprintf(&code, "type T%d struct{a, b, c, d, e, f, g, h T%d}\n", i, i-1)
+		}
+		return code.String()
+	}
+
+	types, err := ParseDecls(nested(4), Archs[DefaultArch])
+	if err != nil {
+		t.Fatalf("failed to parse nested declarations, reason -> %s", err.Error())
+	}
+	// Fields of memoized type are laid out independently
+	typ := types[3].Type
+	if typ.Sizeof != 4096 || typ.Fields[7].Offset != 3584 || typ.Fields[7].Fields[7].Offset != 448 ||
+		typ.Fields[0].Fields[7].Offset != 448 || typ.Fields[7].Fields[0].Offset != 0 {
+		t.Errorf("invalid layout of nested declarations: size %d, offsets %d, %d",
+			typ.Sizeof, typ.Fields[7].Offset, typ.Fields[7].Fields[7].Offset)
+	}
+
+	start := time.Now()
+	_, err = ParseDecls(nested(13), Archs[DefaultArch])
+	if !errors.Is(err, ErrTooComplex) {
+		t.Errorf("expected error of too complex types, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("parsing of too complex types took %s", elapsed)
+	}
+}
@@ -0,0 +1,155 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	diagramRowBytes    = 8
+	diagramMaxBytes    = 4096
+	diagramPadding     = "░"
+	diagramTailPadding = "▒"
+	diagramKeys        = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+var errDiagramTooLarge = fmt.Errorf(
+	"type is too large for diagram, maximum size is %d bytes", diagramMaxBytes,
+)
+
+// Field placed in diagram with its absolute offset.
+type diagramField struct {
+	key    string
+	name   string
+	offset uint64
+	size   uint64
+}
+
+// Diagram renders byte-by-byte memory layout of given type as box diagram.
+// Each field occupies cells marked with its key, padding bytes are shaded
+// differently between fields and after the last field. Fields of nested
+// structs are shown separately. Diagram is followed by legend of keys and
+// shades, and by totals of used and padding bytes.
+func Diagram(typ *TypeInfo) (string, error) {
+	if typ.Sizeof > diagramMaxBytes {
+		return "", errDiagramTooLarge
+	}
+	var fields []*diagramField
+	if typ.IsStruct {
+		fields = flattenFields(typ.Fields, "", 0, nil)
+	} else {
+		fields = []*diagramField{{name: typ.Name, size: typ.Sizeof}}
+	}
+	if len(fields) > len(diagramKeys) {
+		return "", errors.New("type has too many fields for diagram")
+	}
+
+	cells := make([]string, typ.Sizeof)
+	end := uint64(0)
+	for _, field := range fields {
+		if field.offset+field.size > end {
+			end = field.offset + field.size
+		}
+	}
+	for i := range cells {
+		cells[i] = diagramPadding
+		if uint64(i) >= end {
+			cells[i] = diagramTailPadding
+		}
+	}
+	used := uint64(0)
+	for i, field := range fields {
+		field.key = diagramKeys[i : i+1]
+		for j := field.offset; j < field.offset+field.size; j++ {
+			cells[j] = field.key
+		}
+		used += field.size
+	}
+
+	var b strings.Builder
+	width := uint64(diagramRowBytes)
+	if typ.Sizeof < width {
+		width = typ.Sizeof
+	}
+	if width > 0 {
+		header := "      "
+		for i := uint64(0); i < width; i++ {
+			header += fmt.Sprintf("%3d ", i)
+		}
+		b.WriteString(strings.TrimRight(header, " ") + "\n")
+		for row := uint64(0); row*width < typ.Sizeof; row++ {
+			left, mid, right := "├", "┼", "┤"
+			if row == 0 {
+				left, mid, right = "┌", "┬", "┐"
+			}
+			b.WriteString(diagramBorder(width, left, mid, right))
+			fmt.Fprintf(&b, "%5d ", row*width)
+			for i := row * width; i < (row+1)*width; i++ {
+				if i < typ.Sizeof {
+					fmt.Fprintf(&b, "│ %s ", cells[i])
+				} else {
+					b.WriteString("│   ")
+				}
+			}
+			b.WriteString("│\n")
+		}
+		b.WriteString(diagramBorder(width, "└", "┴", "┘"))
+	}
+	for _, field := range fields {
+		fmt.Fprintf(
+			&b, "%s  %s (offset %d, size %d)\n",
+			field.key, field.name, field.offset, field.size,
+		)
+	}
+	padding := typ.Sizeof - used
+	if padding > typ.Sizeof-end {
+		fmt.Fprintf(&b, "%s  padding between fields\n", diagramPadding)
+	}
+	if end < typ.Sizeof {
+		fmt.Fprintf(&b, "%s  tail padding\n", diagramTailPadding)
+	}
+	digits := len(fmt.Sprint(typ.Sizeof))
+	b.WriteString("\n")
+	for _, total := range []struct {
+		name  string
+		bytes uint64
+	}{{"used", used}, {"padding", padding}, {"total", typ.Sizeof}} {
+		fmt.Fprintf(&b, "%-8s %*d bytes\n", total.name, digits, total.bytes)
+	}
+	return b.String(), nil
+}
+
+// Returns fields of nested structs flattened to list of leaf fields
+// with absolute offsets.
+func flattenFields(
+	fields []*TypeInfo,
+	prefix string,
+	offset uint64,
+	flat []*diagramField,
+) []*diagramField {
+	for _, field := range fields {
+		name := prefix + field.FieldName
+		if name == prefix {
+			name = prefix + field.TypeName
+		}
+		if field.IsStruct && len(field.Fields) > 0 {
+			flat = flattenFields(
+				field.Fields, name+".", offset+field.Offset, flat,
+			)
+			continue
+		}
+		flat = append(flat, &diagramField{
+			name:   name + " " + field.TypeName,
+			offset: offset + field.Offset,
+			size:   field.Sizeof,
+		})
+	}
+	return flat
+}
+
+// Returns horizontal border line of diagram with given corner characters.
+func diagramBorder(width uint64, left, mid, right string) string {
+	return "      " + left +
+		strings.Repeat("───"+mid, int(width)-1) + "───" + right + "\n"
+}
@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiagram(t *testing.T) {
+	typ, err := ParseCode(`struct{a bool; b int16; n struct{c bool; d int32}}`)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	diagram, err := Diagram(typ)
+	if err != nil {
+		t.Fatalf("failed to render diagram, reason -> %s", err.Error())
+	}
+	for _, line := range []string{
+		"      ┌───┬───┬───┬───┬───┬───┬───┬───┐",
+		"    0 │ A │ ░ │ B │ B │ C │ ░ │ ░ │ ░ │",
+		"    8 │ D │ D │ D │ D │   │   │   │   │",
+		"      └───┴───┴───┴───┴───┴───┴───┴───┘",
+		"C  n.c bool (offset 4, size 1)",
+		"D  n.d int32 (offset 8, size 4)",
+		"░  padding between fields",
+		"",
+		"used      8 bytes",
+		"padding   4 bytes",
+		"total    12 bytes",
+	} {
+		if !strings.Contains(diagram, line+"\n") {
+			t.Errorf("diagram does not contain line\n%s\ndiagram:\n%s", line, diagram)
+		}
+	}
+
+	// Bytes after the last field are shaded as tail padding
+	typ, err = ParseCode(`struct{a int64; b bool}`)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	if diagram, err = Diagram(typ); err != nil {
+		t.Fatalf("failed to render diagram, reason -> %s", err.Error())
+	}
+	for _, line := range []string{
+		"    8 │ B │ ▒ │ ▒ │ ▒ │ ▒ │ ▒ │ ▒ │ ▒ │",
+		"▒  tail padding",
+		"padding   7 bytes",
+	} {
+		if !strings.Contains(diagram, line+"\n") {
+			t.Errorf("diagram does not contain line\n%s\ndiagram:\n%s", line, diagram)
+		}
+	}
+	if strings.Contains(diagram, "between fields") {
+		t.Errorf("diagram without padding between fields has its legend:\n%s", diagram)
+	}
+
+	typ, err = ParseCode(`[8192]byte`)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	if _, err = Diagram(typ); err != errDiagramTooLarge {
+		t.Errorf("expected error for large type, actual: %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"fmt"
+	. "go/ast"
+	"go/scanner"
+	"strings"
+)
+
+// SyntaxError is error of parsing source code with position
+// of its first occurrence.
+type SyntaxError struct {
+	Decl   string // name of type declaration, if error occurred in it
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (err *SyntaxError) Error() string {
+	msg := fmt.Sprintf("syntax error: %d:%d: %s", err.Line, err.Column, err.Msg)
+	if err.Decl != "" {
+		return "type " + err.Decl + ": " + msg
+	}
+	return msg
+}
+
+// TypeError is error of computing layout of syntactically valid code,
+// like reference to unknown type.
+type TypeError struct {
+	Decl string // name of type declaration or instantiation, if any
+	Msg  string
+	// Code uses valid Go type expression, which layout is not supported
+	Unsupported bool
+	// Error of computing layout
+	err error
+}
+
+// Unwrap returns error of computing layout, like ErrTooComplex.
+func (err *TypeError) Unwrap() error {
+	return err.err
+}
+
+func (err *TypeError) Error() string {
+	msg := "type error: " + err.Msg
+	if err.Decl != "" {
+		return "type " + err.Decl + ": " + msg
+	}
+	return msg
+}
+
+// Error of type expression, which layout cannot be computed.
+type unsupportedError struct {
+	node Node
+	msg  string // overrides default message if set
+}
+
+func (err *unsupportedError) Error() string {
+	if err.msg != "" {
+		return err.msg
+	}
+	return fmt.Sprintf("unsupported type expression %T", err.node)
+}
+
+// Returns type error of given declaration caused by given error.
+func typeError(decl string, err error) error {
+	_, unsupported := err.(*unsupportedError)
+	return &TypeError{Decl: decl, Msg: err.Error(), Unsupported: unsupported, err: err}
+}
+
+// Returns syntax error of parsed code with position shifted by given number
+// of lines, and name of type declaration it occurred in, if any.
+func syntaxError(err error, specs []*TypeSpec, lineOffset int) error {
+	list, ok := err.(scanner.ErrorList)
+	if !ok || len(list) < 1 {
+		return fmt.Errorf("syntax error: %s", err.Error())
+	}
+	first := list[0]
+	syntaxErr := &SyntaxError{
+		Line:   first.Pos.Line - lineOffset,
+		Column: first.Pos.Column,
+		Msg:    strings.Replace(first.Msg, "found 'EOF'", "found end of input", 1),
+	}
+	for _, spec := range specs {
+		if int(spec.Pos()) <= first.Pos.Offset+1 {
+			syntaxErr.Decl = spec.Name.Name
+		}
+	}
+	return syntaxErr
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSyntaxError(t *testing.T) {
+	cases := []struct {
+		code         string
+		decl         string
+		line, column int
+		msg          string
+	}{
+		{"type X struct {", "X", 1, 16, "found end of input"},
+		{"struct{a bool", "", 1, 14, "found end of input"},
+		{"type A struct{\n\ta bool\n\tb int64 c\n}", "A", 3, 10, "expected ';'"},
+		{"type A int\n\ntype B struct{\n\tx: bool\n}", "B", 4, 3, "expected"},
+		{"package main\n\ntype X struct{ a int", "X", 3, 21, "found end of input"},
+		{"[3 int", "", 1, 4, "expected ']'"},
+	}
+	for _, c := range cases {
+		_, err := ParseDecls(c.code, Archs[DefaultArch])
+		syntaxErr, ok := err.(*SyntaxError)
+		if !ok {
+			t.Errorf("expected syntax error for '%s', actual: %v", c.code, err)
+			continue
+		}
+		if syntaxErr.Decl != c.decl || syntaxErr.Line != c.line ||
+			syntaxErr.Column != c.column || !strings.Contains(syntaxErr.Msg, c.msg) {
+			t.Errorf(
+				"invalid syntax error for '%s'\n\texpected: %s %d:%d %s\n\tactual: %s %d:%d %s",
+				c.code, c.decl, c.line, c.column, c.msg,
+				syntaxErr.Decl, syntaxErr.Line, syntaxErr.Column, syntaxErr.Msg,
+			)
+		}
+	}
+}
+
+func TestTypeError(t *testing.T) {
+	cases := []struct {
+		code        string
+		decl        string
+		unsupported bool
+	}{
+		{"struct{a Foo}", "", false},
+		{"type A struct{b B}\ntype B struct{a A}", "A", false},
+		{"type A struct{t foo.Bar}", "A", true},
+		{"[1 + 1.5]byte", "", false},
+	}
+	for _, c := range cases {
+		_, err := ParseDecls(c.code, Archs[DefaultArch])
+		typeErr, ok := err.(*TypeError)
+		if !ok {
+			t.Errorf("expected type error for '%s', actual: %v", c.code, err)
+			continue
+		}
+		if typeErr.Decl != c.decl || typeErr.Unsupported != c.unsupported {
+			t.Errorf(
+				"invalid type error for '%s'\n\texpected: %s (unsupported %t)\n\tactual: %s (unsupported %t)",
+				c.code, c.decl, c.unsupported, typeErr.Decl, typeErr.Unsupported,
+			)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package parser
+
+import "fmt"
+
+// ExternalType is layout of type declared outside of parsed source (like
+// "MyType" or "models.User"), given by user, so that structs referring to
+// it can be laid out.
+type ExternalType struct {
+	Size  uint64 `json:"size"`
+	Align uint64 `json:"align"`
+}
+
+// WithExternalTypes returns copy of architecture which resolves given types
+// declared outside of parsed source, in addition to types of its own.
+// External types take precedence over well-known standard library types.
+func (arch *Arch) WithExternalTypes(types map[string]ExternalType) (*Arch, error) {
+	if len(types) == 0 {
+		return arch, nil
+	}
+	for name, ext := range types {
+		if ext.Align == 0 || ext.Align&(ext.Align-1) != 0 {
+			return nil, fmt.Errorf(
+				"alignment %d of type '%s' is not a power of two", ext.Align, name,
+			)
+		}
+		if ext.Size%ext.Align != 0 {
+			return nil, fmt.Errorf(
+				"size %d of type '%s' is not multiple of its alignment %d",
+				ext.Size, name, ext.Align,
+			)
+		}
+	}
+	c := *arch
+	c.External = types
+	return &c, nil
+}
+
+// Returns info of external type with given name, if it is given.
+func (arch *Arch) externalType(name string) (*TypeInfo, bool) {
+	ext, exists := arch.External[name]
+	if !exists {
+		return nil, false
+	}
+	return &TypeInfo{
+		Sizeof:   ext.Size,
+		Alignof:  ext.Align,
+		Name:     name,
+		IsFixed:  true,
+		External: true,
+	}, true
+}
@@ -0,0 +1,45 @@
+package parser
+
+import "testing"
+
+func TestExternalTypes(t *testing.T) {
+	arch, err := Archs[DefaultArch].WithExternalTypes(map[string]ExternalType{
+		"MyType":      {Size: 24, Align: 8},
+		"models.User": {Size: 6, Align: 2},
+	})
+	if err != nil {
+		t.Fatalf("failed to set external types, reason -> %s", err.Error())
+	}
+	if Archs[DefaultArch].External != nil {
+		t.Errorf("external types must not be set on shared architecture")
+	}
+	types, err := ParseDecls(`type A struct {
+	ok   bool
+	my   MyType
+	user models.User
+	all  [2]models.User
+	t    time.Time
+}`, arch)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	typ := types[0].Type
+	if typ.Sizeof != 80 || typ.Alignof != 8 {
+		t.Errorf("invalid layout: size %d, align %d", typ.Sizeof, typ.Alignof)
+	}
+	for i, external := range []bool{false, true, true, true, false} {
+		if field := typ.Fields[i]; field.External != external {
+			t.Errorf("field '%s' expected external: %t, got %t", field.FieldName, external, field.External)
+		}
+	}
+
+	for _, types := range []map[string]ExternalType{
+		{"Bad": {Size: 6, Align: 3}},
+		{"Bad": {Size: 6, Align: 4}},
+		{"Bad": {Size: 8}},
+	} {
+		if _, err = arch.WithExternalTypes(types); err == nil {
+			t.Errorf("invalid external types must be rejected: %v", types)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"fmt"
+	. "go/ast"
+	. "go/parser"
+	"strings"
+)
+
+// Returns names of type parameters of given type declaration.
+func typeParamNames(spec *TypeSpec) []string {
+	if spec.TypeParams == nil {
+		return nil
+	}
+	var names []string
+	for _, field := range spec.TypeParams.List {
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+// Returns info of generic type given by instantiation expression
+// (like "Box[int64]") resolved with types declared in source.
+func (src *source) instantiate(inst string) (*TypeInfo, error) {
+	expr, err := ParseExpr(inst)
+	if err != nil {
+		return nil, syntaxError(err, nil, 0)
+	}
+	return parseType(expr, src)
+}
+
+// Returns info of generic type with given name expression
+// instantiated with given type arguments.
+func (src *source) instantiateExpr(x Expr, args []Expr) (*TypeInfo, error) {
+	ident, ok := x.(*Ident)
+	if !ok {
+		return nil, errInvalidType
+	}
+	argTypes := make([]*TypeInfo, len(args))
+	for i, arg := range args {
+		typ, err := parseType(arg, src)
+		if err != nil {
+			return nil, err
+		}
+		argTypes[i] = typ
+	}
+	typ, declared, err := src.resolve(ident.Name, argTypes)
+	if !declared {
+		return nil, fmt.Errorf("unknown generic type '%s'", ident.Name)
+	}
+	return typ, err
+}
+
+// Returns name of generic type instantiated with given type arguments.
+func instanceName(name string, args []*TypeInfo) string {
+	if len(args) == 0 {
+		return name
+	}
+	names := make([]string, len(args))
+	for i, arg := range args {
+		names[i] = arg.Name
+	}
+	return name + "[" + strings.Join(names, ", ") + "]"
+}
+
+// Returns number of type infos in tree of given type info, which is the
+// type itself and its fields, recursively.
+func (typ *TypeInfo) nodeCount() int {
+	n := 1
+	for _, field := range typ.Fields {
+		n += field.nodeCount()
+	}
+	return n
+}
+
+// Returns deep copy of type info, so that it can be used as type of
+// several fields.
+func (typ *TypeInfo) clone() *TypeInfo {
+	c := *typ
+	if typ.Fields != nil {
+		c.Fields = make([]*TypeInfo, len(typ.Fields))
+		for i, field := range typ.Fields {
+			c.Fields[i] = field.clone()
+		}
+	}
+	return &c
+}
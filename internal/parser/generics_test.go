@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGenerics(t *testing.T) {
+	code := `
+type Box[T any] struct {
+	ok bool
+	v  T
+}
+
+type Pair[K comparable, V any] struct {
+	key K
+	box Box[V]
+}
+
+type Boxes struct {
+	a Box[int8]
+	b Box[string]
+}
+`
+	types, err := ParseDecls(code, Archs[DefaultArch])
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	if len(types) != 3 || types[0].Type != nil ||
+		strings.Join(types[1].TypeParams, ",") != "K,V" {
+		t.Fatalf("generic types must be returned without layout")
+	}
+	if types[2].Type.Sizeof != 32 {
+		t.Errorf("invalid size of 'Boxes': %d", types[2].Type.Sizeof)
+	}
+
+	cases := map[string]uint64{
+		"Box[int64]":            16,
+		"Box[int16]":            4,
+		"Box[[3]byte]":          4,
+		"Pair[bool, int32]":     12,
+		"Pair[Box[bool], bool]": 4,
+	}
+	for inst, size := range cases {
+		for _, arch := range []*Arch{Archs["amd64"], Archs["386"]} {
+			types, err := ParseDeclsContext(context.Background(), code, arch, inst)
+			if err != nil {
+				t.Fatalf("failed to instantiate '%s', reason -> %s", inst, err.Error())
+			}
+			typ := types[len(types)-1]
+			if typ.Name != inst {
+				t.Errorf("invalid name of instance: %s", typ.Name)
+			}
+			if arch.Name == "amd64" && typ.Type.Sizeof != size {
+				t.Errorf(
+					"invalid sizeof('%s')\n\texpected: %d\n\tactual: %d",
+					inst, size, typ.Type.Sizeof,
+				)
+			}
+		}
+	}
+	types, _ = ParseDeclsContext(context.Background(), code, Archs["386"], "Box[int64]")
+	if size := types[len(types)-1].Type.Sizeof; size != 12 {
+		t.Errorf("invalid sizeof('Box[int64]') on 386: %d", size)
+	}
+
+	for inst, msg := range map[string]string{
+		"Box":            "requires type arguments",
+		"Box[int, bool]": "expects 1 type arguments, got 2",
+		"Boxes[int]":     "expects 0 type arguments, got 1",
+		"Nope[int]":      "unknown generic type",
+		"Box[":           "syntax error",
+	} {
+		_, err := ParseDeclsContext(context.Background(), code, Archs[DefaultArch], inst)
+		if err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("invalid error for '%s'\n\texpected: %s\n\tactual: %v", inst, msg, err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	. "go/ast"
+	"go/constant"
+	"go/token"
+)
+
+var errArrayTooLarge = errors.New("array is too large")
+
+// Evaluates length of array given as constant expression: integer literals
+// combined with arithmetic operators and constants declared in source.
+func (src *source) arrayLength(n Expr) (uint64, error) {
+	val, err := src.constValue(n)
+	if err != nil {
+		return 0, err
+	}
+	num, ok := constant.Uint64Val(constant.ToInt(val))
+	if !ok {
+		return 0, errInvalidArrayLength
+	}
+	return num, nil
+}
+
+func (src *source) constValue(n Expr) (constant.Value, error) {
+	switch node := n.(type) {
+	case *BasicLit:
+		if node.Kind != token.INT {
+			return nil, errInvalidArrayLength
+		}
+		return constant.MakeFromLiteral(node.Value, node.Kind, 0), nil
+	case *ParenExpr:
+		return src.constValue(node.X)
+	case *Ident:
+		value, declared := src.consts[node.Name]
+		if !declared {
+			return nil, fmt.Errorf("unknown constant '%s'", node.Name)
+		}
+		if value == nil {
+			return nil, fmt.Errorf("unsupported constant '%s'", node.Name)
+		}
+		key := "const " + node.Name
+		if src.resolving[key] {
+			return nil, fmt.Errorf("invalid recursive constant '%s'", node.Name)
+		}
+		src.resolving[key] = true
+		defer delete(src.resolving, key)
+		return src.constValue(value)
+	case *UnaryExpr:
+		x, err := src.constValue(node.X)
+		if err != nil {
+			return nil, err
+		}
+		switch node.Op {
+		case token.ADD, token.SUB, token.XOR:
+			return constant.UnaryOp(node.Op, x, 0), nil
+		}
+	case *BinaryExpr:
+		x, err := src.constValue(node.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := src.constValue(node.Y)
+		if err != nil {
+			return nil, err
+		}
+		switch node.Op {
+		case token.SHL, token.SHR:
+			s, ok := constant.Uint64Val(y)
+			if !ok || s > 64 {
+				return nil, errInvalidArrayLength
+			}
+			return constant.Shift(x, node.Op, uint(s)), nil
+		case token.QUO, token.REM:
+			if constant.Sign(y) == 0 {
+				return nil, errors.New("division by zero in array length")
+			}
+			op := node.Op
+			if op == token.QUO {
+				op = token.QUO_ASSIGN // integer division
+			}
+			return constant.BinaryOp(x, op, y), nil
+		case token.ADD, token.SUB, token.MUL,
+			token.AND, token.OR, token.XOR, token.AND_NOT:
+			return constant.BinaryOp(x, node.Op, y), nil
+		}
+	}
+	return nil, errInvalidArrayLength
+}
@@ -0,0 +1,84 @@
+package parser
+
+import "sort"
+
+// PackingRun is run of consecutive fields smaller than struct alignment,
+// which is followed by padding.
+type PackingRun struct {
+	Fields  []*TypeInfo
+	Size    uint64
+	Padding uint64
+}
+
+// PackingHint explains padding caused by small fields (bools, small integers)
+// scattered between larger fields, and how many bytes would be saved
+// by grouping them together.
+type PackingHint struct {
+	Runs  []*PackingRun
+	Saved uint64
+}
+
+// AnalyzePacking returns packing hint for given struct, or nil if grouping
+// its small fields does not reduce its size.
+func AnalyzePacking(strct *TypeInfo) *PackingHint {
+	if !strct.IsStruct || len(strct.Fields) < 3 {
+		return nil
+	}
+	isSmall := func(typ *TypeInfo) bool {
+		return typ.Sizeof > 0 && typ.Sizeof < strct.Alignof
+	}
+
+	hint := &PackingHint{}
+	var run *PackingRun
+	runs := 0
+	for i, field := range strct.Fields {
+		if !isSmall(field) {
+			continue
+		}
+		if run == nil {
+			run = &PackingRun{}
+			runs++
+		}
+		run.Fields = append(run.Fields, field)
+		run.Size = field.Offset + field.Sizeof - run.Fields[0].Offset
+		if i+1 < len(strct.Fields) && isSmall(strct.Fields[i+1]) {
+			continue
+		}
+		if i+1 < len(strct.Fields) {
+			run.Padding = strct.Fields[i+1].Padding
+		} else {
+			run.Padding = strct.TrailingPadding
+		}
+		if run.Padding > 0 {
+			hint.Runs = append(hint.Runs, run)
+		}
+		run = nil
+	}
+	if runs < 2 || len(hint.Runs) < 1 {
+		return nil
+	}
+
+	// Place small fields together after larger ones, keeping their order
+	// except that more aligned fields go first.
+	grouped := *strct
+	grouped.Fields = make([]*TypeInfo, 0, len(strct.Fields))
+	var small []*TypeInfo
+	for _, field := range strct.Fields {
+		f := *field
+		if isSmall(field) {
+			small = append(small, &f)
+		} else {
+			grouped.Fields = append(grouped.Fields, &f)
+		}
+	}
+	sort.SliceStable(small, func(i, j int) bool {
+		return small[i].Alignof > small[j].Alignof
+	})
+	grouped.Fields = append(grouped.Fields, small...)
+	layoutStruct(&grouped)
+	if grouped.Sizeof >= strct.Sizeof {
+		return nil
+	}
+	hint.Saved = strct.Sizeof - grouped.Sizeof
+	return hint
+}
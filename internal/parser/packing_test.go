@@ -0,0 +1,53 @@
+package parser
+
+import "testing"
+
+func TestAnalyzePacking(t *testing.T) {
+	typ, err := ParseCode(`struct{a bool; x int64; b bool; c int16; y int64; d uint8}`)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	hint := AnalyzePacking(typ)
+	if hint == nil {
+		t.Fatalf("expected packing hint")
+	}
+	// 8 bytes are enough for a, b, c and d instead of 3 words
+	if typ.Sizeof != 40 || hint.Saved != 16 {
+		t.Errorf("invalid bytes saved: %d of %d", hint.Saved, typ.Sizeof)
+	}
+	expected := []struct {
+		fields        int
+		size, padding uint64
+	}{
+		{1, 1, 7},
+		{2, 4, 4},
+		{1, 1, 7},
+	}
+	if len(hint.Runs) != len(expected) {
+		t.Fatalf("invalid number of runs, expected: %d, actual: %d",
+			len(expected), len(hint.Runs))
+	}
+	for i, e := range expected {
+		run := hint.Runs[i]
+		if len(run.Fields) != e.fields || run.Size != e.size || run.Padding != e.padding {
+			t.Errorf(
+				"invalid run #%d\n\texpected: %d fields, size %d, padding %d\n\tactual: %d fields, size %d, padding %d",
+				i, e.fields, e.size, e.padding, len(run.Fields), run.Size, run.Padding,
+			)
+		}
+	}
+
+	for _, code := range []string{
+		`struct{x int64; a bool; b bool}`,
+		`struct{a bool; b int16; c int32}`,
+		`int64`,
+	} {
+		typ, err := ParseCode(code)
+		if err != nil {
+			t.Fatalf("failed to parse code '%s', reason -> %s", code, err.Error())
+		}
+		if hint := AnalyzePacking(typ); hint != nil {
+			t.Errorf("unexpected packing hint for '%s': %+v", code, hint)
+		}
+	}
+}
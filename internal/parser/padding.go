@@ -0,0 +1,95 @@
+package parser
+
+import "fmt"
+
+// PaddedField is row of explicitly padded struct layout: either struct field
+// or synthetic blank field of byte array type, which takes place of padding.
+type PaddedField struct {
+	// Struct field, nil for padding
+	Field  *TypeInfo
+	Offset uint64
+	Size   uint64
+	// Padding is at the end of struct, not between fields
+	Trailing bool
+}
+
+// Decl returns declaration of field: source of struct field, or blank field
+// like "_ [3]byte" for padding.
+func (f *PaddedField) Decl() string {
+	if f.Field != nil {
+		return f.Field.Source
+	}
+	return fmt.Sprintf("_ [%d]byte", f.Size)
+}
+
+// Comment returns description of padding field, or empty string for struct
+// field.
+func (f *PaddedField) Comment() string {
+	switch {
+	case f.Field != nil:
+		return ""
+	case f.Trailing:
+		return "trailing padding"
+	}
+	return "padding"
+}
+
+// ExplicitPadding returns fields of given struct with synthetic blank fields
+// inserted in place of padding between fields and at the end of struct, so
+// that struct declared with them has the same layout without implicit padding.
+// Returns nil if type is not a struct.
+func ExplicitPadding(strct *TypeInfo) []*PaddedField {
+	if !strct.IsStruct {
+		return nil
+	}
+	fields := make([]*PaddedField, 0, 2*len(strct.Fields)+1)
+	for _, field := range strct.Fields {
+		if field.Padding > 0 {
+			fields = append(fields, &PaddedField{
+				Offset: field.Offset - field.Padding,
+				Size:   field.Padding,
+			})
+		}
+		fields = append(fields, &PaddedField{
+			Field:  field,
+			Offset: field.Offset,
+			Size:   field.Sizeof,
+		})
+	}
+	if strct.TrailingPadding > 0 {
+		fields = append(fields, &PaddedField{
+			Offset:   strct.Sizeof - strct.TrailingPadding,
+			Size:     strct.TrailingPadding,
+			Trailing: true,
+		})
+	}
+	return fields
+}
+
+// FieldPadding attributes padding inserted immediately before struct field
+// to that field, as padding is forced by its alignment.
+type FieldPadding struct {
+	Field  *TypeInfo
+	Before uint64
+	// Offset of field is not multiple of its alignment. Layouts computed by
+	// Go rules never have such fields, but hypothetical packed layouts may.
+	Misaligned bool
+}
+
+// AttributePadding returns padding before each field of given struct, so that
+// fields which alignment wastes the most bytes can be found. Returns nil if
+// type is not a struct.
+func AttributePadding(strct *TypeInfo) []*FieldPadding {
+	if !strct.IsStruct {
+		return nil
+	}
+	fields := make([]*FieldPadding, 0, len(strct.Fields))
+	for _, field := range strct.Fields {
+		fields = append(fields, &FieldPadding{
+			Field:      field,
+			Before:     field.Padding,
+			Misaligned: field.Alignof > 0 && field.Offset%field.Alignof != 0,
+		})
+	}
+	return fields
+}
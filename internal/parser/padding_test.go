@@ -0,0 +1,85 @@
+package parser
+
+import "testing"
+
+func TestExplicitPadding(t *testing.T) {
+	typ, err := ParseCode(`struct{a bool; b int64; c int16; d struct{}}`)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	expected := []struct {
+		decl, comment string
+		offset, size  uint64
+	}{
+		{"a bool", "", 0, 1},
+		{"_ [7]byte", "padding", 1, 7},
+		{"b int64", "", 8, 8},
+		{"c int16", "", 16, 2},
+		{"d struct{}", "", 18, 0},
+		{"_ [6]byte", "trailing padding", 18, 6},
+	}
+	fields := ExplicitPadding(typ)
+	if len(fields) != len(expected) {
+		t.Fatalf("invalid number of fields, expected: %d, actual: %d", len(expected), len(fields))
+	}
+	for i, e := range expected {
+		f := fields[i]
+		if f.Decl() != e.decl || f.Comment() != e.comment || f.Offset != e.offset || f.Size != e.size {
+			t.Errorf(
+				"invalid field #%d\n\texpected: %s // %s (offset %d, size %d)"+
+					"\n\tactual: %s // %s (offset %d, size %d)",
+				i, e.decl, e.comment, e.offset, e.size,
+				f.Decl(), f.Comment(), f.Offset, f.Size,
+			)
+		}
+	}
+
+	// Explicitly padded struct has the same layout without implicit padding
+	padded, err := ParseCode(`struct{a bool; _ [7]byte; b int64; c int16; d struct{}; _ [6]byte}`)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	if padded.Sizeof != typ.Sizeof || padded.TotalPadding() != 0 {
+		t.Errorf("explicitly padded struct has size %d and %d bytes of padding",
+			padded.Sizeof, padded.TotalPadding())
+	}
+}
+
+func TestAttributePadding(t *testing.T) {
+	typ, err := ParseCode(`struct{a bool; b int32; c bool; d int64}`)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	fields := AttributePadding(typ)
+	expected := []uint64{0, 3, 0, 7}
+	if len(fields) != len(expected) {
+		t.Fatalf("invalid number of fields, expected: %d, actual: %d", len(expected), len(fields))
+	}
+	for i, before := range expected {
+		if f := fields[i]; f.Before != before || f.Misaligned {
+			t.Errorf("field '%s': expected %d bytes of padding before, got %d (misaligned %t)",
+				f.Field.FieldName, before, f.Before, f.Misaligned)
+		}
+	}
+
+	// Packed layout places fields regardless of their alignment
+	packed := *typ
+	packed.Fields = nil
+	offset := uint64(0)
+	for _, field := range typ.Fields {
+		f := *field
+		f.Offset, f.Padding = offset, 0
+		offset += f.Sizeof
+		packed.Fields = append(packed.Fields, &f)
+	}
+	for i, f := range AttributePadding(&packed) {
+		if misaligned := i == 1 || i == 3; f.Before != 0 || f.Misaligned != misaligned {
+			t.Errorf("field '%s' of packed layout: expected misaligned %t, got %t (%d bytes before)",
+				f.Field.FieldName, misaligned, f.Misaligned, f.Before)
+		}
+	}
+
+	if AttributePadding(&TypeInfo{IsFixed: true}) != nil {
+		t.Error("padding must not be attributed for non-struct type")
+	}
+}
@@ -0,0 +1,33 @@
+package parser
+
+// Size in bytes above which struct is considered large when none is given,
+// as copying it is costly when it is passed by value.
+const DefaultLargeSize = 128
+
+// Properties are performance related properties of struct layout, which
+// help to spot problems at a glance.
+type Properties struct {
+	// Size is a power of two, so struct fits allocation size class and
+	// slice elements can be indexed with shifts
+	PowerOfTwoSize bool
+	// Size is a multiple of cache line size, so elements of array of
+	// cache line aligned structs do not share cache lines
+	CacheLineSized bool
+	// Size exceeds large size threshold, so struct is better passed
+	// by pointer
+	Large bool
+}
+
+// StructProperties returns properties of given struct for given cache line
+// size and large size threshold, or nil if type is not a struct.
+func StructProperties(strct *TypeInfo, lineSize, largeSize uint64) *Properties {
+	if !strct.IsStruct {
+		return nil
+	}
+	size := strct.Sizeof
+	return &Properties{
+		PowerOfTwoSize: size > 0 && size&(size-1) == 0,
+		CacheLineSized: size > 0 && lineSize > 0 && size%lineSize == 0,
+		Large:          size > largeSize,
+	}
+}
@@ -0,0 +1,28 @@
+package parser
+
+import "testing"
+
+func TestStructProperties(t *testing.T) {
+	for code, expected := range map[string]*Properties{
+		`struct{}`:                         {},
+		`struct{a bool; b int64}`:          {PowerOfTwoSize: true},
+		`struct{a int64; b bool}`:          {PowerOfTwoSize: true},
+		`struct{a [3]int64}`:               {},
+		`struct{a [8]int64}`:               {PowerOfTwoSize: true, CacheLineSized: true},
+		`struct{a [24]int64}`:              {CacheLineSized: true, Large: true},
+		`struct{a [16]int64; b int8}`:      {Large: true},
+		`[16]int64`:                        nil,
+		`struct{a [15]int64; b [8]uint8}`:  {PowerOfTwoSize: true, CacheLineSized: true},
+		`struct{a [16]int64; b struct{}}`:  {Large: true},
+		`struct{a string; b []int; c int}`: {},
+	} {
+		typ, err := ParseCode(code)
+		if err != nil {
+			t.Fatalf("failed to parse '%s', reason -> %s", code, err.Error())
+		}
+		actual := StructProperties(typ, DefaultCacheLineSize, DefaultLargeSize)
+		if (actual == nil) != (expected == nil) || actual != nil && *actual != *expected {
+			t.Errorf("invalid properties of '%s'\n\texpected: %+v\n\tactual: %+v", code, expected, actual)
+		}
+	}
+}
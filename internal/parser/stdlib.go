@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	. "go/ast"
+	. "go/parser"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// Definitions of well-known standard library types, which mimic their
+// unexported fields with basic types of the same layout, as of gc compiler.
+// Pseudo type "align64" is zero sized field which aligns struct to 8 bytes
+// on all architectures, like the one of sync/atomic package.
+var stdlibTypes = map[string]string{
+	"time.Time":       "struct{wall uint64; ext int64; loc *int}",
+	"time.Duration":   "int64",
+	"time.Month":      "int",
+	"time.Weekday":    "int",
+	"time.Location":   "struct{name string; zone, tx []int; extend string; cacheStart, cacheEnd int64; cacheZone *int}",
+	"sync.Mutex":      "struct{state int32; sema uint32}",
+	"sync.RWMutex":    "struct{w sync.Mutex; writerSem, readerSem uint32; readerCount, readerWait int32}",
+	"sync.Once":       "struct{done uint32; m sync.Mutex}",
+	"sync.WaitGroup":  "struct{state atomic.Uint64; sema uint32}",
+	"sync.Map":        "struct{inited uint32; initMu sync.Mutex; root *int; keyHash, valEqual func(); seed uintptr}",
+	"sync.Pool":       "struct{local *int; localSize uintptr; victim *int; victimSize uintptr; New func()}",
+	"atomic.Bool":     "struct{v uint32}",
+	"atomic.Int32":    "struct{v int32}",
+	"atomic.Uint32":   "struct{v uint32}",
+	"atomic.Int64":    "struct{_ align64; v int64}",
+	"atomic.Uint64":   "struct{_ align64; v uint64}",
+	"atomic.Uintptr":  "struct{v uintptr}",
+	"atomic.Value":    "struct{v any}",
+	"bytes.Buffer":    "struct{buf []byte; off int; lastRead int8}",
+	"strings.Builder": "struct{addr *int; buf []byte}",
+	"context.Context": "interface{}",
+	"io.Reader":       "interface{}",
+	"io.Writer":       "interface{}",
+	"reflect.Type":    "interface{}",
+	"unsafe.Pointer":  "*int",
+	"net.IP":          "[]byte",
+	"http.Header":     "map[string][]string",
+	"json.RawMessage": "[]byte",
+}
+
+// StdlibTypeNames returns qualified names (like "time.Time") of well-known
+// standard library types, which sizes are known without their source.
+func StdlibTypeNames() []string {
+	names := make([]string, 0, len(stdlibTypes))
+	for name := range stdlibTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns info of standard library type given by qualified identifier
+// (like "time.Time"). Its layout is opaque, as fields are unexported.
+func (src *source) stdlibType(sel *SelectorExpr) (*TypeInfo, error) {
+	pkg, ok := sel.X.(*Ident)
+	if !ok {
+		return nil, &unsupportedError{node: sel}
+	}
+	name := pkg.Name + "." + sel.Sel.Name
+	if typ, given := src.arch.externalType(name); given {
+		return typ, nil
+	}
+	def, known := stdlibTypes[name]
+	if !known {
+		return nil, &unsupportedError{node: sel, msg: fmt.Sprintf(
+			"unknown type '%s', only sizes of well-known standard library types are known: %s",
+			name, strings.Join(StdlibTypeNames(), ", "),
+		)}
+	}
+	fset := token.NewFileSet()
+	expr, err := ParseExprFrom(fset, "", def, 0)
+	if err != nil {
+		return nil, err
+	}
+	typ, err := parseType(expr, &source{
+		ctx:  src.ctx,
+		fset: fset,
+		code: def,
+		arch: src.arch,
+		scope: map[string]*TypeInfo{
+			"align64": {Alignof: 8, Name: "align64", IsStruct: true},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &TypeInfo{
+		Sizeof:  typ.Sizeof,
+		Alignof: typ.Alignof,
+		Name:    name,
+		IsFixed: true,
+	}, nil
+}
+
+// StdlibType returns layout of well-known standard library type with given
+// package and name on given architecture, or nil if type is not known.
+func StdlibType(pkg, name string, arch *Arch) (*TypeInfo, error) {
+	if _, known := stdlibTypes[pkg+"."+name]; !known {
+		return nil, nil
+	}
+	src := &source{ctx: context.Background(), arch: arch}
+	return src.stdlibType(&SelectorExpr{X: NewIdent(pkg), Sel: NewIdent(name)})
+}
@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestStdlibTypes(t *testing.T) {
+	if runtime.GOARCH != DefaultArch {
+		t.Skipf("sizes of standard library types are compared on %s", DefaultArch)
+	}
+	layout := func(size, align uintptr) [2]uintptr { return [2]uintptr{size, align} }
+	// Every type of registry is compared with its actual layout
+	actual := map[string][2]uintptr{
+		"time.Time":       layout(unsafe.Sizeof(time.Time{}), unsafe.Alignof(time.Time{})),
+		"time.Duration":   layout(unsafe.Sizeof(time.Duration(0)), unsafe.Alignof(time.Duration(0))),
+		"time.Month":      layout(unsafe.Sizeof(time.Month(0)), unsafe.Alignof(time.Month(0))),
+		"time.Weekday":    layout(unsafe.Sizeof(time.Weekday(0)), unsafe.Alignof(time.Weekday(0))),
+		"time.Location":   layout(unsafe.Sizeof(time.Location{}), unsafe.Alignof(time.Location{})),
+		"sync.Mutex":      layout(unsafe.Sizeof(sync.Mutex{}), unsafe.Alignof(sync.Mutex{})),
+		"sync.RWMutex":    layout(unsafe.Sizeof(sync.RWMutex{}), unsafe.Alignof(sync.RWMutex{})),
+		"sync.Once":       layout(unsafe.Sizeof(sync.Once{}), unsafe.Alignof(sync.Once{})),
+		"sync.WaitGroup":  layout(unsafe.Sizeof(sync.WaitGroup{}), unsafe.Alignof(sync.WaitGroup{})),
+		"sync.Map":        layout(unsafe.Sizeof(sync.Map{}), unsafe.Alignof(sync.Map{})),
+		"sync.Pool":       layout(unsafe.Sizeof(sync.Pool{}), unsafe.Alignof(sync.Pool{})),
+		"atomic.Bool":     layout(unsafe.Sizeof(atomic.Bool{}), unsafe.Alignof(atomic.Bool{})),
+		"atomic.Int32":    layout(unsafe.Sizeof(atomic.Int32{}), unsafe.Alignof(atomic.Int32{})),
+		"atomic.Uint32":   layout(unsafe.Sizeof(atomic.Uint32{}), unsafe.Alignof(atomic.Uint32{})),
+		"atomic.Int64":    layout(unsafe.Sizeof(atomic.Int64{}), unsafe.Alignof(atomic.Int64{})),
+		"atomic.Uint64":   layout(unsafe.Sizeof(atomic.Uint64{}), unsafe.Alignof(atomic.Uint64{})),
+		"atomic.Uintptr":  layout(unsafe.Sizeof(atomic.Uintptr{}), unsafe.Alignof(atomic.Uintptr{})),
+		"atomic.Value":    layout(unsafe.Sizeof(atomic.Value{}), unsafe.Alignof(atomic.Value{})),
+		"bytes.Buffer":    layout(unsafe.Sizeof(bytes.Buffer{}), unsafe.Alignof(bytes.Buffer{})),
+		"strings.Builder": layout(unsafe.Sizeof(strings.Builder{}), unsafe.Alignof(strings.Builder{})),
+		"context.Context": layout(unsafe.Sizeof(*(*context.Context)(nil)), unsafe.Alignof(*(*context.Context)(nil))),
+		"io.Reader":       layout(unsafe.Sizeof(*(*io.Reader)(nil)), unsafe.Alignof(*(*io.Reader)(nil))),
+		"io.Writer":       layout(unsafe.Sizeof(*(*io.Writer)(nil)), unsafe.Alignof(*(*io.Writer)(nil))),
+		"reflect.Type":    layout(unsafe.Sizeof(*(*reflect.Type)(nil)), unsafe.Alignof(*(*reflect.Type)(nil))),
+		"unsafe.Pointer":  layout(unsafe.Sizeof(unsafe.Pointer(nil)), unsafe.Alignof(unsafe.Pointer(nil))),
+		"net.IP":          layout(unsafe.Sizeof(net.IP{}), unsafe.Alignof(net.IP{})),
+		"http.Header":     layout(unsafe.Sizeof(http.Header{}), unsafe.Alignof(http.Header{})),
+		"json.RawMessage": layout(unsafe.Sizeof(json.RawMessage{}), unsafe.Alignof(json.RawMessage{})),
+	}
+	for _, name := range StdlibTypeNames() {
+		if _, exists := actual[name]; !exists {
+			t.Errorf("layout of '%s' is not compared with actual one", name)
+		}
+	}
+	for name, expected := range actual {
+		typ, err := ParseCode(name)
+		if err != nil {
+			t.Errorf("failed to parse type '%s', reason -> %s", name, err.Error())
+			continue
+		}
+		if typ.Name != name || typ.Sizeof != uint64(expected[0]) || typ.Alignof != uint64(expected[1]) {
+			t.Errorf(
+				"invalid layout of '%s'\n\texpected: size %d, align %d\n\tactual: %s (size %d, align %d)",
+				name, expected[0], expected[1], typ.Name, typ.Sizeof, typ.Alignof,
+			)
+		}
+	}
+}
+
+func TestStdlibTypesArch(t *testing.T) {
+	typ, err := ParseCodeArch(
+		`struct{t time.Time; mu sync.Mutex; wg sync.WaitGroup}`, Archs["386"],
+	)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	// 64-bit atomic counter of WaitGroup is aligned to 8 bytes on 386 too
+	if typ.Sizeof != 48 || typ.Alignof != 8 || typ.Fields[2].Offset != 32 {
+		t.Errorf("invalid layout on 386: size %d, align %d, offset of 'wg' %d",
+			typ.Sizeof, typ.Alignof, typ.Fields[2].Offset)
+	}
+
+	_, err = ParseDeclsContext(context.Background(), `type A struct{b big.Int}`, Archs[DefaultArch])
+	if err == nil || !strings.Contains(err.Error(), "unknown type 'big.Int'") ||
+		!err.(*TypeError).Unsupported {
+		t.Errorf("invalid error of unknown standard library type: %v", err)
+	}
+}
+
+func TestStdlibType(t *testing.T) {
+	for _, c := range []struct {
+		pkg, name, arch string
+		size, align     uint64
+	}{
+		{"time", "Time", "amd64", 24, 8},
+		{"time", "Time", "386", 20, 4},
+		{"atomic", "Int64", "386", 8, 8},
+		{"sync", "Mutex", "arm", 8, 4},
+	} {
+		typ, err := StdlibType(c.pkg, c.name, Archs[c.arch])
+		if err != nil || typ == nil {
+			t.Errorf("failed to lay out %s.%s, reason -> %v", c.pkg, c.name, err)
+			continue
+		}
+		if typ.Name != c.pkg+"."+c.name || typ.Sizeof != c.size || typ.Alignof != c.align {
+			t.Errorf("invalid layout of %s.%s on %s: size %d, align %d",
+				c.pkg, c.name, c.arch, typ.Sizeof, typ.Alignof)
+		}
+	}
+	for _, name := range [][2]string{{"time", "Timer"}, {"os", "File"}, {"", ""}} {
+		if typ, err := StdlibType(name[0], name[1], Archs[DefaultArch]); typ != nil || err != nil {
+			t.Errorf("unexpected layout of unknown type %s.%s: %+v, %v", name[0], name[1], typ, err)
+		}
+	}
+}
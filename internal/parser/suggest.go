@@ -0,0 +1,172 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Strategies of reordering struct fields, which can be given to
+// SuggestLayoutWith.
+const (
+	// Fields are sorted by descending alignment
+	StrategyGreedy = "greedy"
+	// All orders of fields are tried, and the smallest one closest to
+	// original order is chosen
+	StrategyOptimal = "optimal"
+	// Fields keep original order, unless other field fills padding before them
+	StrategyMinChurn = "minchurn"
+)
+
+// Strategies lists all strategies of reordering struct fields.
+var Strategies = []string{StrategyGreedy, StrategyOptimal, StrategyMinChurn}
+
+// The largest number of fields which StrategyOptimal tries all orders of.
+const maxOptimalFields = 8
+
+// SuggestLayout returns copy of given struct with fields reordered by
+// descending alignment, which leaves no padding between them. Zero sized
+// fields are moved to the front. If keepGroups is set, fields are reordered
+// only within their groups, and groups keep their order.
+func SuggestLayout(strct *TypeInfo, keepGroups bool) *TypeInfo {
+	suggested := *strct
+	suggested.Fields = make([]*TypeInfo, len(strct.Fields))
+	for i, field := range strct.Fields {
+		f := *field
+		suggested.Fields[i] = &f
+	}
+	fields := suggested.Fields
+	sort.SliceStable(fields, func(i, j int) bool {
+		if keepGroups && fields[i].Group != fields[j].Group {
+			return fields[i].Group < fields[j].Group
+		}
+		if (fields[i].Sizeof == 0) != (fields[j].Sizeof == 0) {
+			return fields[i].Sizeof == 0
+		}
+		return fields[i].Alignof > fields[j].Alignof
+	})
+	layoutStruct(&suggested)
+	return &suggested
+}
+
+// SuggestLayoutWith returns copy of given struct with fields reordered by
+// given strategy, and strategy which is actually used. StrategyOptimal falls
+// back to StrategyGreedy for structs of more than maxOptimalFields fields, and
+// so does StrategyMinChurn if it leaves more padding than greedy order. If
+// keepGroups is set, fields are reordered only within their groups, and
+// groups keep their order.
+func SuggestLayoutWith(strct *TypeInfo, keepGroups bool, strategy string) (*TypeInfo, string, error) {
+	greedy := SuggestLayout(strct, keepGroups)
+	var order []int
+	switch strategy {
+	case StrategyGreedy:
+		return greedy, StrategyGreedy, nil
+	case StrategyOptimal:
+		if len(strct.Fields) > maxOptimalFields {
+			return greedy, StrategyGreedy, nil
+		}
+		order = optimalOrder(strct.Fields, keepGroups)
+	case StrategyMinChurn:
+		order = minChurnOrder(strct.Fields, keepGroups)
+	default:
+		return nil, "", fmt.Errorf("unknown strategy '%s'", strategy)
+	}
+	suggested := *strct
+	suggested.Fields = make([]*TypeInfo, len(order))
+	for i, j := range order {
+		f := *strct.Fields[j]
+		suggested.Fields[i] = &f
+	}
+	layoutStruct(&suggested)
+	if suggested.Sizeof > greedy.Sizeof {
+		return greedy, StrategyGreedy, nil
+	}
+	return &suggested, strategy, nil
+}
+
+// Helper function which returns whether field of given index can be placed
+// next: with keepGroups set, only fields of the first group which still has
+// unplaced fields can be.
+func canPlace(fields []*TypeInfo, placed []bool, i int, keepGroups bool) bool {
+	if placed[i] {
+		return false
+	}
+	if !keepGroups {
+		return true
+	}
+	for j, field := range fields {
+		if !placed[j] {
+			return field.Group == fields[i].Group
+		}
+	}
+	return false
+}
+
+// Returns indexes of given fields in the order of the smallest size, which
+// moves fields the least from their original positions among such orders.
+func optimalOrder(fields []*TypeInfo, keepGroups bool) []int {
+	var (
+		best                []int
+		bestSize, bestChurn uint64
+		order               = make([]int, 0, len(fields))
+		placed              = make([]bool, len(fields))
+	)
+	var try func(offset, align, churn uint64)
+	try = func(offset, align, churn uint64) {
+		if len(order) == len(fields) {
+			if len(fields) > 0 && fields[order[len(order)-1]].Sizeof == 0 && offset > 0 {
+				offset++
+			}
+			size := alignUp(offset, align)
+			if best == nil || size < bestSize || size == bestSize && churn < bestChurn {
+				best = append(best[:0], order...)
+				bestSize, bestChurn = size, churn
+			}
+			return
+		}
+		pos := uint64(len(order))
+		for i, field := range fields {
+			if !canPlace(fields, placed, i, keepGroups) {
+				continue
+			}
+			moved := pos - uint64(i)
+			if uint64(i) > pos {
+				moved = uint64(i) - pos
+			}
+			placed[i] = true
+			order = append(order, i)
+			try(alignUp(offset, field.Alignof)+field.Sizeof, max(align, field.Alignof), churn+moved)
+			order = order[:len(order)-1]
+			placed[i] = false
+		}
+	}
+	try(0, 1, 0)
+	return best
+}
+
+// Returns indexes of given fields in the order, where each next field is the
+// first one of original order which needs no padding before it. If there is
+// no such field, the first one of the largest alignment is placed next.
+func minChurnOrder(fields []*TypeInfo, keepGroups bool) []int {
+	order := make([]int, 0, len(fields))
+	placed := make([]bool, len(fields))
+	offset := uint64(0)
+	for len(order) < len(fields) {
+		next := -1
+		for i, field := range fields {
+			if !canPlace(fields, placed, i, keepGroups) {
+				continue
+			}
+			if alignUp(offset, field.Alignof) == offset {
+				next = i
+				break
+			}
+			if next < 0 || field.Alignof > fields[next].Alignof {
+				next = i
+			}
+		}
+		placed[next] = true
+		order = append(order, next)
+		offset = alignUp(offset, fields[next].Alignof) + fields[next].Sizeof
+	}
+	return order
+}
@@ -0,0 +1,100 @@
+package parser
+
+import "testing"
+
+func TestSuggestLayout(t *testing.T) {
+	code := `struct {
+	a bool
+	b int64
+
+	c bool
+	d int64
+	e struct{}
+}`
+	typ, err := ParseCode(code)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	if typ.Sizeof != 40 {
+		t.Fatalf("invalid sizeof, expected: 40, actual: %d", typ.Sizeof)
+	}
+
+	cases := []struct {
+		keepGroups bool
+		order      string
+		size       uint64
+	}{
+		{false, "ebdac", 24},
+		{true, "baedc", 32},
+	}
+	for _, c := range cases {
+		suggested := SuggestLayout(typ, c.keepGroups)
+		order := ""
+		for _, field := range suggested.Fields {
+			order += field.FieldName
+		}
+		if order != c.order || suggested.Sizeof != c.size {
+			t.Errorf(
+				"invalid suggested layout (keepGroups: %v)\n\texpected: %s (%d)\n\tactual: %s (%d)",
+				c.keepGroups, c.order, c.size, order, suggested.Sizeof,
+			)
+		}
+	}
+	if typ.Fields[1].FieldName != "b" || typ.Fields[1].Offset != 8 {
+		t.Errorf("original struct was modified")
+	}
+	if typ.Fields[3].Source != "d int64" || typ.Fields[3].Group != 1 {
+		t.Errorf("invalid source or group of field 'd': '%s', %d",
+			typ.Fields[3].Source, typ.Fields[3].Group)
+	}
+}
+
+func TestSuggestLayoutWith(t *testing.T) {
+	fieldOrder := func(typ *TypeInfo) string {
+		order := ""
+		for _, field := range typ.Fields {
+			order += field.FieldName
+		}
+		return order
+	}
+	cases := []struct {
+		code, strategy string
+		keepGroups     bool
+		used, order    string
+		size           uint64
+	}{
+		{"struct{a int64; b bool; c int64; d bool}", StrategyGreedy, false, StrategyGreedy, "acbd", 24},
+		// The smallest order which moves fields the least
+		{"struct{a int64; b bool; c int64; d bool}", StrategyOptimal, false, StrategyOptimal, "abdc", 24},
+		{"struct{a int64; b bool; c int64; d bool}", StrategyMinChurn, false, StrategyMinChurn, "abdc", 24},
+		// Minimal churn order leaves more padding than greedy one
+		{"struct{a bool; b int32; c int16; d int64}", StrategyMinChurn, false, StrategyGreedy, "dbca", 16},
+		{"struct{a bool; b int32; c int16; d int64}", StrategyOptimal, false, StrategyOptimal, "acbd", 16},
+		// Too many fields to try all orders
+		{"struct{a, b, c, d, e, f, g, h bool; i int64}", StrategyOptimal, false, StrategyGreedy, "iabcdefgh", 16},
+		// Greedy order within groups takes 32 bytes
+		{"struct{a bool; b int64\n\nc bool; d int64; e struct{}}", StrategyOptimal, true, StrategyOptimal, "baced", 24},
+		{"struct{a bool; b int64\n\nc bool; d int64; e struct{}}", StrategyMinChurn, true, StrategyMinChurn, "abced", 32},
+	}
+	for _, c := range cases {
+		typ, err := ParseCode(c.code)
+		if err != nil {
+			t.Fatalf("failed to parse code, reason -> %s", err.Error())
+		}
+		suggested, used, err := SuggestLayoutWith(typ, c.keepGroups, c.strategy)
+		if err != nil {
+			t.Errorf("%s (%s): unexpected error -> %s", c.code, c.strategy, err.Error())
+			continue
+		}
+		if used != c.used || fieldOrder(suggested) != c.order || suggested.Sizeof != c.size {
+			t.Errorf("%s (%s, keepGroups: %v)\n\texpected: %s %s (%d)\n\tactual: %s %s (%d)",
+				c.code, c.strategy, c.keepGroups, c.used, c.order, c.size,
+				used, fieldOrder(suggested), suggested.Sizeof)
+		}
+	}
+
+	typ, _ := ParseCode("struct{a bool}")
+	if _, _, err := SuggestLayoutWith(typ, false, "random"); err == nil {
+		t.Errorf("expected error of unknown strategy")
+	}
+}
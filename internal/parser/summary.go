@@ -0,0 +1,39 @@
+package parser
+
+// FieldCount returns number of fields declared in struct (fields declared
+// together like "a, b int" are counted separately), or 0 for other types.
+func (typ *TypeInfo) FieldCount() int {
+	return len(typ.Fields)
+}
+
+// LargestAlignField returns the first struct field of the largest alignment,
+// which dictates alignment of struct, or nil if type has no fields.
+func (typ *TypeInfo) LargestAlignField() *TypeInfo {
+	var largest *TypeInfo
+	for _, field := range typ.Fields {
+		if largest == nil || field.Alignof > largest.Alignof {
+			largest = field
+		}
+	}
+	return largest
+}
+
+// TotalPadding returns number of padding bytes inserted between struct
+// fields and at the end of struct. Padding of nested structs is not counted,
+// as it is part of their size.
+func (typ *TypeInfo) TotalPadding() uint64 {
+	padding := typ.TrailingPadding
+	for _, field := range typ.Fields {
+		padding += field.Padding
+	}
+	return padding
+}
+
+// PaddingPercent returns total padding as percentage of type size, or 0 for
+// zero sized type.
+func (typ *TypeInfo) PaddingPercent() float64 {
+	if typ.Sizeof == 0 {
+		return 0
+	}
+	return float64(typ.TotalPadding()) * 100 / float64(typ.Sizeof)
+}
@@ -0,0 +1,45 @@
+package parser
+
+import "testing"
+
+func TestStructSummary(t *testing.T) {
+	for code, expected := range map[string]struct {
+		fields  int
+		largest string
+		padding uint64
+	}{
+		`struct{}`:                                  {0, "", 0},
+		`struct{a bool; b int64; c int16}`:          {3, "b", 13},
+		`struct{a, b int32; c int8}`:                {3, "a", 3},
+		`struct{a bool; s struct{x int32; y bool}}`: {2, "s", 3},
+		`struct{a int64; b bool; c struct{}}`:       {3, "a", 7},
+		`struct{a [3]byte; b [2]int16}`:             {2, "b", 1},
+	} {
+		typ, err := ParseCode(code)
+		if err != nil {
+			t.Fatalf("failed to parse '%s', reason -> %s", code, err.Error())
+		}
+		largest := ""
+		if field := typ.LargestAlignField(); field != nil {
+			largest = field.FieldName
+		}
+		if typ.FieldCount() != expected.fields || largest != expected.largest ||
+			typ.TotalPadding() != expected.padding {
+			t.Errorf("invalid summary of '%s': %d fields, largest '%s', padding %d",
+				code, typ.FieldCount(), largest, typ.TotalPadding())
+		}
+		// Padding takes all of size which fields do not
+		fieldsSize := uint64(0)
+		for _, field := range typ.Fields {
+			fieldsSize += field.Sizeof
+		}
+		if typ.TotalPadding() != typ.Sizeof-fieldsSize {
+			t.Errorf("padding %d of '%s' differs from size %d minus sizes of fields %d",
+				typ.TotalPadding(), code, typ.Sizeof, fieldsSize)
+		}
+		if percent := float64(typ.TotalPadding()) * 100 / float64(typ.Sizeof); typ.Sizeof > 0 &&
+			typ.PaddingPercent() != percent {
+			t.Errorf("invalid padding percent of '%s': %f", code, typ.PaddingPercent())
+		}
+	}
+}
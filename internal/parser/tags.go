@@ -0,0 +1,147 @@
+package parser
+
+import (
+	"fmt"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// TagWarnings returns warnings about common mistakes in struct tags of fields
+// of given struct and its nested structs: malformed tag syntax, key repeated
+// in the same tag, tag of blank field (which is never encoded), and the same
+// JSON key used by several fields, so that some of them are not encoded.
+func TagWarnings(strct *TypeInfo) []string {
+	if !strct.IsStruct {
+		return nil
+	}
+	var warnings []string
+	warn := func(field *TypeInfo, format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf("line %d: field '%s' ", field.Line, field.FieldName)+
+			fmt.Sprintf(format, args...))
+	}
+	// Fields by JSON keys they are encoded with
+	jsonKeys := make(map[string]*TypeInfo)
+	tagged := make(map[string]bool)
+	for _, field := range strct.Fields {
+		if field.IsStruct {
+			warnings = append(warnings, TagWarnings(field)...)
+		}
+		keys, err := parseTag(field.Tag)
+		if err != nil {
+			warn(field, "has malformed tag `%s`: %s", field.Tag, err.Error())
+			continue
+		}
+		if field.Tag != "" && field.FieldName == "_" {
+			warn(field, "is blank, so its tag `%s` is ignored", field.Tag)
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, kv := range keys {
+			if seen[kv[0]] {
+				warn(field, "has key '%s' repeated in tag `%s`", kv[0], field.Tag)
+			}
+			seen[kv[0]] = true
+		}
+		// Unexported fields are not encoded, while fields of embedded
+		// structs are promoted, which is not tracked
+		if !token.IsExported(field.FieldName) {
+			continue
+		}
+		value := lookupTag(keys, "json")
+		if value == "-" {
+			continue
+		}
+		if i := strings.Index(value, ","); i >= 0 {
+			value = value[:i]
+		}
+		switch {
+		case value != "":
+			addJSONKey(jsonKeys, tagged, value, field, true, warn)
+		case !field.Embedded:
+			addJSONKey(jsonKeys, tagged, field.FieldName, field, false, warn)
+		}
+	}
+	return warnings
+}
+
+// Helper function which remembers JSON key of given field, and reports
+// field which already uses this key. Field with key given in tag shadows
+// field named by the same key without tag.
+func addJSONKey(
+	keys map[string]*TypeInfo,
+	tagged map[string]bool,
+	key string,
+	field *TypeInfo,
+	isTagged bool,
+	warn func(*TypeInfo, string, ...interface{}),
+) {
+	prev, exists := keys[key]
+	switch {
+	case !exists:
+		keys[key], tagged[key] = field, isTagged
+	case isTagged && !tagged[key]:
+		warn(prev, "is shadowed by field '%s' with JSON key '%s'", field.FieldName, key)
+		keys[key], tagged[key] = field, isTagged
+	case !isTagged && tagged[key]:
+		warn(field, "is shadowed by field '%s' with JSON key '%s'", prev.FieldName, key)
+	default:
+		warn(field, "has JSON key '%s' of field '%s', so neither of them is encoded",
+			key, prev.FieldName)
+	}
+}
+
+// Returns key-value pairs of struct tag of conventional format
+// `key:"value" key2:"value2"`, or error if tag does not conform to it.
+func parseTag(tag string) ([][2]string, error) {
+	var keys [][2]string
+	for tag != "" {
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			break
+		}
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 {
+			return nil, fmt.Errorf("key expected at '%s'", tag)
+		}
+		if i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return nil, fmt.Errorf("value of key '%s' must be quoted and follow colon", tag[:i])
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+		// Scan quoted value until closing quote
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return nil, fmt.Errorf("value of key '%s' is not terminated", key)
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			return nil, fmt.Errorf("value of key '%s' is not valid quoted string", key)
+		}
+		keys = append(keys, [2]string{key, value})
+		tag = tag[i+1:]
+		if tag != "" && tag[0] != ' ' {
+			return nil, fmt.Errorf("key-value pairs must be separated by spaces")
+		}
+	}
+	return keys, nil
+}
+
+// Returns value of given key of parsed struct tag, or empty string.
+func lookupTag(keys [][2]string, key string) string {
+	for _, kv := range keys {
+		if kv[0] == key {
+			return kv[1]
+		}
+	}
+	return ""
+}
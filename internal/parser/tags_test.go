@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTagWarnings(t *testing.T) {
+	types, err := ParseDecls("type A struct {\n"+
+		"\tID    int    `json:\"id\"`\n"+
+		"\tName  string `json:\"name\"`\n"+
+		"\tAlias string `json:\"name,omitempty\"`\n"+
+		"\tid    int    `json:\"id\"`\n"+
+		"\tKey   string\n"+
+		"\tOther string `json:\"Key\"`\n"+
+		"\tSkip  string `json:\"-\"`\n"+
+		"\tDash  string `json:\"-,\"`\n"+
+		"\tBad   int    `json:id`\n"+
+		"\tTwice int    `json:\"twice\" json:\"again\"`\n"+
+		"\t_     [4]byte `json:\"pad\"`\n"+
+		"\tIn    struct{ X, Y int `json:\"x\"` }\n"+
+		"}", Archs[DefaultArch])
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	expected := []string{
+		"line 4: field 'Alias' has JSON key 'name' of field 'Name', so neither of them is encoded",
+		"line 6: field 'Key' is shadowed by field 'Other' with JSON key 'Key'",
+		"line 10: field 'Bad' has malformed tag `json:id`: value of key 'json' must be quoted and follow colon",
+		"line 11: field 'Twice' has key 'json' repeated in tag `json:\"twice\" json:\"again\"`",
+		"line 12: field '_' is blank, so its tag `json:\"pad\"` is ignored",
+		"line 13: field 'Y' has JSON key 'x' of field 'X', so neither of them is encoded",
+	}
+	warnings := TagWarnings(types[0].Type)
+	if strings.Join(warnings, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("invalid warnings\n\texpected: %q\n\tactual: %q", expected, warnings)
+	}
+
+	for tag, valid := range map[string]bool{
+		`json:"a" xml:"b"`:   true,
+		`json:"a\"b"`:        true,
+		`json:"a"xml:"b"`:    false,
+		`json:"a`:            false,
+		`:"a"`:               false,
+		` json:"a"  xml:"" `: true,
+	} {
+		if _, err = parseTag(tag); (err == nil) != valid {
+			t.Errorf("tag `%s` expected to be valid: %t, error: %v", tag, valid, err)
+		}
+	}
+}
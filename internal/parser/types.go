@@ -1,45 +1,105 @@
 package parser
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	. "go/ast"
 	. "go/parser"
 	"go/token"
-	"reflect"
+	"math"
 	"strconv"
 	"strings"
-	"unsafe"
 )
 
-var BasicSizes = map[string]uint64{
-	"bool":       uint64(unsafe.Sizeof(true)),
-	"int8":       uint64(unsafe.Sizeof(int8(0))),
-	"uint8":      uint64(unsafe.Sizeof(uint8(0))),
-	"byte":       uint64(unsafe.Sizeof(byte(0))),
-	"int16":      uint64(unsafe.Sizeof(int16(0))),
-	"uint16":     uint64(unsafe.Sizeof(uint16(0))),
-	"int32":      uint64(unsafe.Sizeof(int32(0))),
-	"uint32":     uint64(unsafe.Sizeof(uint32(0))),
-	"rune":       uint64(unsafe.Sizeof(rune(0))),
-	"float32":    uint64(unsafe.Sizeof(float32(0))),
-	"int":        uint64(unsafe.Sizeof(int(0))),
-	"uint":       uint64(unsafe.Sizeof(uint(0))),
-	"int64":      uint64(unsafe.Sizeof(int64(0))),
-	"uint64":     uint64(unsafe.Sizeof(uint64(0))),
-	"float64":    uint64(unsafe.Sizeof(float64(0))),
-	"uintptr":    uint64(unsafe.Sizeof(uintptr(0))),
-	"complex64":  uint64(unsafe.Sizeof(complex64(0))),
-	"complex128": uint64(unsafe.Sizeof(complex128(0))),
-	"string":     uint64(unsafe.Sizeof("")),
-}
-
-var FixedSizes = map[string]uint64{
-	"ptr":   uint64(unsafe.Sizeof(&struct{}{})),
-	"map":   uint64(unsafe.Sizeof(map[bool]bool{})),
-	"slice": uint64(unsafe.Sizeof([]struct{}{})),
-	"chan":  uint64(unsafe.Sizeof(make(chan struct{}))),
-	"func":  uint64(unsafe.Sizeof(func() {})),
+// Arch describes sizes which differ between target architectures.
+type Arch struct {
+	Name     string
+	PtrSize  uint64
+	MaxAlign uint64
+	// Types declared outside of parsed source, set by WithExternalTypes()
+	External map[string]ExternalType
+}
+
+// Supported target architectures. Values follow sizes used by gc compiler.
+var Archs = map[string]*Arch{
+	"amd64": {Name: "amd64", PtrSize: 8, MaxAlign: 8},
+	"386":   {Name: "386", PtrSize: 4, MaxAlign: 4},
+	"arm":   {Name: "arm", PtrSize: 4, MaxAlign: 4},
+	"arm64": {Name: "arm64", PtrSize: 8, MaxAlign: 8},
+	"wasm":  {Name: "wasm", PtrSize: 8, MaxAlign: 8},
+}
+
+// Architecture used when none is given.
+const DefaultArch = "amd64"
+
+// APIVersion is version of schema of layouts reported by JSON API, which is
+// "apiVersion" field of each response. Minor version is bumped when fields
+// are added, while existing fields keep their names and meaning. Major
+// version is bumped only when fields are removed or changed incompatibly.
+const APIVersion = "2.0"
+
+// LookupArch returns target architecture by its name,
+// or default architecture if name is empty.
+func LookupArch(name string) (*Arch, error) {
+	if name == "" {
+		name = DefaultArch
+	}
+	arch, exists := Archs[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown architecture '%s'", name)
+	}
+	return arch, nil
+}
+
+// Returns size of basic type with given name on architecture.
+func (arch *Arch) basicSize(name string) (uint64, bool) {
+	switch name {
+	case "bool", "int8", "uint8", "byte":
+		return 1, true
+	case "int16", "uint16":
+		return 2, true
+	case "int32", "uint32", "rune", "float32":
+		return 4, true
+	case "int64", "uint64", "float64", "complex64":
+		return 8, true
+	case "complex128":
+		return 16, true
+	case "int", "uint", "uintptr":
+		return arch.PtrSize, true
+	}
+	return 0, false
+}
+
+// Returns alignment of basic type with given name and size on architecture.
+// Complex numbers are aligned as their real and imaginary parts.
+func (arch *Arch) basicAlign(name string, size uint64) uint64 {
+	if name == "complex64" || name == "complex128" {
+		size /= 2
+	}
+	return min(size, arch.MaxAlign)
+}
+
+// Number of machine words occupied by runtime headers of fixed sized types.
+var headerWords = map[string]uint64{
+	"pointer":   1,
+	"map":       1,
+	"channel":   1,
+	"function":  1,
+	"string":    2,
+	"interface": 2,
+	"slice":     3,
+}
+
+// Returns info of fixed sized type of given kind, which size is scaled
+// by pointer size of architecture.
+func (arch *Arch) fixedType(kind string) *TypeInfo {
+	return &TypeInfo{
+		Sizeof:  headerWords[kind] * arch.PtrSize,
+		Alignof: arch.PtrSize,
+		Name:    kind,
+		IsFixed: true,
+	}
 }
 
 var (
@@ -55,82 +115,157 @@ type TypeInfo struct {
 	IsArray  bool
 	IsStruct bool
 	Fields   []*TypeInfo
+	// Layout of type (or element of array) is given by user
+	External bool
+
+	// Field name and type name for struct fields
+	FieldName string
+	TypeName  string
+	// Offset of struct field and size of padding inserted before it
+	Offset  uint64
+	Padding uint64
+	// Size of padding at the end of struct
+	TrailingPadding uint64
+	// Source code of struct field declaration and index of group of
+	// fields (separated by blank lines) it belongs to
+	Source string
+	Group  int
+	// Line of submitted code where struct field is declared
+	Line int
+	// Struct tag of field, without quotes
+	Tag string
+	// Field is embedded, so it is named by its type
+	Embedded bool
+	// Cache lines occupied by type, set by AnnotateCacheLines()
+	FirstCacheLine   uint64
+	LastCacheLine    uint64
+	CrossesCacheLine bool
 }
 
-func parseType(n Node) (*TypeInfo, error) {
+// Source code of parsed type expression, used to extract field declarations
+// and resolve named types declared in it.
+type source struct {
+	ctx       context.Context
+	fset      *token.FileSet
+	code      string
+	arch      *Arch
+	decls     map[string]*TypeSpec
+	consts    map[string]Expr
+	resolving map[string]bool
+	resolved  map[string]*resolvedType // memoized layouts of declared types
+	scope     map[string]*TypeInfo     // type arguments bound to parameters
+	header    int                      // number of lines prepended to submitted code
+	nodes     int                      // number of laid out types and fields
+}
+
+// Largest number of types and fields laid out for single source, so that
+// nested declarations, which expand exponentially, cannot exhaust memory.
+const maxNodes = 1 << 16
+
+// ErrTooComplex is error of source, which layout has more types and fields
+// than the parser lays out.
+var ErrTooComplex = fmt.Errorf(
+	"types are too complex, layout exceeds limit of %d fields", maxNodes,
+)
+
+// Helper function which counts given number of laid out types and fields,
+// returning ErrTooComplex when there are too many of them.
+func (src *source) count(n int) error {
+	src.nodes += n
+	if src.nodes > maxNodes {
+		return ErrTooComplex
+	}
+	return nil
+}
+
+// Returns source code and line numbers of given node.
+func (src *source) node(n Node) (code string, first, last int) {
+	from, to := src.fset.Position(n.Pos()), src.fset.Position(n.End())
+	return src.code[from.Offset:to.Offset], from.Line, to.Line
+}
+
+func parseType(n Node, src *source) (*TypeInfo, error) {
+	if err := src.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := src.count(1); err != nil {
+		return nil, err
+	}
+	arch := src.arch
 	switch node := n.(type) {
 	case *Ident:
-		size, exists := BasicSizes[node.Name]
+		if typ, bound := src.scope[node.Name]; bound {
+			if err := src.count(typ.nodeCount()); err != nil {
+				return nil, err
+			}
+			return typ.clone(), nil
+		}
+		switch node.Name {
+		case "string":
+			return arch.fixedType("string"), nil
+		case "error", "any":
+			typ := arch.fixedType("interface")
+			typ.Name = node.Name
+			return typ, nil
+		}
+		size, exists := arch.basicSize(node.Name)
 		if !exists {
-			return nil, fmt.Errorf("unknown type '%s'", node.Name)
+			typ, declared, err := src.resolve(node.Name, nil)
+			if !declared {
+				if typ, given := arch.externalType(node.Name); given {
+					return typ, nil
+				}
+				return nil, fmt.Errorf("unknown type '%s'", node.Name)
+			}
+			return typ, err
 		}
 		return &TypeInfo{
 			Sizeof:  size,
-			Alignof: min(size, BasicSizes["uintptr"]),
+			Alignof: arch.basicAlign(node.Name, size),
 			Name:    node.Name,
 			IsFixed: true,
 		}, nil
-	case *StarExpr: // todo: maybe more deep checking?
-		return &TypeInfo{
-			Sizeof:  FixedSizes["ptr"],
-			Alignof: min(FixedSizes["ptr"], BasicSizes["uintptr"]),
-			Name:    "pointer",
-			IsFixed: true,
-		}, nil
+	case *IndexExpr:
+		return src.instantiateExpr(node.X, []Expr{node.Index})
+	case *IndexListExpr:
+		return src.instantiateExpr(node.X, node.Indices)
+	case *SelectorExpr:
+		return src.stdlibType(node)
+	case *StarExpr:
+		// Pointer is sized as pointer word regardless of pointee, which
+		// is not resolved, so self-referential types have finite size.
+		return arch.fixedType("pointer"), nil
 	case *MapType:
-		return &TypeInfo{
-			Sizeof:  FixedSizes["map"],
-			Alignof: min(FixedSizes["map"], BasicSizes["uintptr"]),
-			Name:    "map",
-			IsFixed: true,
-		}, nil
+		return arch.fixedType("map"), nil
 	case *ChanType:
-		return &TypeInfo{
-			Sizeof:  FixedSizes["chan"],
-			Alignof: min(FixedSizes["chan"], BasicSizes["uintptr"]),
-			Name:    "channel",
-			IsFixed: true,
-		}, nil
+		return arch.fixedType("channel"), nil
 	case *FuncLit:
-		return &TypeInfo{
-			Sizeof:  FixedSizes["func"],
-			Alignof: min(FixedSizes["func"], BasicSizes["uintptr"]),
-			Name:    "function",
-			IsFixed: true,
-		}, nil
+		return arch.fixedType("function"), nil
 	case *FuncType:
-		return &TypeInfo{
-			Sizeof:  FixedSizes["func"],
-			Alignof: min(FixedSizes["func"], BasicSizes["uintptr"]),
-			Name:    "function",
-			IsFixed: true,
-		}, nil
+		return arch.fixedType("function"), nil
+	case *InterfaceType:
+		return arch.fixedType("interface"), nil
 	case *ArrayType:
 		if node.Len == nil {
-			return &TypeInfo{
-				Sizeof:  FixedSizes["slice"],
-				Alignof: min(FixedSizes["slice"], BasicSizes["uintptr"]),
-				Name:    "slice",
-				IsFixed: true,
-			}, nil
+			return arch.fixedType("slice"), nil
 		}
-		len, ok := node.Len.(*BasicLit)
-		if !ok || len.Kind != token.INT {
-			return nil, errInvalidArrayLength
-		}
-		num, err := strconv.ParseUint(len.Value, 10, 64)
+		num, err := src.arrayLength(node.Len)
 		if err != nil {
-			return nil, errInvalidArrayLength
+			return nil, err
 		}
-		typ, err := parseType(node.Elt)
+		typ, err := parseType(node.Elt, src)
 		if err != nil {
 			return nil, err
 		}
+		if typ.Sizeof > 0 && num > math.MaxUint64/typ.Sizeof {
+			return nil, errArrayTooLarge
+		}
 		return &TypeInfo{
-			Sizeof:  num * typ.Sizeof,
-			Alignof: typ.Alignof,
-			Name:    "array",
-			IsArray: true,
+			Sizeof:   num * typ.Sizeof,
+			Alignof:  typ.Alignof,
+			Name:     "array",
+			IsArray:  true,
+			External: typ.External,
 		}, nil
 	case *StructType:
 		strct := &TypeInfo{
@@ -141,47 +276,103 @@ func parseType(n Node) (*TypeInfo, error) {
 		if len(node.Fields.List) < 1 {
 			return strct, nil
 		}
-		strct.Fields = make([]*TypeInfo, len(node.Fields.List))
+		strct.Fields = make([]*TypeInfo, 0, len(node.Fields.List))
+		group, prevLine := 0, 0
 		for i, field := range node.Fields.List {
-			typ, err := parseType(field.Type)
-			if err != nil {
-				return nil, err
-			}
-			if len(field.Names) > 0 {
-				typ.Name = field.Names[0].Name + " " + typ.Name
+			code, first, last := src.node(field)
+			tag := ""
+			if field.Tag != nil {
+				tag, _ = strconv.Unquote(field.Tag.Value)
 			}
-			if typ.Alignof > strct.Alignof {
-				strct.Alignof = typ.Alignof
+			if i > 0 && first > prevLine+1 {
+				group++
 			}
-			strct.Fields[i] = typ
-		}
-		num, size := uint64(0), uint64(0)
-		for _, typ := range strct.Fields {
-			if typ.Sizeof == 0 {
+			prevLine = last
+			if len(field.Names) < 1 {
+				typ, err := parseType(field.Type, src)
+				if err != nil {
+					return nil, err
+				}
+				typ.Source, typ.Group, typ.Tag = code, group, tag
+				typ.Line = first - src.header
+				typ.TypeName = typ.Name
+				// Embedded field is named by its type
+				typ.FieldName = embeddedName(field.Type)
+				typ.Embedded = true
+				if typ.FieldName != typ.TypeName {
+					typ.Name = typ.FieldName + " " + typ.TypeName
+				}
+				strct.Fields = append(strct.Fields, typ)
 				continue
 			}
-			n := typ.Sizeof / typ.Alignof
-			for i := uint64(0); i < n; i++ {
-				size += typ.Alignof
-				if size <= strct.Alignof {
-					continue
+			// Field declaration with several names ("a, b int")
+			// results in separate field for each name.
+			typeCode, _, _ := src.node(field.Type)
+			for _, name := range field.Names {
+				typ, err := parseType(field.Type, src)
+				if err != nil {
+					return nil, err
 				}
-				size = typ.Alignof % strct.Alignof
-				num++
-				if typ.Alignof == strct.Alignof {
-					num++
+				typ.Source, typ.Group, typ.Tag = code, group, tag
+				typ.Line = first - src.header
+				if len(field.Names) > 1 {
+					typ.Source = name.Name + " " + typeCode
 				}
+				typ.TypeName = typ.Name
+				typ.FieldName = name.Name
+				typ.Name = name.Name + " " + typ.Name
+				strct.Fields = append(strct.Fields, typ)
 			}
 		}
-		if size > 0 {
-			num++
-		}
-		strct.Sizeof = num * strct.Alignof
+		layoutStruct(strct)
 		return strct, nil
 	default:
-		//return nil, errInvalidType
-		return nil, fmt.Errorf("%v", reflect.TypeOf(n))
+		return nil, &unsupportedError{node: n}
+	}
+}
+
+// Returns name of embedded field of given type.
+func embeddedName(n Node) string {
+	switch node := n.(type) {
+	case *Ident:
+		return node.Name
+	case *StarExpr:
+		return embeddedName(node.X)
+	case *SelectorExpr:
+		return node.Sel.Name
 	}
+	return ""
+}
+
+// Computes alignment, size and field offsets of given struct
+// in order of its fields.
+func layoutStruct(strct *TypeInfo) {
+	strct.Alignof = 1 // empty struct has unsafe.Alignof() == 1
+	offset := uint64(0)
+	for _, typ := range strct.Fields {
+		if typ.Alignof > strct.Alignof {
+			strct.Alignof = typ.Alignof
+		}
+		typ.Offset = alignUp(offset, typ.Alignof)
+		typ.Padding = typ.Offset - offset
+		offset = typ.Offset + typ.Sizeof
+	}
+	end := offset
+	// Like gc compiler, pad struct which ends with zero sized field,
+	// so that address of that field does not point past the struct.
+	if n := len(strct.Fields); n > 0 && strct.Fields[n-1].Sizeof == 0 && offset > 0 {
+		offset++
+	}
+	strct.Sizeof = alignUp(offset, strct.Alignof)
+	strct.TrailingPadding = strct.Sizeof - end
+}
+
+// alignUp rounds given offset up to the nearest multiple of given alignment.
+func alignUp(offset, align uint64) uint64 {
+	if align < 2 {
+		return offset
+	}
+	return (offset + align - 1) / align * align
 }
 
 func min(x, y uint64) uint64 {
@@ -191,18 +382,43 @@ func min(x, y uint64) uint64 {
 	return y
 }
 
+func max(x, y uint64) uint64 {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+// ParseCode parses given type expression and computes its layout
+// on default architecture.
 func ParseCode(code string) (*TypeInfo, error) {
-	expr, err := ParseExpr(code)
+	return ParseCodeArch(code, Archs[DefaultArch])
+}
+
+// ParseCodeArch parses given type expression and computes its layout
+// on given architecture.
+func ParseCodeArch(code string, arch *Arch) (*TypeInfo, error) {
+	return parseCode(context.Background(), code, arch)
+}
+
+func parseCode(ctx context.Context, code string, arch *Arch) (*TypeInfo, error) {
+	fset := token.NewFileSet()
+	expr, err := ParseExprFrom(fset, "", code, 0)
 	if err != nil {
 		if i := strings.Index(code, "struct"); i > -1 && strings.Contains(code, "type") {
 			code = code[i:]
-			return ParseCode(code)
+			return parseCode(ctx, code, arch)
 		}
-		return nil, fmt.Errorf("syntax error: %s", err.Error())
+		return nil, syntaxError(err, nil, 0)
 	}
-	typ, err := parseType(expr)
+	typ, err := parseType(expr, &source{
+		ctx:  ctx,
+		fset: fset,
+		code: code,
+		arch: arch,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("type error: %s", err.Error())
+		return nil, typeError("", err)
 	}
 	return typ, nil
 }
@@ -1,13 +1,29 @@
 package parser
 
 import (
+	"runtime"
 	"testing"
 	"unsafe"
 )
 
+// Returns host architecture, as expected values are taken from unsafe.
+func hostArch(t *testing.T) *Arch {
+	arch, err := LookupArch(runtime.GOARCH)
+	if err != nil {
+		t.Skipf("host architecture is not supported: %s", err.Error())
+	}
+	return arch
+}
+
 func TestTypeParsing(t *testing.T) {
+	arch := hostArch(t)
 	cases := map[string]uint64{
-		`struct{}`:  uint64(unsafe.Sizeof(struct{}{})),
+		`struct{}`: uint64(unsafe.Sizeof(struct{}{})),
+		`struct{ s []byte; m map[int]int; i interface{} }`: uint64(unsafe.Sizeof(struct {
+			s []byte
+			m map[int]int
+			i interface{}
+		}{})),
 		`[0]string`: uint64(unsafe.Sizeof([0]string{})),
 		`struct{a struct{}; b bool}`: uint64(unsafe.Sizeof(struct {
 			a struct{}
@@ -79,7 +95,7 @@ func TestTypeParsing(t *testing.T) {
 		}{})),
 	}
 	for code, size := range cases {
-		typ, err := ParseCode(code)
+		typ, err := ParseCodeArch(code, arch)
 		if err != nil {
 			t.Fatalf(
 				"failed to parse code '%s', reason -> %s",
@@ -94,3 +110,231 @@ func TestTypeParsing(t *testing.T) {
 		}
 	}
 }
+
+func TestFieldOffsets(t *testing.T) {
+	typ, err := ParseCodeArch(
+		`struct{a bool; s string; b bool; c int32; d [0]int64}`, hostArch(t),
+	)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	var v struct {
+		a bool
+		s string
+		b bool
+		c int32
+		d [0]int64
+	}
+	offsets := []uint64{
+		uint64(unsafe.Offsetof(v.a)),
+		uint64(unsafe.Offsetof(v.s)),
+		uint64(unsafe.Offsetof(v.b)),
+		uint64(unsafe.Offsetof(v.c)),
+		uint64(unsafe.Offsetof(v.d)),
+	}
+	for i, field := range typ.Fields {
+		if field.Offset != offsets[i] {
+			t.Errorf(
+				"invalid offset of field '%s'\n\texpected: %d\n\tactual: %d",
+				field.Name, offsets[i], field.Offset,
+			)
+		}
+	}
+	// Padding precedes field, after the end of previous one
+	paddings := []uint64{
+		uint64(unsafe.Offsetof(v.s) - unsafe.Offsetof(v.a) - unsafe.Sizeof(v.a)),
+		uint64(unsafe.Offsetof(v.c) - unsafe.Offsetof(v.b) - unsafe.Sizeof(v.b)),
+	}
+	if typ.Fields[1].Padding != paddings[0] || typ.Fields[3].Padding != paddings[1] {
+		t.Errorf("invalid paddings of fields 's' and 'c'\n\texpected: %d, %d\n\tactual: %d, %d",
+			paddings[0], paddings[1], typ.Fields[1].Padding, typ.Fields[3].Padding)
+	}
+	if typ.Fields[1].FieldName != "s" || typ.Fields[1].TypeName != "string" {
+		t.Errorf("invalid field and type names: '%s', '%s'",
+			typ.Fields[1].FieldName, typ.Fields[1].TypeName)
+	}
+}
+
+func TestArchSizes(t *testing.T) {
+	cases := []struct {
+		code  string
+		sizes map[string]uint64
+	}{
+		{`int`, map[string]uint64{"386": 4, "amd64": 8}},
+		{`*int`, map[string]uint64{"386": 4, "amd64": 8}},
+		{`uintptr`, map[string]uint64{"386": 4, "amd64": 8}},
+		{`string`, map[string]uint64{"386": 8, "amd64": 16}},
+		{`[]bool`, map[string]uint64{"386": 12, "amd64": 24}},
+		{`struct{a bool; b int64}`, map[string]uint64{"386": 12, "amd64": 16}},
+		{`struct{a bool; p *int; b bool}`, map[string]uint64{"386": 12, "amd64": 24}},
+		{`struct{a bool; c complex64}`, map[string]uint64{"386": 12, "amd64": 12}},
+		{`interface{}`, map[string]uint64{"386": 8, "amd64": 16}},
+		{`error`, map[string]uint64{"386": 8, "amd64": 16}},
+		{`func()`, map[string]uint64{"386": 4, "amd64": 8}},
+		{`chan int`, map[string]uint64{"386": 4, "amd64": 8}},
+		{`struct{ s []byte; m map[int]int; i interface{} }`, map[string]uint64{"386": 24, "amd64": 48}},
+		{`struct{ b bool; e error; s string }`, map[string]uint64{"386": 20, "amd64": 40}},
+	}
+	for _, c := range cases {
+		for name, size := range c.sizes {
+			arch, err := LookupArch(name)
+			if err != nil {
+				t.Fatalf("failed to lookup architecture, reason -> %s", err.Error())
+			}
+			typ, err := ParseCodeArch(c.code, arch)
+			if err != nil {
+				t.Fatalf(
+					"failed to parse code '%s', reason -> %s",
+					c.code, err.Error(),
+				)
+			}
+			if typ.Sizeof != size {
+				t.Errorf(
+					"invalid sizeof('%s') on %s\n\texpected: %d\n\tactual: %d",
+					c.code, name, size, typ.Sizeof,
+				)
+			}
+		}
+	}
+	if _, err := LookupArch("pdp11"); err == nil {
+		t.Errorf("expected error for unknown architecture")
+	}
+}
+
+func TestMultipleFieldNames(t *testing.T) {
+	typ, err := ParseCode(`struct{a, b bool; c int16}`)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	if typ.Sizeof != 4 || len(typ.Fields) != 3 {
+		t.Fatalf("invalid struct, sizeof: %d, fields: %d", typ.Sizeof, len(typ.Fields))
+	}
+	if b := typ.Fields[1]; b.FieldName != "b" || b.Offset != 1 || b.Source != "b bool" {
+		t.Errorf("invalid field 'b': %s, offset %d, source '%s'", b.Name, b.Offset, b.Source)
+	}
+}
+
+func TestArrayLayout(t *testing.T) {
+	arch := hostArch(t)
+	cases := map[string]struct{ size, align uintptr }{
+		`[4]int32`:           {unsafe.Sizeof([4]int32{}), unsafe.Alignof([4]int32{})},
+		`[2][3]int16`:        {unsafe.Sizeof([2][3]int16{}), unsafe.Alignof([2][3]int16{})},
+		`[0]byte`:            {unsafe.Sizeof([0]byte{}), unsafe.Alignof([0]byte{})},
+		`[2*8]byte`:          {16, 1},
+		`[0x10 >> 1]byte`:    {8, 1},
+		`[(3 + 1) / 2]int64`: {16, unsafe.Alignof(int64(0))},
+		`struct{a [0]int64; b bool}`: {
+			unsafe.Sizeof(struct {
+				a [0]int64
+				b bool
+			}{}),
+			unsafe.Alignof(struct {
+				a [0]int64
+				b bool
+			}{}),
+		},
+		`struct{a bool; b [0]int32; c [3]int16}`: {
+			unsafe.Sizeof(struct {
+				a bool
+				b [0]int32
+				c [3]int16
+			}{}),
+			unsafe.Alignof(struct {
+				a bool
+				b [0]int32
+				c [3]int16
+			}{}),
+		},
+	}
+	for code, c := range cases {
+		typ, err := ParseCodeArch(code, arch)
+		if err != nil {
+			t.Fatalf("failed to parse code '%s', reason -> %s", code, err.Error())
+		}
+		if typ.Sizeof != uint64(c.size) || typ.Alignof != uint64(c.align) {
+			t.Errorf(
+				"invalid layout of '%s'\n\texpected: %d (align %d)\n\tactual: %d (align %d)",
+				code, c.size, c.align, typ.Sizeof, typ.Alignof,
+			)
+		}
+	}
+
+	types, err := ParseDecls("const N, M = 4, N + 1\ntype T [M][N]int16", arch)
+	if err != nil {
+		t.Fatalf("failed to parse declarations, reason -> %s", err.Error())
+	}
+	if types[0].Type.Sizeof != 40 {
+		t.Errorf("invalid size of array with constant length: %d", types[0].Type.Sizeof)
+	}
+
+	for _, code := range []string{
+		`[1.5]byte`, `[-1]byte`, `[N]byte`, `[1 / 0]byte`, `[1 << 62][8]byte`,
+	} {
+		if _, err := ParseCodeArch(code, arch); err == nil {
+			t.Errorf("expected error for invalid array '%s'", code)
+		}
+	}
+}
+
+func TestTrailingZeroSizeField(t *testing.T) {
+	arch := hostArch(t)
+	cases := map[string]struct{ size, align uintptr }{
+		`struct{}`: {unsafe.Sizeof(struct{}{}), unsafe.Alignof(struct{}{})},
+		`struct{ x int64; _ [0]byte }`: {
+			unsafe.Sizeof(struct {
+				x int64
+				_ [0]byte
+			}{}),
+			unsafe.Alignof(struct {
+				x int64
+				_ [0]byte
+			}{}),
+		},
+		`struct{ x int32; b bool; e struct{} }`: {
+			unsafe.Sizeof(struct {
+				x int32
+				b bool
+				e struct{}
+			}{}),
+			unsafe.Alignof(struct {
+				x int32
+				b bool
+				e struct{}
+			}{}),
+		},
+		`struct{ e struct{}; x int64 }`: {
+			unsafe.Sizeof(struct {
+				e struct{}
+				x int64
+			}{}),
+			unsafe.Alignof(struct {
+				e struct{}
+				x int64
+			}{}),
+		},
+		`struct{ a, b struct{} }`: {
+			unsafe.Sizeof(struct{ a, b struct{} }{}),
+			unsafe.Alignof(struct{ a, b struct{} }{}),
+		},
+	}
+	for code, c := range cases {
+		typ, err := ParseCodeArch(code, arch)
+		if err != nil {
+			t.Fatalf("failed to parse code '%s', reason -> %s", code, err.Error())
+		}
+		if typ.Sizeof != uint64(c.size) || typ.Alignof != uint64(c.align) {
+			t.Errorf(
+				"invalid layout of '%s'\n\texpected: %d (align %d)\n\tactual: %d (align %d)",
+				code, c.size, c.align, typ.Sizeof, typ.Alignof,
+			)
+		}
+	}
+
+	typ, err := ParseCodeArch(`struct{ x int64; _ [0]byte }`, arch)
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	if typ.TrailingPadding != arch.PtrSize {
+		t.Errorf("expected trailing padding word, actual: %d", typ.TrailingPadding)
+	}
+}
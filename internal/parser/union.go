@@ -0,0 +1,46 @@
+package parser
+
+// UnionNote compares C union of declared types, which overlays them in the
+// same memory, with struct holding all of them. In Go union is usually
+// emulated by struct with the largest member and type tag. It is educational
+// only, as Go has no unions.
+type UnionNote struct {
+	Members []*NamedType
+	// Size of union is size of the largest member rounded up to the largest
+	// alignment of members
+	Size    uint64
+	Alignof uint64
+	Largest *NamedType
+	// Sum of sizes of members, and size of union with one byte tag before it
+	Sum        uint64
+	TaggedSize uint64
+	Saved      uint64
+}
+
+// AnalyzeUnion returns union note for given types, or nil if there are less
+// than two of them with known layout. Generic types are skipped.
+func AnalyzeUnion(types []*NamedType) *UnionNote {
+	note := &UnionNote{Alignof: 1}
+	for _, named := range types {
+		if named.Type == nil {
+			continue
+		}
+		note.Members = append(note.Members, named)
+		note.Sum += named.Type.Sizeof
+		if note.Largest == nil || named.Type.Sizeof > note.Largest.Type.Sizeof {
+			note.Largest = named
+		}
+		if named.Type.Alignof > note.Alignof {
+			note.Alignof = named.Type.Alignof
+		}
+	}
+	if len(note.Members) < 2 {
+		return nil
+	}
+	note.Size = alignUp(note.Largest.Type.Sizeof, note.Alignof)
+	note.TaggedSize = alignUp(1, note.Alignof) + note.Size
+	if note.Size < note.Sum {
+		note.Saved = note.Sum - note.Size
+	}
+	return note
+}
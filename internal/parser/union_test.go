@@ -0,0 +1,31 @@
+package parser
+
+import "testing"
+
+func TestAnalyzeUnion(t *testing.T) {
+	types, err := ParseDecls(`
+type A struct{ a int32; b bool }
+type B [10]byte
+type C int16
+type G[T any] struct{ v T }
+`, Archs[DefaultArch])
+	if err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	note := AnalyzeUnion(types)
+	if note == nil {
+		t.Fatalf("expected union note")
+	}
+	// B is the largest with 10 bytes, which are rounded up to alignment 4 of A
+	if len(note.Members) != 3 || note.Largest.Name != "B" || note.Size != 12 ||
+		note.Alignof != 4 || note.Sum != 20 || note.Saved != 8 || note.TaggedSize != 16 {
+		t.Errorf("invalid union note: %+v", note)
+	}
+
+	if types, err = ParseDecls(`type A int64`, Archs[DefaultArch]); err != nil {
+		t.Fatalf("failed to parse code, reason -> %s", err.Error())
+	}
+	if note = AnalyzeUnion(types); note != nil {
+		t.Errorf("expected no union note for single type, got: %+v", note)
+	}
+}
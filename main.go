@@ -1,11 +1,19 @@
 package main
 
 import (
+	"embed"
 	"os"
 
 	"github.com/chappjc/go-sizeof-webapp/app"
 )
 
+// HTML templates and static files embedded into binary, so that it does not
+// depend on working directory.
+//
+//go:embed templs pub
+var assets embed.FS
+
 func main() {
+	app.Assets = assets
 	os.Exit(app.Run())
 }